@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/objectweaver/go-sdk/jsonSchema"
@@ -13,6 +15,23 @@ type Client struct {
 	HttpClient        HttpClient
 	RequestSender     RequestSender
 	ResponseProcessor ResponseProcessor
+
+	// Scheduler optionally queues outgoing requests by Definition.Priority. Requests are sent immediately when nil.
+	Scheduler *PriorityScheduler
+
+	// RateLimiter optionally throttles outgoing requests and backs off when the server returns 429.
+	RateLimiter *RateLimiter
+
+	// Endpoints optionally replaces BaseURL with several endpoints behind circuit breakers.
+	// Requests fail over to the next available endpoint when one is unreachable.
+	Endpoints *EndpointPool
+
+	// TypedResponses converts response data to the types declared by the Definition,
+	// such as int64 for Integer and []byte for Byte, instead of leaving every number as float64.
+	TypedResponses bool
+
+	// Prices optionally prices LLM calls by model, for the cost estimate of Plan
+	Prices PriceTable
 }
 
 // HttpClient interface to abstract HTTP operations
@@ -49,17 +68,96 @@ func NewGZipClient(password, url string, client *http.Client) *Client {
 
 // SendRequest sends the prompt and definition, and returns the parsed response
 func (c *Client) SendRequest(prompt string, definition *jsonSchema.Definition) (*Response, error) {
+	return c.SendRequestContext(context.Background(), prompt, definition)
+}
+
+// SendRequestContext sends the prompt and definition once the client side scheduling allows it.
+// The context bounds how long the request may wait in the queue and on the rate limiter.
+func (c *Client) SendRequestContext(ctx context.Context, prompt string, definition *jsonSchema.Definition) (*Response, error) {
+	var priority int32
+	var model string
+	if definition != nil {
+		priority = definition.Priority
+		model = definition.Model
+	}
+
 	requestBody := &RequestBody{
 		Prompt:     prompt,
 		Definition: definition,
 	}
 
-	// Use the RequestSender to send the request
-	resp, err := c.RequestSender.SendRequestBody(c.BaseURL, c.Password, requestBody)
+	return c.send(ctx, priority, model, requestBody)
+}
+
+// SendComplexRequest sends the prompt and ComplexSystem, and returns the parsed response
+func (c *Client) SendComplexRequest(prompt string, system *jsonSchema.ComplexSystem) (*Response, error) {
+	return c.SendComplexRequestContext(context.Background(), prompt, system)
+}
+
+// SendComplexRequestContext sends the prompt and ComplexSystem, scheduled and rate limited by its root schema
+func (c *Client) SendComplexRequestContext(ctx context.Context, prompt string, system *jsonSchema.ComplexSystem) (*Response, error) {
+	if system == nil {
+		return nil, fmt.Errorf("complex system is nil")
+	}
+
+	requestBody := &RequestBody{
+		Prompt:        prompt,
+		ComplexSystem: system,
+	}
+
+	return c.send(ctx, system.RootSchema.Priority, system.RootSchema.Model, requestBody)
+}
+
+// send runs the request body through the scheduler, rate limiter and endpoint failover
+func (c *Client) send(ctx context.Context, priority int32, model string, requestBody *RequestBody) (*Response, error) {
+	release, err := c.acquire(ctx, priority)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var response *Response
+	err = c.throttled(ctx, model, func() attempt {
+		endpoint, result := c.failover(ctx, func(endpoint string) attempt {
+			// Use the RequestSender to send the request
+			resp, err := c.RequestSender.SendRequestBody(endpoint, c.Password, requestBody)
+			if err != nil {
+				return attempt{unavailable: true, err: err}
+			}
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+				resp.Body.Close()
+				return attempt{
+					retryAfter:  parseRetryAfter(resp.Header.Get("Retry-After")),
+					throttled:   resp.StatusCode == http.StatusTooManyRequests,
+					unavailable: resp.StatusCode >= http.StatusInternalServerError,
+					err:         fmt.Errorf("received non-200 response code: %d", resp.StatusCode),
+				}
+			}
+
+			// Process the response
+			if c.TypedResponses {
+				response, err = c.ResponseProcessor.ProcessTypedResponse(resp, requestBody.schema())
+			} else {
+				response, err = c.ResponseProcessor.ProcessResponse(resp)
+			}
+			return attempt{err: err}
+		})
+		if response != nil {
+			response.Endpoint = endpoint
+		}
+		return result
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Process the response
-	return c.ResponseProcessor.ProcessResponse(resp)
+	return response, nil
+}
+
+// acquire waits for the scheduler, if one is configured, and returns the function that frees the slot
+func (c *Client) acquire(ctx context.Context, priority int32) (func(), error) {
+	if c.Scheduler == nil {
+		return func() {}, nil
+	}
+	return c.Scheduler.Acquire(ctx, priority)
 }
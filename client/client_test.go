@@ -0,0 +1,61 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestRequestBodyPath(t *testing.T) {
+	tests := []struct {
+		name string
+		body RequestBody
+		want string
+	}{
+		{"definition", RequestBody{Definition: &jsonSchema.Definition{Type: jsonSchema.String}}, ObjectGenPath},
+		{"complex system", RequestBody{ComplexSystem: &jsonSchema.ComplexSystem{Name: "system"}}, ComplexGenPath},
+		{"empty", RequestBody{}, ObjectGenPath},
+	}
+	for _, tt := range tests {
+		if got := tt.body.Path(); got != tt.want {
+			t.Errorf("%s: Path() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSendComplexRequest(t *testing.T) {
+	var path string
+	var body map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"data":{"summary":"ok"},"usdCost":0.02}`))
+	}))
+	defer server.Close()
+
+	c := NewDefaultClient("token", server.URL, http.DefaultClient)
+	system := jsonSchema.ExampleComplexSystem()
+	response, err := c.SendComplexRequest("prompt", &system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != ComplexGenPath {
+		t.Errorf("sent to %s, want %s", path, ComplexGenPath)
+	}
+	if _, ok := body["complexSystem"]; !ok {
+		t.Error("request body has no complexSystem")
+	}
+	if _, ok := body["definition"]; ok {
+		t.Error("request body has a definition alongside the complexSystem")
+	}
+	if response.Data["summary"] != "ok" {
+		t.Errorf("data = %v", response.Data)
+	}
+
+	if _, err := c.SendComplexRequest("prompt", nil); err == nil {
+		t.Error("nil complex system was sent")
+	}
+}
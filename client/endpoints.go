@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoAvailableEndpoint is returned when the circuit of every endpoint is open
+var ErrNoAvailableEndpoint = errors.New("no available endpoint: all circuits are open")
+
+// CircuitState is the state of the circuit breaker in front of a single endpoint
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // requests flow normally
+	CircuitOpen     CircuitState = "open"      // requests are rejected until the open timeout has passed
+	CircuitHalfOpen CircuitState = "half_open" // trial requests decide whether the circuit closes again
+)
+
+// HealthCheckFunc probes a single endpoint and returns an error when it is unhealthy
+type HealthCheckFunc func(ctx context.Context, endpoint string) error
+
+// EndpointPool holds the ObjectWeaver endpoints a Client fails over between.
+// Endpoints are tried in the order given, so list the preferred region first.
+type EndpointPool struct {
+	// FailureThreshold is the number of consecutive failures that opens an endpoint's circuit
+	FailureThreshold int
+	// OpenTimeout is how long an open circuit rejects requests before trial requests are let through
+	OpenTimeout time.Duration
+	// HealthCheck optionally probes endpoints, see HTTPHealthCheck and GrpcHealthCheck
+	HealthCheck HealthCheckFunc
+
+	mu        sync.Mutex
+	endpoints []*endpointState
+}
+
+// endpointState tracks the circuit breaker of a single endpoint
+type endpointState struct {
+	url       string
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	lastError string
+}
+
+// EndpointStatus is a snapshot of a single endpoint's circuit
+type EndpointStatus struct {
+	URL                 string       `json:"url"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	LastError           string       `json:"lastError,omitempty"`
+}
+
+// NewEndpointPool initializes an EndpointPool opening a circuit after three consecutive failures for thirty seconds
+func NewEndpointPool(endpoints ...string) *EndpointPool {
+	pool := &EndpointPool{
+		FailureThreshold: 3,
+		OpenTimeout:      30 * time.Second,
+	}
+	for _, endpoint := range endpoints {
+		pool.endpoints = append(pool.endpoints, &endpointState{url: endpoint, state: CircuitClosed})
+	}
+	return pool
+}
+
+// Candidates returns the endpoints that may currently receive a request, healthy endpoints first
+func (p *EndpointPool) Candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var closed, trial []string
+	for _, endpoint := range p.endpoints {
+		if endpoint.state == CircuitOpen && now.Sub(endpoint.openedAt) >= p.OpenTimeout {
+			endpoint.state = CircuitHalfOpen
+		}
+		switch endpoint.state {
+		case CircuitClosed:
+			closed = append(closed, endpoint.url)
+		case CircuitHalfOpen:
+			trial = append(trial, endpoint.url)
+		}
+	}
+	return append(closed, trial...)
+}
+
+// ReportSuccess closes the circuit of the endpoint
+func (p *EndpointPool) ReportSuccess(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if state := p.find(endpoint); state != nil {
+		state.state = CircuitClosed
+		state.failures = 0
+		state.lastError = ""
+	}
+}
+
+// ReportFailure records a failed request, opening the circuit once the failure threshold is reached
+func (p *EndpointPool) ReportFailure(endpoint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state := p.find(endpoint)
+	if state == nil {
+		return
+	}
+	state.failures++
+	if err != nil {
+		state.lastError = err.Error()
+	}
+	// a failed trial reopens the circuit straight away
+	if state.state == CircuitHalfOpen || state.failures >= p.FailureThreshold {
+		state.state = CircuitOpen
+		state.openedAt = time.Now()
+	}
+}
+
+// Status returns the circuit state of every endpoint
+func (p *EndpointPool) Status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]EndpointStatus, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		statuses[i] = EndpointStatus{
+			URL:                 endpoint.url,
+			State:               endpoint.state,
+			ConsecutiveFailures: endpoint.failures,
+			LastError:           endpoint.lastError,
+		}
+	}
+	return statuses
+}
+
+// CheckHealth probes every endpoint with the HealthCheck, closing healthy circuits and opening unhealthy ones
+func (p *EndpointPool) CheckHealth(ctx context.Context) {
+	if p.HealthCheck == nil {
+		return
+	}
+	for _, status := range p.Status() {
+		err := p.HealthCheck(ctx, status.URL)
+		if err == nil {
+			p.ReportSuccess(status.URL)
+			continue
+		}
+
+		p.mu.Lock()
+		if state := p.find(status.URL); state != nil {
+			state.failures++
+			state.lastError = err.Error()
+			state.state = CircuitOpen
+			state.openedAt = time.Now()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// StartHealthChecks runs CheckHealth every interval in the background until the context is done
+func (p *EndpointPool) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			p.CheckHealth(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (p *EndpointPool) find(endpoint string) *endpointState {
+	for _, state := range p.endpoints {
+		if state.url == endpoint {
+			return state
+		}
+	}
+	return nil
+}
+
+// HTTPHealthCheck returns a HealthCheckFunc that expects a 2xx response to a GET of the endpoint joined with path
+func HTTPHealthCheck(client *http.Client, path string) HealthCheckFunc {
+	return func(ctx context.Context, endpoint string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+		if err != nil {
+			return fmt.Errorf("error creating health check request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("error sending health check request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("health check failed with status: %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// GrpcHealthCheck returns a HealthCheckFunc using the standard gRPC health checking protocol
+func GrpcHealthCheck(service string) HealthCheckFunc {
+	return func(ctx context.Context, endpoint string) error {
+		conn, err := grpc.NewClient(endpoint, grpc.WithInsecure())
+		if err != nil {
+			return fmt.Errorf("failed to connect to server: %v", err)
+		}
+		defer conn.Close()
+
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			return fmt.Errorf("failed to call health check: %w", err)
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return fmt.Errorf("endpoint is %s", resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// attempt is the outcome of sending a request to a single endpoint
+type attempt struct {
+	retryAfter  time.Duration // how long the server asked the client to back off
+	throttled   bool          // the server rejected the request because of rate limiting
+	unavailable bool          // the endpoint failed and the request may be sent to the next one
+	err         error
+}
+
+// failover sends the request to each available endpoint in turn until one of them handles it.
+// It returns the endpoint that served the request alongside the outcome. A throttled request, or one
+// ended by the caller's context, says nothing about the endpoint's health and leaves its circuit as it is.
+func (c *Client) failover(ctx context.Context, send func(endpoint string) attempt) (string, attempt) {
+	if c.Endpoints == nil {
+		return c.BaseURL, send(c.BaseURL)
+	}
+
+	candidates := c.Endpoints.Candidates()
+	if len(candidates) == 0 {
+		return "", attempt{err: ErrNoAvailableEndpoint}
+	}
+
+	var result attempt
+	for _, endpoint := range candidates {
+		result = send(endpoint)
+		if !result.unavailable {
+			if !result.throttled && ctx.Err() == nil {
+				c.Endpoints.ReportSuccess(endpoint)
+			}
+			return endpoint, result
+		}
+		c.Endpoints.ReportFailure(endpoint, result.err)
+	}
+	return "", result
+}
+
+// isEndpointFailure reports whether a gRPC error means the endpoint itself could not serve the call.
+// A deadline only counts against the endpoint while the caller's own context is still alive.
+func isEndpointFailure(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEndpointPoolCircuit(t *testing.T) {
+	pool := NewEndpointPool("a", "b")
+	pool.FailureThreshold = 2
+	pool.OpenTimeout = time.Hour
+	failure := errors.New("connection refused")
+
+	steps := []struct {
+		name   string
+		report func()
+		want   []string
+		stateA CircuitState
+	}{
+		{"all closed", func() {}, []string{"a", "b"}, CircuitClosed},
+		{"below threshold", func() { pool.ReportFailure("a", failure) }, []string{"a", "b"}, CircuitClosed},
+		{"threshold opens", func() { pool.ReportFailure("a", failure) }, []string{"b"}, CircuitOpen},
+		{"timeout half opens", func() { pool.OpenTimeout = 0 }, []string{"b", "a"}, CircuitHalfOpen},
+		{"failed trial reopens", func() { pool.OpenTimeout = time.Hour; pool.ReportFailure("a", failure) }, []string{"b"}, CircuitOpen},
+		{"success closes", func() { pool.ReportSuccess("a") }, []string{"a", "b"}, CircuitClosed},
+	}
+	for _, step := range steps {
+		step.report()
+		if got := pool.Candidates(); !reflect.DeepEqual(got, step.want) {
+			t.Fatalf("%s: candidates = %v, want %v", step.name, got, step.want)
+		}
+		if state := pool.Status()[0].State; state != step.stateA {
+			t.Fatalf("%s: state of a = %s, want %s", step.name, state, step.stateA)
+		}
+	}
+}
+
+// newTestServer answers every request with the status code and a minimal response body
+func newTestServer(t *testing.T, status int, hits *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"data":{"name":"ok"},"usdCost":0.01}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientFailover(t *testing.T) {
+	var downHits, upHits int
+	down := newTestServer(t, http.StatusServiceUnavailable, &downHits)
+	up := newTestServer(t, http.StatusOK, &upHits)
+
+	c := NewDefaultClient("token", "", http.DefaultClient)
+	c.Endpoints = NewEndpointPool(down.URL, up.URL)
+	c.Endpoints.FailureThreshold = 1
+	definition := &jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{"name": {Type: jsonSchema.String}}}
+
+	response, err := c.SendRequest("prompt", definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Endpoint != up.URL || response.Data["name"] != "ok" {
+		t.Errorf("response = %+v, want data served by %s", response, up.URL)
+	}
+	if state := c.Endpoints.Status()[0].State; state != CircuitOpen {
+		t.Errorf("failing endpoint state = %s, want open", state)
+	}
+
+	// the open circuit keeps the failing endpoint out of the next request
+	if _, err := c.SendRequest("prompt", definition); err != nil {
+		t.Fatal(err)
+	}
+	if downHits != 1 || upHits != 2 {
+		t.Errorf("hits = %d down, %d up, want 1 and 2", downHits, upHits)
+	}
+}
+
+func TestClientFailoverAllOpen(t *testing.T) {
+	c := NewDefaultClient("token", "", http.DefaultClient)
+	c.Endpoints = NewEndpointPool("http://a", "http://b")
+	c.Endpoints.FailureThreshold = 1
+	c.Endpoints.ReportFailure("http://a", nil)
+	c.Endpoints.ReportFailure("http://b", nil)
+
+	if _, err := c.SendRequest("prompt", &jsonSchema.Definition{Type: jsonSchema.String}); !errors.Is(err, ErrNoAvailableEndpoint) {
+		t.Errorf("error = %v, want ErrNoAvailableEndpoint", err)
+	}
+}
+
+func TestClientFailoverThrottled(t *testing.T) {
+	var hits int
+	throttling := newTestServer(t, http.StatusTooManyRequests, &hits)
+
+	c := NewDefaultClient("token", "", http.DefaultClient)
+	c.Endpoints = NewEndpointPool(throttling.URL)
+	c.Endpoints.ReportFailure(throttling.URL, errors.New("connection refused"))
+
+	if _, err := c.SendRequest("prompt", &jsonSchema.Definition{Type: jsonSchema.String}); err == nil {
+		t.Fatal("throttled request returned no error")
+	}
+	// a throttled response neither counts against the endpoint nor clears its earlier failure
+	if failures := c.Endpoints.Status()[0].ConsecutiveFailures; failures != 1 {
+		t.Errorf("consecutive failures = %d, want 1", failures)
+	}
+}
+
+func TestIsEndpointFailure(t *testing.T) {
+	expired, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"unavailable", context.Background(), status.Error(codes.Unavailable, "down"), true},
+		{"endpoint deadline", context.Background(), status.Error(codes.DeadlineExceeded, "slow"), true},
+		{"caller deadline", expired, status.Error(codes.DeadlineExceeded, "slow"), false},
+		{"throttled", context.Background(), status.Error(codes.ResourceExhausted, "busy"), false},
+		{"invalid request", context.Background(), status.Error(codes.InvalidArgument, "bad"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isEndpointFailure(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("isEndpointFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointPoolCheckHealth(t *testing.T) {
+	var healthyHits, sickHits int
+	healthy := newTestServer(t, http.StatusOK, &healthyHits)
+	sick := newTestServer(t, http.StatusInternalServerError, &sickHits)
+
+	pool := NewEndpointPool(healthy.URL, sick.URL)
+	pool.HealthCheck = HTTPHealthCheck(http.DefaultClient, "/health")
+	pool.CheckHealth(context.Background())
+
+	statuses := pool.Status()
+	if statuses[0].State != CircuitClosed || statuses[1].State != CircuitOpen {
+		t.Errorf("states = %s, %s, want closed and open", statuses[0].State, statuses[1].State)
+	}
+	if statuses[1].LastError == "" {
+		t.Error("unhealthy endpoint has no last error")
+	}
+}
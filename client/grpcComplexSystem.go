@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"google.golang.org/grpc"
+)
+
+// GrpcGenerateComplexSystem sends a ComplexSystem to the gRPC server and returns the generated object
+func (c *Client) GrpcGenerateComplexSystem(prompt string, system *pb.ComplexSystem) (*Response, error) {
+	return c.GrpcGenerateComplexSystemContext(context.Background(), prompt, system)
+}
+
+// GrpcGenerateComplexSystemContext sends the ComplexSystem, scheduled and rate limited by its root schema.
+// The context bounds the wait in the queue and on the rate limiter as well as the call itself.
+func (c *Client) GrpcGenerateComplexSystemContext(ctx context.Context, prompt string, system *pb.ComplexSystem) (*Response, error) {
+	request := &pb.ComplexSystemRequestBody{
+		Prompt:        prompt,
+		ComplexSystem: system,
+	}
+
+	return c.grpcGenerate(ctx, system.GetRootSchema(), func(ctx context.Context, client pb.JSONSchemaServiceClient) (*pb.Response, error) {
+		response, err := client.GenerateComplexSystem(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call GenerateComplexSystem: %w", err)
+		}
+		return response, nil
+	})
+}
+
+// GrpcStreamComplexSystem sends a ComplexSystem to the gRPC server and streams responses
+// The handler function is called for each response received from the stream
+func (c *Client) GrpcStreamComplexSystem(prompt string, system *pb.ComplexSystem, handler func(*StreamingResponse) error) error {
+	return c.GrpcStreamComplexSystemContext(context.Background(), prompt, system, handler)
+}
+
+// GrpcStreamComplexSystemContext streams the responses for the ComplexSystem, scheduled and rate limited by its root schema.
+// The context bounds the wait in the queue and on the rate limiter as well as the stream itself.
+func (c *Client) GrpcStreamComplexSystemContext(ctx context.Context, prompt string, system *pb.ComplexSystem, handler func(*StreamingResponse) error) error {
+	request := &pb.ComplexSystemRequestBody{
+		Prompt:        prompt,
+		ComplexSystem: system,
+	}
+
+	return c.grpcStream(ctx, system.GetRootSchema(), handler, func(ctx context.Context, client pb.JSONSchemaServiceClient) (grpc.ServerStreamingClient[pb.StreamingResponse], error) {
+		stream, err := client.StreamComplexSystem(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call StreamComplexSystem: %w", err)
+		}
+		return stream, nil
+	})
+}
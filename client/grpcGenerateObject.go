@@ -8,13 +8,80 @@ import (
 	"github.com/objectweaver/go-sdk/converison"
 	pb "github.com/objectweaver/go-sdk/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// grpcUnaryCall performs the RPC itself on an authorized context
+type grpcUnaryCall func(ctx context.Context, client pb.JSONSchemaServiceClient) (*pb.Response, error)
+
 // SendRequestToServer sends a request to the gRPC server with authorization headers
 func (c *Client) GrpcGenerateObject(prompt string, definition *pb.Definition) (*Response, error) {
+	return c.GrpcGenerateObjectContext(context.Background(), prompt, definition)
+}
+
+// GrpcGenerateObjectContext sends the request once the client side scheduling allows it.
+// The context bounds the wait in the queue and on the rate limiter as well as the call itself.
+func (c *Client) GrpcGenerateObjectContext(ctx context.Context, prompt string, definition *pb.Definition) (*Response, error) {
+	// Create the request object
+	request := &pb.RequestBody{
+		Prompt:     prompt,
+		Definition: definition,
+	}
+
+	return c.grpcGenerate(ctx, definition, func(ctx context.Context, client pb.JSONSchemaServiceClient) (*pb.Response, error) {
+		// Call the gRPC method on the client
+		response, err := client.GenerateObject(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call GenerateObject: %w", err)
+		}
+		return response, nil
+	})
+}
+
+// grpcGenerate runs a unary call for the definition through the scheduler, rate limiter and endpoint failover
+// and converts the response
+func (c *Client) grpcGenerate(ctx context.Context, definition *pb.Definition, call grpcUnaryCall) (*Response, error) {
+	release, err := c.acquire(ctx, definition.GetPriority())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var response *pb.Response
+	var served string
+	err = c.throttled(ctx, definition.GetModel(), func() attempt {
+		var result attempt
+		served, result = c.failover(ctx, func(endpoint string) attempt {
+			response, err = c.grpcUnary(ctx, endpoint, call)
+			return attempt{throttled: isResourceExhausted(err), unavailable: isEndpointFailure(ctx, err), err: err}
+		})
+		return result
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := converison.ConvertStructpbToMap(response.Data)
+
+	res := &Response{
+		Data:         data,
+		UsdCost:      response.UsdCost,
+		DetailedData: response.DetailedData,
+		Endpoint:     served,
+	}
+	if c.TypedResponses {
+		res.Data, res.TypeMismatches = converison.ApplyDefinitionTypes(data, converison.ConvertProtoToModel(definition))
+	}
+
+	return res, nil
+}
+
+// grpcUnary performs a single unary call against the endpoint
+func (c *Client) grpcUnary(parent context.Context, endpoint string, call grpcUnaryCall) (*pb.Response, error) {
 	// Set up a connection to the gRPC server
-	conn, err := grpc.NewClient(c.BaseURL, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := grpc.NewClient(endpoint, grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %v", err)
 	}
@@ -29,32 +96,17 @@ func (c *Client) GrpcGenerateObject(prompt string, definition *pb.Definition) (*
 	client := pb.NewJSONSchemaServiceClient(conn)
 
 	// Create a context with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	ctx, cancel := context.WithTimeout(parent, time.Second*10)
 	defer cancel()
 
 	// Set up metadata with the authorization token
 	md := metadata.New(map[string]string{"x-api-key": c.Password})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	// Create the request object
-	request := &pb.RequestBody{
-		Prompt:     prompt,
-		Definition: definition,
-	}
-
-	// Call the gRPC method on the client
-	response, err := client.GenerateObject(ctx, request) // Replace 'GenerateObject' with the actual RPC method name
-	if err != nil {
-		return nil, fmt.Errorf("failed to call GenerateObject: %v", err)
-	}
-
-	data, err := converison.ConvertStructpbToMap(response.Data)
-
-	res := &Response{
-		Data:         data,
-		UsdCost:      response.UsdCost,
-		DetailedData: response.DetailedData,
-	}
+	return call(ctx, client)
+}
 
-	return res, nil
+// isResourceExhausted reports whether the server rejected a gRPC call because of rate limiting
+func isResourceExhausted(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
 }
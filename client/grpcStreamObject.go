@@ -8,6 +8,7 @@ import (
 
 	"github.com/objectweaver/go-sdk/converison"
 	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -18,15 +19,77 @@ type StreamingResponse struct {
 	UsdCost      float64                      `json:"usdCost"`
 	Status       string                       `json:"status"`
 	DetailedData map[string]*pb.DetailedField `json:"detailedData"`
+	Endpoint     string                       `json:"-"` //the endpoint that served the stream
+
+	// TypeMismatches lists values that did not match their Definition when Client.TypedResponses is set
+	TypeMismatches []converison.TypeMismatch `json:"-"`
 }
 
+// grpcStreamCall opens the server stream on an authorized context
+type grpcStreamCall func(ctx context.Context, client pb.JSONSchemaServiceClient) (grpc.ServerStreamingClient[pb.StreamingResponse], error)
+
 // GrpcStreamGeneratedObjects sends a request to the gRPC server and streams responses
 // The handler function is called for each response received from the stream
 func (c *Client) GrpcStreamGeneratedObjects(prompt string, definition *pb.Definition, handler func(*StreamingResponse) error) error {
+	return c.GrpcStreamGeneratedObjectsContext(context.Background(), prompt, definition, handler)
+}
+
+// GrpcStreamGeneratedObjectsContext streams the responses once the client side scheduling allows it.
+// The context bounds the wait in the queue and on the rate limiter as well as the stream itself.
+func (c *Client) GrpcStreamGeneratedObjectsContext(ctx context.Context, prompt string, definition *pb.Definition, handler func(*StreamingResponse) error) error {
+	// Create the request object
+	request := &pb.RequestBody{
+		Prompt:     prompt,
+		Definition: definition,
+	}
+
+	return c.grpcStream(ctx, definition, handler, func(ctx context.Context, client pb.JSONSchemaServiceClient) (grpc.ServerStreamingClient[pb.StreamingResponse], error) {
+		// Call the streaming gRPC method
+		stream, err := client.StreamGeneratedObjects(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call StreamGeneratedObjects: %w", err)
+		}
+		return stream, nil
+	})
+}
+
+// grpcStream runs a streaming call for the definition through the scheduler, rate limiter and endpoint failover
+func (c *Client) grpcStream(ctx context.Context, definition *pb.Definition, handler func(*StreamingResponse) error, call grpcStreamCall) error {
+	// The slot is held for the lifetime of the stream
+	release, err := c.acquire(ctx, definition.GetPriority())
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var schema *jsonSchema.Definition
+	if c.TypedResponses {
+		schema = converison.ConvertProtoToModel(definition)
+	}
+
+	return c.throttled(ctx, definition.GetModel(), func() attempt {
+		_, result := c.failover(ctx, func(endpoint string) attempt {
+			delivered, err := c.grpcReceiveStream(ctx, endpoint, schema, handler, call)
+			// Once part of the stream has reached the handler it cannot be retried transparently
+			return attempt{
+				throttled:   !delivered && isResourceExhausted(err),
+				unavailable: !delivered && isEndpointFailure(ctx, err),
+				err:         err,
+			}
+		})
+		return result
+	})
+}
+
+// grpcReceiveStream performs a single streaming call, reporting whether any response reached the handler.
+// When schema is set the data of every response is converted to the types it declares.
+func (c *Client) grpcReceiveStream(parent context.Context, endpoint string, schema *jsonSchema.Definition, handler func(*StreamingResponse) error, call grpcStreamCall) (bool, error) {
+	delivered := false
+
 	// Set up a connection to the gRPC server
-	conn, err := grpc.NewClient(c.BaseURL, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := grpc.NewClient(endpoint, grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
-		return fmt.Errorf("failed to connect to server: %v", err)
+		return delivered, fmt.Errorf("failed to connect to server: %v", err)
 	}
 	defer func(conn *grpc.ClientConn) {
 		err = conn.Close()
@@ -39,23 +102,16 @@ func (c *Client) GrpcStreamGeneratedObjects(prompt string, definition *pb.Defini
 	client := pb.NewJSONSchemaServiceClient(conn)
 
 	// Create a context with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5) // Longer timeout for streaming
+	ctx, cancel := context.WithTimeout(parent, time.Minute*5) // Longer timeout for streaming
 	defer cancel()
 
 	// Set up metadata with the authorization token
 	md := metadata.New(map[string]string{"x-api-key": c.Password})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	// Create the request object
-	request := &pb.RequestBody{
-		Prompt:     prompt,
-		Definition: definition,
-	}
-
-	// Call the streaming gRPC method
-	stream, err := client.StreamGeneratedObjects(ctx, request)
+	stream, err := call(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to call StreamGeneratedObjects: %v", err)
+		return delivered, err
 	}
 
 	// Process the stream
@@ -66,13 +122,13 @@ func (c *Client) GrpcStreamGeneratedObjects(prompt string, definition *pb.Defini
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("error receiving from stream: %v", err)
+			return delivered, fmt.Errorf("error receiving from stream: %w", err)
 		}
 
 		// Convert the structpb data to map
 		data, err := converison.ConvertStructpbToMap(response.Data)
 		if err != nil {
-			return fmt.Errorf("error converting response data: %v", err)
+			return delivered, fmt.Errorf("error converting response data: %v", err)
 		}
 
 		// Create streaming response
@@ -81,13 +137,18 @@ func (c *Client) GrpcStreamGeneratedObjects(prompt string, definition *pb.Defini
 			UsdCost:      response.UsdCost,
 			Status:       response.Status,
 			DetailedData: response.DetailedData,
+			Endpoint:     endpoint,
+		}
+		if schema != nil {
+			streamResp.Data, streamResp.TypeMismatches = converison.ApplyDefinitionTypes(data, schema)
 		}
 
 		// Call the handler function
+		delivered = true
 		if err := handler(streamResp); err != nil {
-			return fmt.Errorf("handler error: %v", err)
+			return delivered, fmt.Errorf("handler error: %v", err)
 		}
 	}
 
-	return nil
+	return delivered, nil
 }
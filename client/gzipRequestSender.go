@@ -22,7 +22,7 @@ func NewGZipRequestSender(client *http.Client) *GZipRequestSender {
 
 // SendRequestBody sends a gzip-compressed JSON request and returns a response
 func (grs *GZipRequestSender) SendRequestBody(baseURL, token string, requestBody *RequestBody) (*http.Response, error) {
-	url := baseURL + "/api/objectGen"
+	url := baseURL + requestBody.Path()
 
 	// Serialize the request body to JSON
 	jsonData, err := json.Marshal(requestBody)
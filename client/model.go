@@ -1,13 +1,37 @@
 package client
 
 import (
+	"github.com/objectweaver/go-sdk/converison"
 	pb "github.com/objectweaver/go-sdk/grpc"
 	"github.com/objectweaver/go-sdk/jsonSchema"
 )
 
+// API routes the request bodies are sent to
+const (
+	ObjectGenPath  = "/api/objectGen"
+	ComplexGenPath = "/api/complexGen"
+)
+
 type RequestBody struct {
-	Prompt     string                 `json:"prompt"`
-	Definition *jsonSchema.Definition `json:"definition"`
+	Prompt        string                    `json:"prompt"`
+	Definition    *jsonSchema.Definition    `json:"definition,omitempty"`
+	ComplexSystem *jsonSchema.ComplexSystem `json:"complexSystem,omitempty"` //when set the request is sent to ComplexGenPath
+}
+
+// schema returns the Definition the response data is generated from
+func (rb *RequestBody) schema() *jsonSchema.Definition {
+	if rb.ComplexSystem != nil {
+		return &rb.ComplexSystem.RootSchema
+	}
+	return rb.Definition
+}
+
+// Path returns the API route the request body should be sent to
+func (rb *RequestBody) Path() string {
+	if rb.ComplexSystem != nil {
+		return ComplexGenPath
+	}
+	return ObjectGenPath
 }
 
 // Create a response struct
@@ -15,4 +39,19 @@ type Response struct {
 	Data         map[string]any               `json:"data"` //this data can then be marshalled into the apprioate object type.
 	UsdCost      float64                      `json:"usdCost"`
 	DetailedData map[string]*pb.DetailedField `json:"detailedData"` //detailed metadata per field including tokens, cost, model, and choices
+	Endpoint     string                       `json:"-"`            //the endpoint that served the request
+
+	// TypeMismatches lists values that did not match their Definition when Client.TypedResponses is set
+	TypeMismatches []converison.TypeMismatch `json:"-"`
+}
+
+// Migrate upgrades Data, generated from the given schema version, to the current version of the registry.
+// It is meant for responses stored long term, whose schema has evolved since they were generated.
+func (r *Response) Migrate(registry *jsonSchema.SchemaRegistry, version string) error {
+	data, err := registry.Upgrade(r.Data, version)
+	if err != nil {
+		return err
+	}
+	r.Data = data
+	return nil
 }
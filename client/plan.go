@@ -0,0 +1,298 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// CallRange is the fewest and most LLM calls a part of a Definition can take
+type CallRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r CallRange) add(other CallRange) CallRange {
+	return CallRange{Min: r.Min + other.Min, Max: r.Max + other.Max}
+}
+
+func (r CallRange) times(other CallRange) CallRange {
+	return CallRange{Min: r.Min * other.Min, Max: r.Max * other.Max}
+}
+
+// ModelPrice is the cost in USD of a single call to a model, as a range since token counts are only known once generated
+type ModelPrice struct {
+	MinUsd float64 `json:"minUsd"`
+	MaxUsd float64 `json:"maxUsd"`
+}
+
+// PriceTable prices LLM calls by model name, the empty name being the server's default model
+type PriceTable interface {
+	Price(model string) (ModelPrice, bool)
+}
+
+// ModelPrices is a PriceTable keyed by model name
+type ModelPrices map[string]ModelPrice
+
+// Price returns the price of a call to the model
+func (p ModelPrices) Price(model string) (ModelPrice, bool) {
+	price, ok := p[model]
+	return price, ok
+}
+
+// CostRange is the estimated cost in USD of running a Definition
+type CostRange struct {
+	MinUsd float64 `json:"minUsd"`
+	MaxUsd float64 `json:"maxUsd"`
+}
+
+// ExecutionPlan describes the LLM calls a Definition will make, from the best case, where loops stop after their
+// first iteration and decision points take their cheapest branch, to the worst case
+type ExecutionPlan struct {
+	GenerationCalls CallRange            `json:"generationCalls"` //fields generated, including images and audio
+	ScoringCalls    CallRange            `json:"scoringCalls"`    //evaluations of ScoringCriteria, DecisionPoint and TerminationPoint prompts
+	JudgeCalls      CallRange            `json:"judgeCalls"`      //epistemic judges
+	LoopIterations  CallRange            `json:"loopIterations"`  //iterations of recursive loops
+	DecisionPoints  int                  `json:"decisionPoints"`
+	Branches        int                  `json:"branches"`           //DecisionPoint branches, of which at most one runs per decision
+	Calls           map[string]CallRange `json:"calls"`              //every call by model, the empty name being the server's default model
+	Cost            CostRange            `json:"cost"`               //the cost of the priced calls
+	Unpriced        []string             `json:"unpriced,omitempty"` //models called without a price in the PriceTable
+	Repeated        []string             `json:"repeated,omitempty"` //arrays and maps whose items are counted once, their length being unknown until generated
+}
+
+// Plan describes the LLM calls the Definition will make and estimates their cost with Client.Prices, without
+// sending anything. Fields without a Model inherit the model of their parent, scoring uses the EvaluationModel
+// when set, and requests to external URLs (Definition.Req) make no LLM calls. A RecursiveLoop repeats every call of its
+// Definition, including its scoring, its DecisionPoint and the evaluation of its TerminationPoint, once per iteration.
+func (c *Client) Plan(def *jsonSchema.Definition) (*ExecutionPlan, error) {
+	if def == nil {
+		return nil, fmt.Errorf("definition is nil")
+	}
+
+	plan := &ExecutionPlan{}
+	cost := (&planner{plan: plan}).definition(def, "$", "")
+	plan.GenerationCalls, plan.ScoringCalls, plan.JudgeCalls = cost.generation, cost.scoring, cost.judges
+	plan.LoopIterations = cost.iterations
+	plan.Calls = cost.models
+
+	for _, model := range sortedModels(cost.models) {
+		var price ModelPrice
+		ok := false
+		if c.Prices != nil {
+			price, ok = c.Prices.Price(model)
+		}
+		if !ok {
+			plan.Unpriced = append(plan.Unpriced, model)
+			continue
+		}
+		calls := cost.models[model]
+		plan.Cost.MinUsd += float64(calls.Min) * price.MinUsd
+		plan.Cost.MaxUsd += float64(calls.Max) * price.MaxUsd
+	}
+	return plan, nil
+}
+
+// planCost counts the calls of a part of a Definition
+type planCost struct {
+	generation, scoring, judges, iterations CallRange
+	models                                  map[string]CallRange
+}
+
+// call counts a single call of the given kind to the model
+func (p *planCost) call(kind *CallRange, model string, count int) {
+	*kind = kind.add(CallRange{Min: count, Max: count})
+	if p.models == nil {
+		p.models = make(map[string]CallRange)
+	}
+	p.models[model] = p.models[model].add(CallRange{Min: count, Max: count})
+}
+
+func (p *planCost) add(other planCost) {
+	p.generation = p.generation.add(other.generation)
+	p.scoring = p.scoring.add(other.scoring)
+	p.judges = p.judges.add(other.judges)
+	p.iterations = p.iterations.add(other.iterations)
+	for model, calls := range other.models {
+		if p.models == nil {
+			p.models = make(map[string]CallRange)
+		}
+		p.models[model] = p.models[model].add(calls)
+	}
+}
+
+func (p planCost) times(r CallRange) planCost {
+	result := planCost{
+		generation: p.generation.times(r),
+		scoring:    p.scoring.times(r),
+		judges:     p.judges.times(r),
+		iterations: p.iterations.times(r),
+		models:     make(map[string]CallRange, len(p.models)),
+	}
+	for model, calls := range p.models {
+		result.models[model] = calls.times(r)
+	}
+	return result
+}
+
+// atLeast raises the most calls of every kind and to every model to those of other
+func (p *planCost) atLeast(other planCost) {
+	raise := func(r, o CallRange) CallRange { return CallRange{Min: r.Min, Max: max(r.Max, o.Max)} }
+	p.generation = raise(p.generation, other.generation)
+	p.scoring = raise(p.scoring, other.scoring)
+	p.judges = raise(p.judges, other.judges)
+	p.iterations = raise(p.iterations, other.iterations)
+	for model, calls := range other.models {
+		if p.models == nil {
+			p.models = make(map[string]CallRange)
+		}
+		p.models[model] = raise(p.models[model], calls)
+	}
+}
+
+// total is the number of calls of every kind
+func (p planCost) total() CallRange {
+	return p.generation.add(p.scoring).add(p.judges)
+}
+
+// between takes the fewest calls from best and the most from worst
+func between(best, worst planCost) planCost {
+	pick := func(a, b CallRange) CallRange { return CallRange{Min: a.Min, Max: b.Max} }
+	result := planCost{
+		generation: pick(best.generation, worst.generation),
+		scoring:    pick(best.scoring, worst.scoring),
+		judges:     pick(best.judges, worst.judges),
+		iterations: pick(best.iterations, worst.iterations),
+		models:     make(map[string]CallRange),
+	}
+	for model := range best.models {
+		result.models[model] = pick(best.models[model], worst.models[model])
+	}
+	for model := range worst.models {
+		result.models[model] = pick(best.models[model], worst.models[model])
+	}
+	return result
+}
+
+// planner walks a Definition, counting its calls and recording its structure in the plan
+type planner struct {
+	plan *ExecutionPlan
+}
+
+// definition counts the calls of the Definition and everything nested in it
+func (p *planner) definition(def *jsonSchema.Definition, path, model string) planCost {
+	if def.Model != "" {
+		model = def.Model
+	}
+
+	var cost planCost
+	generated := true
+	switch {
+	case def.Req != nil:
+		generated = false
+	case def.Image != nil:
+		cost.call(&cost.generation, mediaModel(def.Image.Model, model), 1)
+	case def.TextToSpeech != nil:
+		cost.call(&cost.generation, mediaModel(def.TextToSpeech.Model, model), 1)
+	case def.SpeechToText != nil:
+		cost.call(&cost.generation, mediaModel(def.SpeechToText.Model, model), 1)
+	case def.Type == jsonSchema.Object && len(def.Properties) > 0, def.Type == jsonSchema.Array && def.Items != nil:
+		generated = false
+	default:
+		// maps generate their keys here and their values below
+		cost.call(&cost.generation, model, 1)
+	}
+
+	if generated && def.Epistemic.Active {
+		cost.call(&cost.judges, model, max(def.Epistemic.Judges, 1))
+	}
+	if def.ScoringCriteria != nil {
+		cost.call(&cost.scoring, mediaModel(def.ScoringCriteria.EvaluationModel, model), 1)
+	} else {
+		// without scoring criteria every evaluation prompt is a call of its own
+		if def.DecisionPoint != nil && def.DecisionPoint.EvaluationPrompt != "" {
+			cost.call(&cost.scoring, model, 1)
+		}
+		if def.RecursiveLoop != nil && def.RecursiveLoop.TerminationPoint != nil && def.RecursiveLoop.TerminationPoint.EvaluationPrompt != "" {
+			cost.call(&cost.scoring, model, 1)
+		}
+	}
+
+	keys := make([]string, 0, len(def.Properties))
+	for key := range def.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		property := def.Properties[key]
+		cost.add(p.definition(&property, path+"."+key, model))
+	}
+	if def.Items != nil {
+		p.plan.Repeated = append(p.plan.Repeated, path+"[]")
+		cost.add(p.definition(def.Items, path+"[]", model))
+	}
+	if def.HashMap != nil && def.HashMap.FieldDefinition != nil {
+		p.plan.Repeated = append(p.plan.Repeated, path+".*")
+		cost.add(p.definition(def.HashMap.FieldDefinition, path+".*", model))
+	}
+
+	if def.DecisionPoint != nil {
+		cost.add(p.decision(def.DecisionPoint, path+".decisionPoint", model))
+	}
+	// every iteration regenerates the field, scores it and routes it again
+	if loop := def.RecursiveLoop; loop != nil {
+		iterations := CallRange{Min: 1, Max: max(loop.MaxIterations, 1)}
+		cost = cost.times(iterations)
+		cost.iterations = cost.iterations.add(iterations)
+	}
+	return cost
+}
+
+// decision counts the calls of the branch taken, none in the best case unless a branch has no conditions.
+// In the worst case the logic of every branch is evaluated, and the most calls any branch makes of each kind
+// and to each model are counted, so the bound holds whichever branch turns out the most expensive.
+func (p *planner) decision(decision *jsonSchema.DecisionPoint, path, model string) planCost {
+	p.plan.DecisionPoints++
+	p.plan.Branches += len(decision.Branches)
+
+	var best, worst, logic planCost
+	always := false
+	for i := range decision.Branches {
+		branch := &decision.Branches[i]
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+
+		var cost planCost
+		if branch.Logic != nil {
+			branchLogic := p.definition(branch.Logic, branchPath+".logic", model)
+			logic.add(branchLogic)
+			cost.add(branchLogic)
+		}
+		then := p.definition(&branch.Then, branchPath+".then", model)
+		cost.add(then)
+
+		if len(branch.Conditions) == 0 && (!always || cost.total().Min < best.total().Min) {
+			best, always = cost, true
+		}
+		worst.atLeast(then)
+	}
+	worst.add(logic)
+	return between(best, worst)
+}
+
+// mediaModel returns the model set on a media or scoring configuration, falling back to the field's model
+func mediaModel(own, model string) string {
+	if own != "" {
+		return own
+	}
+	return model
+}
+
+func sortedModels(models map[string]CallRange) []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestPlan(t *testing.T) {
+	str := jsonSchema.Definition{Type: jsonSchema.String}
+	pair := jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{"a": str, "b": str}}
+	routed := func(prompt string) *jsonSchema.DecisionPoint {
+		return &jsonSchema.DecisionPoint{EvaluationPrompt: prompt, Branches: []jsonSchema.ConditionalBranch{
+			{Conditions: []jsonSchema.Condition{{Field: "quality", Operator: jsonSchema.OpLessThan, Value: 50}}, Then: str},
+		}}
+	}
+	scored := &jsonSchema.ScoringCriteria{Dimensions: map[string]jsonSchema.ScoringDimension{"quality": {}}}
+
+	type calls struct {
+		generation, scoring, judges, iterations CallRange
+		models                                  map[string]CallRange
+	}
+	tests := []struct {
+		name string
+		def  jsonSchema.Definition
+		want calls
+	}{
+		{
+			name: "single field",
+			def:  str,
+			want: calls{generation: CallRange{1, 1}, models: map[string]CallRange{"": {1, 1}}},
+		},
+		{
+			name: "inherited models",
+			def: jsonSchema.Definition{Type: jsonSchema.Object, Model: "large", Properties: map[string]jsonSchema.Definition{
+				"a": str,
+				"b": {Type: jsonSchema.String, Model: "small"},
+			}},
+			want: calls{generation: CallRange{2, 2}, models: map[string]CallRange{"large": {1, 1}, "small": {1, 1}}},
+		},
+		{
+			name: "scoring with an evaluation model",
+			def:  jsonSchema.Definition{Type: jsonSchema.String, ScoringCriteria: &jsonSchema.ScoringCriteria{EvaluationModel: "judge"}},
+			want: calls{generation: CallRange{1, 1}, scoring: CallRange{1, 1}, models: map[string]CallRange{"": {1, 1}, "judge": {1, 1}}},
+		},
+		{
+			name: "epistemic judges",
+			def:  jsonSchema.Definition{Type: jsonSchema.String, Epistemic: jsonSchema.EpistemicValidation{Active: true, Judges: 3}},
+			want: calls{generation: CallRange{1, 1}, judges: CallRange{3, 3}, models: map[string]CallRange{"": {4, 4}}},
+		},
+		{
+			name: "external request",
+			def:  jsonSchema.Definition{Type: jsonSchema.String, Req: &jsonSchema.RequestFormat{URL: "https://example.com"}},
+			want: calls{},
+		},
+		{
+			name: "decision takes the cheapest and dearest branch",
+			def: jsonSchema.Definition{Type: jsonSchema.String, DecisionPoint: &jsonSchema.DecisionPoint{
+				EvaluationPrompt: "Route it",
+				Branches: []jsonSchema.ConditionalBranch{
+					{Conditions: []jsonSchema.Condition{{Field: "quality", Operator: jsonSchema.OpLessThan, Value: 50}}, Then: pair},
+					{Then: str},
+				},
+			}},
+			want: calls{generation: CallRange{2, 3}, scoring: CallRange{1, 1}, models: map[string]CallRange{"": {3, 4}}},
+		},
+		{
+			name: "loop repeats its decision",
+			def: jsonSchema.Definition{
+				Type:          jsonSchema.String,
+				DecisionPoint: routed("Route it"),
+				RecursiveLoop: &jsonSchema.RecursiveLoop{MaxIterations: 3},
+			},
+			want: calls{generation: CallRange{1, 6}, scoring: CallRange{1, 3}, iterations: CallRange{1, 3}, models: map[string]CallRange{"": {2, 9}}},
+		},
+		{
+			name: "loop evaluates its termination point every iteration",
+			def: jsonSchema.Definition{
+				Type:          jsonSchema.String,
+				RecursiveLoop: &jsonSchema.RecursiveLoop{MaxIterations: 3, TerminationPoint: routed("Is it done?")},
+			},
+			want: calls{generation: CallRange{1, 3}, scoring: CallRange{1, 3}, iterations: CallRange{1, 3}, models: map[string]CallRange{"": {2, 6}}},
+		},
+		{
+			name: "decision and termination prompts are separate evaluations",
+			def: jsonSchema.Definition{
+				Type:          jsonSchema.String,
+				DecisionPoint: routed("Route it"),
+				RecursiveLoop: &jsonSchema.RecursiveLoop{MaxIterations: 2, TerminationPoint: routed("Is it done?")},
+			},
+			want: calls{generation: CallRange{1, 4}, scoring: CallRange{2, 4}, iterations: CallRange{1, 2}, models: map[string]CallRange{"": {3, 8}}},
+		},
+		{
+			name: "scoring criteria cover the termination point",
+			def: jsonSchema.Definition{
+				Type:            jsonSchema.String,
+				ScoringCriteria: scored,
+				RecursiveLoop:   &jsonSchema.RecursiveLoop{MaxIterations: 2, TerminationPoint: routed("Is it done?")},
+			},
+			want: calls{generation: CallRange{1, 2}, scoring: CallRange{1, 2}, iterations: CallRange{1, 2}, models: map[string]CallRange{"": {2, 4}}},
+		},
+		{
+			name: "nested loops multiply",
+			def: jsonSchema.Definition{
+				Type:          jsonSchema.Object,
+				RecursiveLoop: &jsonSchema.RecursiveLoop{MaxIterations: 2},
+				Properties: map[string]jsonSchema.Definition{
+					"a": {Type: jsonSchema.String, RecursiveLoop: &jsonSchema.RecursiveLoop{MaxIterations: 3}},
+				},
+			},
+			want: calls{generation: CallRange{1, 6}, iterations: CallRange{2, 8}, models: map[string]CallRange{"": {1, 6}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := (&Client{}).Plan(&tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := calls{plan.GenerationCalls, plan.ScoringCalls, plan.JudgeCalls, plan.LoopIterations, plan.Calls}
+			if len(got.models) == 0 {
+				got.models = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() calls = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanStructureAndCost(t *testing.T) {
+	def := jsonSchema.Definition{
+		Type:  jsonSchema.Object,
+		Model: "large",
+		Properties: map[string]jsonSchema.Definition{
+			"tags":   {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.String}},
+			"scores": {Type: jsonSchema.Map, Model: "small", HashMap: &jsonSchema.HashMap{FieldDefinition: &jsonSchema.Definition{Type: jsonSchema.Number}}},
+			"body": {Type: jsonSchema.String, Model: "unknown", DecisionPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{
+				{Then: jsonSchema.Definition{Type: jsonSchema.String}},
+				{Then: jsonSchema.Definition{Type: jsonSchema.String}},
+			}}},
+		},
+	}
+	c := &Client{Prices: ModelPrices{"large": {MinUsd: 0.01, MaxUsd: 0.02}, "small": {MinUsd: 0.001, MaxUsd: 0.002}}}
+	plan, err := c.Plan(&def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.DecisionPoints != 1 || plan.Branches != 2 {
+		t.Errorf("DecisionPoints, Branches = %d, %d, want 1, 2", plan.DecisionPoints, plan.Branches)
+	}
+	if want := []string{"$.scores.*", "$.tags[]"}; !reflect.DeepEqual(plan.Repeated, want) {
+		t.Errorf("Repeated = %v, want %v", plan.Repeated, want)
+	}
+	if want := []string{"unknown"}; !reflect.DeepEqual(plan.Unpriced, want) {
+		t.Errorf("Unpriced = %v, want %v", plan.Unpriced, want)
+	}
+	// large generates the tags, small the map keys and values, and the unpriced model the body and its branch
+	if math.Abs(plan.Cost.MinUsd-0.012) > 1e-9 || math.Abs(plan.Cost.MaxUsd-0.024) > 1e-9 {
+		t.Errorf("Cost = %+v, want 0.012-0.024", plan.Cost)
+	}
+
+	if _, err := c.Plan(nil); err == nil {
+		t.Error("Plan(nil) returned no error")
+	}
+}
+
+func TestPlanDecisionAcrossModels(t *testing.T) {
+	def := jsonSchema.Definition{Type: jsonSchema.String, Model: "a", DecisionPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{
+		{
+			Name:       "x",
+			Conditions: []jsonSchema.Condition{{Field: "quality", Operator: jsonSchema.OpLessThan, Value: 50}},
+			Then: jsonSchema.Definition{Type: jsonSchema.Object, Model: "b", Properties: map[string]jsonSchema.Definition{
+				"one": {Type: jsonSchema.String}, "two": {Type: jsonSchema.String},
+			}},
+		},
+		{Name: "default", Then: jsonSchema.Definition{Type: jsonSchema.String, Model: "expensive"}},
+	}}}
+	c := &Client{Prices: ModelPrices{"a": {MinUsd: 1, MaxUsd: 1}, "b": {MinUsd: 1, MaxUsd: 1}, "expensive": {MinUsd: 100, MaxUsd: 100}}}
+	plan, err := c.Plan(&def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for model, calls := range plan.Calls {
+		if calls.Min > calls.Max {
+			t.Errorf("calls to %q = %+v, min above max", model, calls)
+		}
+	}
+	if plan.Cost.MinUsd > plan.Cost.MaxUsd {
+		t.Errorf("Cost = %+v, min above max", plan.Cost)
+	}
+	// the worst case counts the most calls either branch makes to each model
+	want := map[string]CallRange{"a": {1, 1}, "b": {0, 2}, "expensive": {1, 1}}
+	if !reflect.DeepEqual(plan.Calls, want) {
+		t.Errorf("Calls = %+v, want %+v", plan.Calls, want)
+	}
+	if plan.Cost.MinUsd != 101 || plan.Cost.MaxUsd != 103 {
+		t.Errorf("Cost = %+v, want 101-103", plan.Cost)
+	}
+	if plan.GenerationCalls != (CallRange{2, 3}) {
+		t.Errorf("GenerationCalls = %+v, want 2-3", plan.GenerationCalls)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/objectweaver/go-sdk/converison"
+	"github.com/objectweaver/go-sdk/jsonSchema"
 )
 
 // ResponseProcessor responsible for processing the HTTP response
@@ -17,6 +20,22 @@ func NewResponseProcessor() ResponseProcessor {
 
 // ProcessResponse processes the response and returns the parsed Response struct
 func (rp *ResponseProcessor) ProcessResponse(resp *http.Response) (*Response, error) {
+	return decodeResponse(resp, false)
+}
+
+// ProcessTypedResponse processes the response and converts the data to the types declared by the definition.
+// Numbers are decoded without going through float64 so that large Integer values keep their precision.
+func (rp *ResponseProcessor) ProcessTypedResponse(resp *http.Response, definition *jsonSchema.Definition) (*Response, error) {
+	response, err := decodeResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+	response.Data, response.TypeMismatches = converison.ApplyDefinitionTypes(response.Data, definition)
+	return response, nil
+}
+
+// decodeResponse closes the body once the Response has been decoded from it, keeping numbers as json.Number if asked
+func decodeResponse(resp *http.Response, useNumber bool) (*Response, error) {
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
@@ -28,8 +47,13 @@ func (rp *ResponseProcessor) ProcessResponse(resp *http.Response) (*Response, er
 		return nil, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
 	}
 
+	decoder := json.NewDecoder(resp.Body)
+	if useNumber {
+		decoder.UseNumber()
+	}
+
 	var response Response
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := decoder.Decode(&response); err != nil {
 		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
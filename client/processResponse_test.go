@@ -0,0 +1,42 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestProcessTypedResponse(t *testing.T) {
+	definition := &jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+		"id":    {Type: jsonSchema.Integer},
+		"score": {Type: jsonSchema.Number},
+	}}
+	tests := []struct {
+		name           string
+		body           string
+		wantID         any
+		wantMismatches int
+	}{
+		{"large integer keeps precision", `{"data":{"id":9007199254740993,"score":1}}`, int64(9007199254740993), 0},
+		{"mismatch is reported", `{"data":{"id":"abc","score":1}}`, "abc", 1},
+	}
+	processor := NewResponseProcessor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tt.body))}
+			response, err := processor.ProcessTypedResponse(resp, definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Data["id"] != tt.wantID || response.Data["score"] != 1.0 {
+				t.Errorf("data = %#v", response.Data)
+			}
+			if len(response.TypeMismatches) != tt.wantMismatches {
+				t.Errorf("mismatches = %v, want %d", response.TypeMismatches, tt.wantMismatches)
+			}
+		})
+	}
+}
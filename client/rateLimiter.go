@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultThrottleBackoff is used when the server throttles a request without saying how long to wait
+const defaultThrottleBackoff = time.Second
+
+// RateLimit configures a single limit. Zero values disable the corresponding part of the limit.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"` //sustained request rate of the token bucket
+	Burst             int     `json:"burst,omitempty"`             //bucket size, defaults to one when a rate is set
+	MaxConcurrent     int     `json:"maxConcurrent,omitempty"`     //cap on requests in flight at the same time
+}
+
+// RateLimiter throttles outgoing requests with a token bucket and a cap on concurrent in-flight requests.
+// Additional limits can be set per model, keyed on Definition.Model. When the server answers with
+// 429 (or ResourceExhausted over gRPC) the rate is halved and recovers gradually on success.
+type RateLimiter struct {
+	// MaxRetries is how many times a throttled request is retried before the error is returned
+	MaxRetries int
+
+	global *limit
+	mu     sync.Mutex
+	models map[string]*limit
+}
+
+// limit pairs a token bucket with a concurrency semaphore
+type limit struct {
+	bucket   *tokenBucket
+	inFlight chan struct{}
+}
+
+// tokenBucket is an adaptive token bucket, the rate is lowered on throttling and raised back towards the limit
+type tokenBucket struct {
+	mu          sync.Mutex
+	limit       float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter initializes a RateLimiter applying the given limit to every request
+func NewRateLimiter(global RateLimit) *RateLimiter {
+	return &RateLimiter{
+		MaxRetries: 3,
+		global:     newLimit(global),
+		models:     make(map[string]*limit),
+	}
+}
+
+// SetModelLimit adds a limit that applies only to requests whose Definition.Model matches model
+func (rl *RateLimiter) SetModelLimit(model string, modelLimit RateLimit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.models[model] = newLimit(modelLimit)
+}
+
+// Wait blocks until a request for the model may be sent and returns the function that frees its concurrency slot.
+// The model's limit is acquired before the global one, so a request waiting on its model holds nothing that
+// requests for other models need. It returns early with the context's error if the context is done first.
+func (rl *RateLimiter) Wait(ctx context.Context, model string) (func(), error) {
+	var limits []*limit
+	if modelLimit := rl.modelLimit(model); modelLimit != nil {
+		limits = append(limits, modelLimit)
+	}
+	limits = append(limits, rl.global)
+
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, l := range limits {
+		free, err := l.acquire(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, free)
+	}
+	return release, nil
+}
+
+// Throttle backs off after the server rejected a request for the model, pausing for retryAfter and halving the rate
+func (rl *RateLimiter) Throttle(model string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultThrottleBackoff
+	}
+	rl.global.bucket.throttle(retryAfter)
+	if modelLimit := rl.modelLimit(model); modelLimit != nil {
+		modelLimit.bucket.throttle(retryAfter)
+	}
+}
+
+// Recover raises a previously throttled rate back towards its configured limit
+func (rl *RateLimiter) Recover(model string) {
+	rl.global.bucket.recover()
+	if modelLimit := rl.modelLimit(model); modelLimit != nil {
+		modelLimit.bucket.recover()
+	}
+}
+
+func (rl *RateLimiter) modelLimit(model string) *limit {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.models[model]
+}
+
+func newLimit(rateLimit RateLimit) *limit {
+	burst := float64(rateLimit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	l := &limit{
+		bucket: &tokenBucket{
+			limit:  rateLimit.RequestsPerSecond,
+			rate:   rateLimit.RequestsPerSecond,
+			burst:  burst,
+			tokens: burst,
+			last:   time.Now(),
+		},
+	}
+	if rateLimit.MaxConcurrent > 0 {
+		l.inFlight = make(chan struct{}, rateLimit.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire takes a concurrency slot and then a token
+func (l *limit) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.inFlight }) }
+	}
+	if err := l.bucket.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// wait blocks until a token is available
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		delay := b.reserveLocked(time.Now())
+		b.mu.Unlock()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserveLocked takes a token if one is available, otherwise it returns how long to wait before trying again
+func (b *tokenBucket) reserveLocked(now time.Time) time.Duration {
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+	if b.limit <= 0 {
+		return 0
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) throttle(retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until := time.Now().Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	// never drop below a sixteenth of the limit so the bucket can always recover
+	b.rate = max(b.rate/2, b.limit/16)
+	// the bucket refills only once the pause is over
+	b.tokens = 0
+	b.last = b.pausedUntil
+}
+
+func (b *tokenBucket) recover() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate = min(b.rate+b.limit/10, b.limit)
+}
+
+// throttled runs send under the client's rate limiter, if one is configured, retrying while the server throttles
+func (c *Client) throttled(ctx context.Context, model string, send func() attempt) error {
+	if c.RateLimiter == nil {
+		return send().err
+	}
+
+	for try := 0; ; try++ {
+		release, err := c.RateLimiter.Wait(ctx, model)
+		if err != nil {
+			return err
+		}
+		result := send()
+		release()
+
+		if !result.throttled {
+			if result.err == nil {
+				c.RateLimiter.Recover(model)
+			}
+			return result.err
+		}
+		c.RateLimiter.Throttle(model, result.retryAfter)
+		if try >= c.RateLimiter.MaxRetries {
+			return result.err
+		}
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
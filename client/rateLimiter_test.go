@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Now()
+	b := &tokenBucket{limit: 10, rate: 10, burst: 2, tokens: 2, last: now}
+
+	for i := 0; i < 2; i++ {
+		if delay := b.reserveLocked(now); delay != 0 {
+			t.Fatalf("reserve %d delay = %v, want 0 within the burst", i, delay)
+		}
+	}
+	if delay := b.reserveLocked(now); delay != 100*time.Millisecond {
+		t.Errorf("delay with an empty bucket = %v, want 100ms", delay)
+	}
+	if delay := b.reserveLocked(now.Add(100 * time.Millisecond)); delay != 0 {
+		t.Errorf("delay after refilling = %v, want 0", delay)
+	}
+
+	unlimited := &tokenBucket{burst: 1, last: now}
+	if delay := unlimited.reserveLocked(now); delay != 0 {
+		t.Errorf("delay without a rate = %v, want 0", delay)
+	}
+}
+
+func TestTokenBucketThrottleAndRecover(t *testing.T) {
+	b := &tokenBucket{limit: 16, rate: 16, burst: 1, tokens: 1, last: time.Now()}
+
+	rates := []float64{8, 4, 2, 1, 1}
+	for _, want := range rates {
+		b.throttle(time.Millisecond)
+		if b.rate != want {
+			t.Fatalf("rate after throttling = %v, want %v", b.rate, want)
+		}
+	}
+	if b.tokens != 0 {
+		t.Errorf("tokens after throttling = %v, want 0", b.tokens)
+	}
+
+	// no tokens accrue while the bucket is paused
+	paused := &tokenBucket{limit: 10, rate: 10, burst: 1, tokens: 1, last: time.Now()}
+	paused.throttle(time.Second)
+	if delay := paused.reserveLocked(paused.pausedUntil); delay != 200*time.Millisecond {
+		t.Errorf("delay when the pause ends = %v, want 200ms at the halved rate", delay)
+	}
+
+	b.recover()
+	if b.rate != 2.6 {
+		t.Errorf("rate after recovering = %v, want 2.6", b.rate)
+	}
+	for i := 0; i < 20; i++ {
+		b.recover()
+	}
+	if b.rate != 16 {
+		t.Errorf("rate after recovering fully = %v, want the limit 16", b.rate)
+	}
+}
+
+func TestRateLimiterWaitCancel(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit RateLimit
+	}{
+		{"empty bucket", RateLimit{RequestsPerSecond: 0.01}},
+		{"concurrency cap", RateLimit{MaxConcurrent: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiter(tt.limit)
+			release, err := rl.Wait(context.Background(), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer release()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			if _, err := rl.Wait(ctx, ""); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Wait error = %v, want context.DeadlineExceeded", err)
+			}
+			if waited := time.Since(start); waited > time.Second {
+				t.Errorf("Wait returned after %v, it should stop when the context is done", waited)
+			}
+		})
+	}
+}
+
+func TestRateLimiterModelLimit(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{})
+	rl.SetModelLimit("slow", RateLimit{MaxConcurrent: 1})
+
+	release, err := rl.Wait(context.Background(), "slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free, err := rl.Wait(context.Background(), "fast"); err != nil {
+		t.Fatalf("other model blocked: %v", err)
+	} else {
+		free()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := rl.Wait(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait on a full model limit error = %v, want context.DeadlineExceeded", err)
+	}
+	release()
+	if free, err := rl.Wait(context.Background(), "slow"); err != nil {
+		t.Errorf("Wait after release: %v", err)
+	} else {
+		free()
+	}
+}
+
+func TestRateLimiterModelLimitFirst(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{RequestsPerSecond: 0.01, Burst: 2})
+	rl.SetModelLimit("slow", RateLimit{MaxConcurrent: 1})
+
+	release, err := rl.Wait(context.Background(), "slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// waiting on the full model limit must not take the last global token
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := rl.Wait(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait on a full model limit error = %v, want context.DeadlineExceeded", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if free, err := rl.Wait(ctx, "fast"); err != nil {
+		t.Errorf("other model found no global token: %v", err)
+	} else {
+		free()
+	}
+}
+
+func TestClientThrottledRetries(t *testing.T) {
+	errThrottled := errors.New("throttled")
+	tests := []struct {
+		name       string
+		maxRetries int
+		throttles  int
+		wantCalls  int
+		wantErr    error
+	}{
+		{"succeeds first time", 3, 0, 1, nil},
+		{"retries until accepted", 3, 2, 3, nil},
+		{"gives up after max retries", 1, 5, 2, errThrottled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{RateLimiter: NewRateLimiter(RateLimit{RequestsPerSecond: 1000, Burst: 10})}
+			c.RateLimiter.MaxRetries = tt.maxRetries
+
+			calls := 0
+			err := c.throttled(context.Background(), "", func() attempt {
+				calls++
+				if calls <= tt.throttles {
+					return attempt{throttled: true, retryAfter: time.Millisecond, err: errThrottled}
+				}
+				return attempt{}
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientThrottledCancelDuringBackoff(t *testing.T) {
+	c := &Client{RateLimiter: NewRateLimiter(RateLimit{RequestsPerSecond: 1000})}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.throttled(ctx, "", func() attempt {
+		return attempt{throttled: true, retryAfter: time.Hour, err: errors.New("throttled")}
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("backoff ignored the context for %v", waited)
+	}
+}
+
+func TestGrpcContextCancelsRateLimit(t *testing.T) {
+	c := &Client{BaseURL: "localhost:0", RateLimiter: NewRateLimiter(RateLimit{MaxConcurrent: 1})}
+	release, err := c.RateLimiter.Wait(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GrpcGenerateObjectContext(ctx, "prompt", &pb.Definition{Type: "string"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GrpcGenerateObjectContext error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{"", 0, 0},
+		{"3", 3 * time.Second, 3 * time.Second},
+		{"soon", 0, 0},
+		{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), 58 * time.Second, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %v, want between %v and %v", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
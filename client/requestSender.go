@@ -21,7 +21,7 @@ func NewDefaultRequestSender(client *http.Client) *DefaultRequestSender {
 
 // SendRequestBody sends a JSON request and returns a response
 func (rs *DefaultRequestSender) SendRequestBody(baseURL, token string, requestBody *RequestBody) (*http.Response, error) {
-	url := baseURL + "/api/objectGen"
+	url := baseURL + requestBody.Path()
 
 	// Serialize the request body to JSON
 	jsonData, err := json.Marshal(requestBody)
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PriorityScheduler queues outgoing requests by Definition.Priority so that urgent work is sent first
+// when this process, rather than the server, is the bottleneck. Requests that wait gain one priority
+// level for every AgingInterval spent in the queue so that low priority work is never starved.
+type PriorityScheduler struct {
+	mu            sync.Mutex
+	maxConcurrent int
+	agingInterval time.Duration
+	inFlight      int
+	seq           uint64
+	waiting       []*scheduledRequest
+	dispatched    map[int32]uint64
+}
+
+// scheduledRequest is a single request waiting for a slot
+type scheduledRequest struct {
+	priority int32
+	enqueued time.Time
+	seq      uint64
+	ready    chan struct{}
+}
+
+// SchedulerMetrics is a point in time snapshot of the scheduler queue
+type SchedulerMetrics struct {
+	InFlight        int              `json:"inFlight"`
+	QueueDepth      int              `json:"queueDepth"`
+	DepthByPriority map[int32]int    `json:"depthByPriority"`
+	Dispatched      map[int32]uint64 `json:"dispatched"` //total requests released per original priority
+	OldestWait      time.Duration    `json:"oldestWait"`
+}
+
+// NewPriorityScheduler initializes a PriorityScheduler allowing maxConcurrent requests in flight.
+// A maxConcurrent below one is treated as one and an agingInterval of zero disables aging.
+func NewPriorityScheduler(maxConcurrent int, agingInterval time.Duration) *PriorityScheduler {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &PriorityScheduler{
+		maxConcurrent: maxConcurrent,
+		agingInterval: agingInterval,
+		dispatched:    make(map[int32]uint64),
+	}
+}
+
+// Acquire blocks until the request may be sent and returns the function that frees its slot.
+// The release function must be called once the request has completed; calling it more than once is safe.
+func (s *PriorityScheduler) Acquire(ctx context.Context, priority int32) (func(), error) {
+	s.mu.Lock()
+	if s.inFlight < s.maxConcurrent && len(s.waiting) == 0 {
+		s.inFlight++
+		s.dispatched[priority]++
+		s.mu.Unlock()
+		return s.releaseFunc(), nil
+	}
+
+	s.seq++
+	req := &scheduledRequest{
+		priority: priority,
+		enqueued: time.Now(),
+		seq:      s.seq,
+		ready:    make(chan struct{}),
+	}
+	s.waiting = append(s.waiting, req)
+	s.mu.Unlock()
+
+	select {
+	case <-req.ready:
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		removed := s.removeLocked(req)
+		s.mu.Unlock()
+		if !removed {
+			// the slot was granted while the context was being cancelled so hand it back
+			s.releaseFunc()()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// Metrics returns the current queue depth and dispatch counters
+func (s *PriorityScheduler) Metrics() SchedulerMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := SchedulerMetrics{
+		InFlight:        s.inFlight,
+		QueueDepth:      len(s.waiting),
+		DepthByPriority: make(map[int32]int),
+		Dispatched:      make(map[int32]uint64, len(s.dispatched)),
+	}
+	now := time.Now()
+	for _, req := range s.waiting {
+		metrics.DepthByPriority[req.priority]++
+		if wait := now.Sub(req.enqueued); wait > metrics.OldestWait {
+			metrics.OldestWait = wait
+		}
+	}
+	for priority, count := range s.dispatched {
+		metrics.Dispatched[priority] = count
+	}
+	return metrics
+}
+
+// releaseFunc returns an idempotent function that frees a slot and dispatches the next request
+func (s *PriorityScheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.inFlight--
+			s.dispatchLocked()
+		})
+	}
+}
+
+// dispatchLocked hands free slots to the waiting requests with the highest effective priority
+func (s *PriorityScheduler) dispatchLocked() {
+	now := time.Now()
+	for s.inFlight < s.maxConcurrent && len(s.waiting) > 0 {
+		best := 0
+		for i := 1; i < len(s.waiting); i++ {
+			if s.before(s.waiting[i], s.waiting[best], now) {
+				best = i
+			}
+		}
+		req := s.waiting[best]
+		s.waiting = append(s.waiting[:best], s.waiting[best+1:]...)
+		s.inFlight++
+		s.dispatched[req.priority]++
+		close(req.ready)
+	}
+}
+
+// before reports whether a should be dispatched ahead of b, falling back to arrival order on ties
+func (s *PriorityScheduler) before(a, b *scheduledRequest, now time.Time) bool {
+	pa, pb := s.effectivePriority(a, now), s.effectivePriority(b, now)
+	if pa != pb {
+		return pa > pb
+	}
+	return a.seq < b.seq
+}
+
+// effectivePriority raises the priority of a request by one level per aging interval waited
+func (s *PriorityScheduler) effectivePriority(req *scheduledRequest, now time.Time) int64 {
+	priority := int64(req.priority)
+	if s.agingInterval > 0 {
+		priority += int64(now.Sub(req.enqueued) / s.agingInterval)
+	}
+	return priority
+}
+
+// removeLocked drops a request from the queue, reporting false if it had already been dispatched
+func (s *PriorityScheduler) removeLocked(req *scheduledRequest) bool {
+	for i, waiting := range s.waiting {
+		if waiting == req {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
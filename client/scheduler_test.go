@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// waitForQueue blocks until the scheduler has depth requests waiting
+func waitForQueue(t *testing.T, s *PriorityScheduler, depth int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for s.Metrics().QueueDepth != depth {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue depth = %d, want %d", s.Metrics().QueueDepth, depth)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPrioritySchedulerOrder(t *testing.T) {
+	s := NewPriorityScheduler(1, 0)
+	release, err := s.Acquire(context.Background(), jsonSchema.StandardPriority)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := make(chan int32, 3)
+	priorities := []int32{-1, 2, 0}
+	for i, priority := range priorities {
+		go func(priority int32) {
+			free, err := s.Acquire(context.Background(), priority)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			order <- priority
+			free()
+		}(priority)
+		waitForQueue(t, s, i+1)
+	}
+	release()
+
+	want := []int32{2, 0, -1}
+	for _, priority := range want {
+		if got := <-order; got != priority {
+			t.Fatalf("dispatched priority %d, want %d", got, priority)
+		}
+	}
+	if metrics := s.Metrics(); metrics.Dispatched[2] != 1 || metrics.Dispatched[-1] != 1 {
+		t.Errorf("metrics = %+v", metrics)
+	}
+}
+
+func TestPrioritySchedulerAging(t *testing.T) {
+	s := NewPriorityScheduler(1, time.Second)
+	now := time.Now()
+	tests := []struct {
+		name string
+		a, b *scheduledRequest
+		want bool
+	}{
+		{"higher priority first", &scheduledRequest{priority: 2, enqueued: now, seq: 2}, &scheduledRequest{priority: 0, enqueued: now, seq: 1}, true},
+		{"arrival order on ties", &scheduledRequest{priority: 1, enqueued: now, seq: 1}, &scheduledRequest{priority: 1, enqueued: now, seq: 2}, true},
+		{"aged request overtakes", &scheduledRequest{priority: 0, enqueued: now.Add(-3 * time.Second), seq: 1}, &scheduledRequest{priority: 2, enqueued: now, seq: 2}, true},
+		{"partly aged request waits", &scheduledRequest{priority: 0, enqueued: now.Add(-1500 * time.Millisecond), seq: 1}, &scheduledRequest{priority: 2, enqueued: now, seq: 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.before(tt.a, tt.b, now); got != tt.want {
+				t.Errorf("before = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrioritySchedulerCancel(t *testing.T) {
+	s := NewPriorityScheduler(1, 0)
+	release, err := s.Acquire(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := s.Acquire(ctx, 0)
+		done <- err
+	}()
+	waitForQueue(t, s, 1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Acquire error = %v, want context.Canceled", err)
+	}
+	if depth := s.Metrics().QueueDepth; depth != 0 {
+		t.Errorf("queue depth after cancel = %d, want 0", depth)
+	}
+}
+
+func TestGrpcContextCancelsScheduling(t *testing.T) {
+	c := &Client{BaseURL: "localhost:0", Scheduler: NewPriorityScheduler(1, 0)}
+	release, err := c.Scheduler.Acquire(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	definition := &pb.Definition{Type: "string"}
+
+	if _, err := c.GrpcGenerateObjectContext(ctx, "prompt", definition); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GrpcGenerateObjectContext error = %v, want context.DeadlineExceeded", err)
+	}
+	handler := func(*StreamingResponse) error { return nil }
+	if err := c.GrpcStreamGeneratedObjectsContext(ctx, "prompt", definition, handler); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GrpcStreamGeneratedObjectsContext error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Command objectweaver-gen generates typed code from a jsonSchema.Definition or ComplexSystem stored as JSON.
+//
+// It is intended to be run through go:generate, for example:
+//
+//	//go:generate go run github.com/objectweaver/go-sdk/cmd/objectweaver-gen -in car.json -type Car
+//
+// which writes car_gen.go next to car.json, in the package being generated. With -lang ts it writes
+// TypeScript declarations instead, car.d.ts by default, and with -lang mermaid or -lang dot a diagram of
+// the fields and decision trees, car.mmd or car.dot by default.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/objectweaver/go-sdk/codegen"
+)
+
+// config holds the command line flags
+type config struct {
+	in, out, lang, pkg, typeName string
+	embed, instructions          bool
+}
+
+// outputSuffixes are the default suffixes of the generated files for each -lang
+var outputSuffixes = map[string]string{
+	"go":      "_gen.go",
+	"ts":      ".d.ts",
+	"mermaid": ".mmd",
+	"dot":     ".dot",
+}
+
+func main() {
+	var c config
+	flag.StringVar(&c.in, "in", "", "the Definition or ComplexSystem JSON file")
+	flag.StringVar(&c.out, "out", "", "the file to write, defaults to the input file with a _gen.go, .d.ts, .mmd or .dot suffix")
+	flag.StringVar(&c.lang, "lang", "go", "the language to generate, go, ts, mermaid or dot")
+	flag.StringVar(&c.pkg, "pkg", os.Getenv("GOPACKAGE"), "the package of the generated Go file, defaults to the package run by go generate")
+	flag.StringVar(&c.typeName, "type", "", "the name of the root type, defaults to the ComplexSystem name or Object")
+	flag.BoolVar(&c.embed, "embed", true, "embed the schema file with go:embed instead of inlining it, when it sits under the output directory")
+	flag.BoolVar(&c.instructions, "instructions", false, "include the instructions in mermaid and dot diagrams")
+	flag.Parse()
+
+	if err := run(c); err != nil {
+		fmt.Fprintln(os.Stderr, "objectweaver-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(c config) error {
+	if c.in == "" {
+		return fmt.Errorf("-in is required")
+	}
+	suffix, ok := outputSuffixes[c.lang]
+	if !ok {
+		return fmt.Errorf("unsupported -lang %q, expected go, ts, mermaid or dot", c.lang)
+	}
+	if c.out == "" {
+		c.out = strings.TrimSuffix(c.in, filepath.Ext(c.in)) + suffix
+	}
+
+	data, err := os.ReadFile(c.in)
+	if err != nil {
+		return fmt.Errorf("error reading schema: %v", err)
+	}
+	schema, err := codegen.ParseSchema(data)
+	if err != nil {
+		return err
+	}
+
+	var source []byte
+	switch c.lang {
+	case "ts":
+		source, err = codegen.GenerateTypeScript(schema, codegen.TypeScriptOptions{TypeName: c.typeName})
+	case "mermaid":
+		source, err = codegen.GenerateMermaid(schema, codegen.DiagramOptions{Instructions: c.instructions})
+	case "dot":
+		source, err = codegen.GenerateDOT(schema, codegen.DiagramOptions{Instructions: c.instructions})
+	default:
+		options := codegen.GoOptions{Package: c.pkg, TypeName: c.typeName}
+		if c.embed {
+			options.EmbedPath = embedPath(c.in, c.out)
+		}
+		source, err = codegen.GenerateGo(schema, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.out, source, 0644); err != nil {
+		return fmt.Errorf("error writing generated code: %v", err)
+	}
+	return nil
+}
+
+// embedPath returns the path of the schema relative to the generated file, or an empty string when go:embed cannot reach it
+func embedPath(in, out string) string {
+	absIn, err := filepath.Abs(in)
+	if err != nil {
+		return ""
+	}
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(filepath.Dir(absOut), absIn)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
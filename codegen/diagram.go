@@ -0,0 +1,283 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// DiagramOptions configures GenerateMermaid and GenerateDOT
+type DiagramOptions struct {
+	Instructions bool // include the first line of each instruction in the nodes, shortened to fit
+}
+
+// maxInstructionLabel is the number of characters of an instruction shown in a node
+const maxInstructionLabel = 60
+
+// nodeShape is the kind of thing a diagram node stands for, each rendered with its own shape
+type nodeShape int
+
+const (
+	shapeObject   nodeShape = iota // objects and the ComplexSystem itself
+	shapeField                     // every other Definition
+	shapeDecision                  // DecisionPoints, including loop termination points
+	shapeLoop                      // RecursiveLoops
+	shapeThread                    // main threads
+	shapeRule                      // intervention rules
+)
+
+// edgeStyle is the relation a diagram edge stands for
+type edgeStyle int
+
+const (
+	edgeContains edgeStyle = iota // properties, items, map values and branch targets
+	edgeOrder                     // ProcessingOrder and decision logic
+	edgeBranch                    // DecisionPoint branches, labelled with their conditions
+	edgeMonitor                   // loops and main thread supervision
+)
+
+type diagramNode struct {
+	id    string
+	shape nodeShape
+	lines []string
+}
+
+type diagramEdge struct {
+	from, to, label string
+	style           edgeStyle
+}
+
+// diagram is the graph of a schema, rendered by the Mermaid and DOT generators
+type diagram struct {
+	options DiagramOptions
+	nodes   []diagramNode
+	edges   []diagramEdge
+}
+
+// buildDiagram draws the fields of the schema with their processing order, decision points with the conditions
+// of their branches, recursive loops and, for a ComplexSystem, the main thread and its intervention rules
+func buildDiagram(schema *Schema, options DiagramOptions) *diagram {
+	g := &diagram{options: options}
+	if schema.System == nil {
+		g.definition(schema.Definition, defaultTypeName(schema))
+		return g
+	}
+
+	system := schema.System
+	lines := []string{"system: " + system.Name}
+	if system.Version != "" {
+		lines = append(lines, "version "+system.Version)
+	}
+	systemID := g.node(shapeObject, lines...)
+	g.edge(systemID, g.definition(&system.RootSchema, "rootSchema"), "", edgeContains)
+
+	from, label := systemID, "supervises"
+	for thread := system.MainThread; thread != nil; thread = thread.ParentThread {
+		threadID := g.thread(thread)
+		g.edge(threadID, from, label, edgeMonitor)
+		from, label = threadID, "reports to"
+	}
+	return g
+}
+
+func (g *diagram) node(shape nodeShape, lines ...string) string {
+	id := fmt.Sprintf("n%d", len(g.nodes))
+	g.nodes = append(g.nodes, diagramNode{id: id, shape: shape, lines: lines})
+	return id
+}
+
+func (g *diagram) edge(from, to, label string, style edgeStyle) {
+	g.edges = append(g.edges, diagramEdge{from: from, to: to, label: label, style: style})
+}
+
+// definition draws the Definition and everything nested in it, returning the id of its node
+func (g *diagram) definition(def *jsonSchema.Definition, name string) string {
+	shape := shapeField
+	if def.Type == jsonSchema.Object {
+		shape = shapeObject
+	}
+	lines := []string{fmt.Sprintf("%s: %s", name, def.Type)}
+	if g.options.Instructions && def.Instruction != "" {
+		lines = append(lines, shortenInstruction(def.Instruction))
+	}
+	id := g.node(shape, lines...)
+
+	ids := make(map[string]string, len(def.Properties))
+	for _, key := range orderedKeys(def) {
+		property := def.Properties[key]
+		ids[key] = g.definition(&property, key)
+		g.edge(id, ids[key], "", edgeContains)
+	}
+	var previous string
+	for _, key := range def.ProcessingOrder {
+		if ids[key] == "" {
+			continue
+		}
+		if previous != "" {
+			g.edge(ids[previous], ids[key], "before", edgeOrder)
+		}
+		previous = key
+	}
+
+	if def.Items != nil {
+		g.edge(id, g.definition(def.Items, name+"[]"), "items", edgeContains)
+	}
+	if def.HashMap != nil && def.HashMap.FieldDefinition != nil {
+		g.edge(id, g.definition(def.HashMap.FieldDefinition, name+"[key]"), "values", edgeContains)
+	}
+	if def.DecisionPoint != nil {
+		g.edge(id, g.decision(def.DecisionPoint, "decision"), "", edgeContains)
+	}
+	if loop := def.RecursiveLoop; loop != nil {
+		lines := []string{fmt.Sprintf("loop: up to %d iterations", loop.MaxIterations)}
+		if loop.Selection != "" {
+			lines = append(lines, fmt.Sprintf("keeps %s", loop.Selection))
+		}
+		loopID := g.node(shapeLoop, lines...)
+		g.edge(id, loopID, "regenerated by", edgeMonitor)
+		if loop.TerminationPoint != nil {
+			g.edge(loopID, g.decision(loop.TerminationPoint, "stop when"), "", edgeMonitor)
+		}
+	}
+	return id
+}
+
+// decision draws a DecisionPoint with an edge to the Then of each branch, labelled with its conditions
+func (g *diagram) decision(decision *jsonSchema.DecisionPoint, kind string) string {
+	lines := []string{kind}
+	if decision.Name != "" {
+		lines[0] = fmt.Sprintf("%s: %s", kind, decision.Name)
+	}
+	if decision.Strategy != "" {
+		lines = append(lines, fmt.Sprintf("by %s", decision.Strategy))
+	}
+	id := g.node(shapeDecision, lines...)
+
+	for i := range decision.Branches {
+		branch := &decision.Branches[i]
+		name := branch.Name
+		if name == "" {
+			name = fmt.Sprintf("branch %d", i)
+		}
+		if branch.Logic != nil {
+			g.edge(id, g.definition(branch.Logic, name+" logic"), "evaluated with", edgeOrder)
+		}
+		g.edge(id, g.definition(&branch.Then, name), branchLabel(branch), edgeBranch)
+	}
+	return id
+}
+
+// thread draws a main thread and its intervention rules, highest priority first
+func (g *diagram) thread(thread *jsonSchema.MainThreadConfig) string {
+	lines := []string{"main thread"}
+	if thread.MonitoringStrategy != "" {
+		lines = append(lines, fmt.Sprintf("monitors %s", thread.MonitoringStrategy))
+	}
+	id := g.node(shapeThread, lines...)
+
+	rules := make([]*jsonSchema.InterventionRule, len(thread.InterventionRules))
+	for i := range thread.InterventionRules {
+		rules[i] = &thread.InterventionRules[i]
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+
+	for _, rule := range rules {
+		lines := []string{"rule"}
+		if rule.Name != "" {
+			lines[0] = "rule: " + rule.Name
+		}
+		if rule.Action.Type != "" {
+			lines = append(lines, fmt.Sprintf("action %s", rule.Action.Type))
+		}
+		ruleID := g.node(shapeRule, lines...)
+		g.edge(id, ruleID, triggerLabel(rule.Trigger), edgeMonitor)
+		if rule.Action.ModifyDefinition != nil {
+			g.edge(ruleID, g.definition(rule.Action.ModifyDefinition, "modifyDefinition"), "replaces with", edgeContains)
+		}
+	}
+	return id
+}
+
+var operatorSymbols = map[jsonSchema.ComparisonOperator]string{
+	jsonSchema.OpEqual:              "==",
+	jsonSchema.OpNotEqual:           "!=",
+	jsonSchema.OpGreaterThan:        ">",
+	jsonSchema.OpLessThan:           "<",
+	jsonSchema.OpGreaterThanOrEqual: ">=",
+	jsonSchema.OpLessThanOrEqual:    "<=",
+	jsonSchema.OpIn:                 "in",
+	jsonSchema.OpNotIn:              "not in",
+	jsonSchema.OpContains:           "contains",
+}
+
+// branchLabel describes the conditions of a branch, ie "improve: accuracy > 70 AND readability < 80"
+func branchLabel(branch *jsonSchema.ConditionalBranch) string {
+	conditions := make([]string, len(branch.Conditions))
+	for i, condition := range branch.Conditions {
+		field := condition.FieldPath
+		if field == "" {
+			field = condition.Field
+		}
+		operator, ok := operatorSymbols[condition.Operator]
+		if !ok {
+			operator = string(condition.Operator)
+		}
+		value, err := json.Marshal(condition.Value)
+		if err != nil {
+			value = []byte(fmt.Sprint(condition.Value))
+		}
+		conditions[i] = fmt.Sprintf("%s %s %s", field, operator, value)
+	}
+
+	label := strings.Join(conditions, " AND ")
+	if label == "" {
+		label = "always"
+	}
+	if branch.Priority != 0 {
+		label += fmt.Sprintf(" (priority %d)", branch.Priority)
+	}
+	return label
+}
+
+// triggerLabel describes when an intervention rule triggers, ie "low_score: quality < 50"
+func triggerLabel(trigger jsonSchema.InterventionTrigger) string {
+	var details []string
+	for _, name := range sortedNames(trigger.ScoreThresholds) {
+		operator := "<"
+		if trigger.Type == jsonSchema.TriggerHighScore {
+			operator = ">"
+		}
+		details = append(details, fmt.Sprintf("%s %s %v", name, operator, trigger.ScoreThresholds[name]))
+	}
+	if trigger.PathDepth != nil {
+		details = append(details, fmt.Sprintf("depth %d", *trigger.PathDepth))
+	}
+	if trigger.CustomCondition != "" {
+		details = append(details, shortenInstruction(trigger.CustomCondition))
+	}
+	if len(details) == 0 {
+		return string(trigger.Type)
+	}
+	return fmt.Sprintf("%s: %s", trigger.Type, strings.Join(details, ", "))
+}
+
+// shortenInstruction returns the first line of the instruction, cut to maxInstructionLabel characters
+func shortenInstruction(instruction string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(instruction), "\n")
+	if runes := []rune(line); len(runes) > maxInstructionLabel {
+		return strings.TrimSpace(string(runes[:maxInstructionLabel-1])) + "…"
+	}
+	return line
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,224 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// orderedLesson has two fields generated in order, the first with a multi line instruction
+var orderedLesson = jsonSchema.Definition{
+	Type:            jsonSchema.Object,
+	ProcessingOrder: []string{"title", "body"},
+	Properties: map[string]jsonSchema.Definition{
+		"title": {Type: jsonSchema.String, Instruction: "A title\nwithout punctuation"},
+		"body":  {Type: jsonSchema.String},
+	},
+}
+
+func TestGenerateMermaid(t *testing.T) {
+	source, err := GenerateMermaid(&Schema{Definition: &orderedLesson}, DiagramOptions{Instructions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `flowchart TD
+    n0["Object: object"]
+    n1("title: string<br/>A title")
+    n2("body: string")
+    n0 --> n1
+    n0 --> n2
+    n1 -.->|"before"| n2
+`
+	if string(source) != want {
+		t.Errorf("GenerateMermaid() =\n%s\nwant\n%s", source, want)
+	}
+}
+
+func TestGenerateDOT(t *testing.T) {
+	source, err := GenerateDOT(&Schema{Definition: &orderedLesson}, DiagramOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph objectweaver {
+    rankdir=TB;
+    node [fontname="Helvetica"];
+    edge [fontname="Helvetica", fontsize=10];
+    n0 [label="Object: object", shape=box];
+    n1 [label="title: string", shape=box, style="rounded"];
+    n2 [label="body: string", shape=box, style="rounded"];
+    n0 -> n1;
+    n0 -> n2;
+    n1 -> n2 [label="before", style=dashed];
+}
+`
+	if string(source) != want {
+		t.Errorf("GenerateDOT() =\n%s\nwant\n%s", source, want)
+	}
+}
+
+func TestBuildDiagram(t *testing.T) {
+	depth := 3
+	tests := []struct {
+		name   string
+		schema *Schema
+		edges  []string //in drawing order, the edges of a child before the edge to it
+	}{
+		{
+			name: "items and map values",
+			schema: &Schema{Definition: &jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+				"tags":   {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.String}},
+				"scores": {Type: jsonSchema.Map, HashMap: &jsonSchema.HashMap{FieldDefinition: &jsonSchema.Definition{Type: jsonSchema.Number}}},
+			}}},
+			edges: []string{
+				"scores: map --values--> scores[key]: number",
+				"Object: object --> scores: map",
+				"tags: array --items--> tags[]: string",
+				"Object: object --> tags: array",
+			},
+		},
+		{
+			name: "decision point",
+			schema: &Schema{Definition: &jsonSchema.Definition{
+				Type: jsonSchema.String,
+				DecisionPoint: &jsonSchema.DecisionPoint{
+					Name:     "route",
+					Strategy: jsonSchema.RouteByScore,
+					Branches: []jsonSchema.ConditionalBranch{
+						{
+							Name:       "improve",
+							Conditions: []jsonSchema.Condition{{Field: "accuracy", Operator: jsonSchema.OpLessThan, Value: 70}},
+							Logic:      &jsonSchema.Definition{Type: jsonSchema.Boolean},
+							Then:       jsonSchema.Definition{Type: jsonSchema.String},
+							Priority:   2,
+						},
+						{Then: jsonSchema.Definition{Type: jsonSchema.String}},
+					},
+				},
+			}},
+			edges: []string{
+				"decision: route --evaluated with--> improve logic: boolean",
+				"decision: route --accuracy < 70 (priority 2)--> improve: string",
+				"decision: route --always--> branch 1: string",
+				"Object: string --> decision: route",
+			},
+		},
+		{
+			name: "recursive loop",
+			schema: &Schema{Definition: &jsonSchema.Definition{
+				Type: jsonSchema.String,
+				RecursiveLoop: &jsonSchema.RecursiveLoop{
+					MaxIterations: 3,
+					Selection:     jsonSchema.SelectHighestScore,
+					TerminationPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{{
+						Name:       "done",
+						Conditions: []jsonSchema.Condition{{Field: "quality", Operator: jsonSchema.OpIn, Value: []string{"good", "great"}}},
+						Then:       jsonSchema.Definition{Type: jsonSchema.Null},
+					}}},
+				},
+			}},
+			edges: []string{
+				"Object: string --regenerated by--> loop: up to 3 iterations",
+				"stop when --quality in [\"good\",\"great\"]--> done: null",
+				"loop: up to 3 iterations --> stop when",
+			},
+		},
+		{
+			name: "complex system",
+			schema: &Schema{System: &jsonSchema.ComplexSystem{
+				Name:       "writer",
+				Version:    "2",
+				RootSchema: jsonSchema.Definition{Type: jsonSchema.String},
+				MainThread: &jsonSchema.MainThreadConfig{
+					MonitoringStrategy: jsonSchema.MonitorContinuous,
+					InterventionRules: []jsonSchema.InterventionRule{
+						{Name: "late", Trigger: jsonSchema.InterventionTrigger{Type: jsonSchema.TriggerDepthExceeded, PathDepth: &depth}},
+						{
+							Name:     "weak",
+							Priority: 1,
+							Trigger:  jsonSchema.InterventionTrigger{Type: jsonSchema.TriggerLowScore, ScoreThresholds: map[string]float64{"quality": 50, "accuracy": 60}},
+							Action:   jsonSchema.InterventionAction{Type: jsonSchema.ActionOverride, ModifyDefinition: &jsonSchema.Definition{Type: jsonSchema.String}},
+						},
+					},
+					ParentThread: &jsonSchema.MainThreadConfig{},
+				},
+			}},
+			edges: []string{
+				"system: writer --> rootSchema: string",
+				"main thread --low_score: accuracy < 60, quality < 50--> rule: weak",
+				"rule: weak --replaces with--> modifyDefinition: string",
+				"main thread --depth_exceeded: depth 3--> rule: late",
+				"main thread --supervises--> system: writer",
+				"main thread --reports to--> main thread",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildDiagram(tt.schema, DiagramOptions{})
+			labels := make(map[string]string, len(g.nodes))
+			for _, node := range g.nodes {
+				labels[node.id] = node.lines[0]
+			}
+			var edges []string
+			for _, edge := range g.edges {
+				arrow := " --> "
+				if edge.label != "" {
+					arrow = " --" + edge.label + "--> "
+				}
+				edges = append(edges, labels[edge.from]+arrow+labels[edge.to])
+			}
+			if strings.Join(edges, "\n") != strings.Join(tt.edges, "\n") {
+				t.Errorf("edges =\n%s\nwant\n%s", strings.Join(edges, "\n"), strings.Join(tt.edges, "\n"))
+			}
+		})
+	}
+}
+
+func TestShortenInstruction(t *testing.T) {
+	long := strings.Repeat("a", maxInstructionLabel+10)
+	tests := []struct {
+		name        string
+		instruction string
+		want        string
+	}{
+		{"short", "A title", "A title"},
+		{"first line", "  A title\nwithout punctuation", "A title"},
+		{"exactly the limit", long[:maxInstructionLabel], long[:maxInstructionLabel]},
+		{"too long", long, long[:maxInstructionLabel-1] + "…"},
+		{"runes", strings.Repeat("é", maxInstructionLabel+1), strings.Repeat("é", maxInstructionLabel-1) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shortenInstruction(tt.instruction); got != tt.want {
+				t.Errorf("shortenInstruction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagramEscaping(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		mermaid string
+		dot     string
+	}{
+		{"plain", "title: string", "title: string", `"title: string"`},
+		{"quotes", `level == "Expert"`, "level == #34;Expert#34;", `"level == \"Expert\""`},
+		{"comparison", "a < 1 & b > 2", "a #60; 1 #38; b #62; 2", `"a < 1 & b > 2"`},
+		{"hash", "#1", "#35;1", `"#1"`},
+		{"lines", "a\nb", "a<br/>b", `"a\nb"`},
+		{"backslash", `a\b`, `a\b`, `"a\\b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mermaidText(tt.text); got != tt.mermaid {
+				t.Errorf("mermaidText() = %q, want %q", got, tt.mermaid)
+			}
+			if got := dotString(tt.text); got != tt.dot {
+				t.Errorf("dotString() = %q, want %q", got, tt.dot)
+			}
+		})
+	}
+}
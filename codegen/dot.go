@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dotShapes are the Graphviz shapes of each kind of node
+var dotShapes = map[nodeShape]string{
+	shapeObject:   "box",
+	shapeField:    `box, style="rounded"`,
+	shapeDecision: "diamond",
+	shapeLoop:     "ellipse",
+	shapeThread:   "box3d",
+	shapeRule:     "hexagon",
+}
+
+// dotStyles are the Graphviz attributes of each kind of edge
+var dotStyles = map[edgeStyle]string{
+	edgeContains: "",
+	edgeOrder:    "style=dashed",
+	edgeBranch:   "penwidth=2",
+	edgeMonitor:  "style=dotted",
+}
+
+// GenerateDOT renders the schema as a Graphviz DOT graph, drawn the same way as GenerateMermaid
+func GenerateDOT(schema *Schema, options DiagramOptions) ([]byte, error) {
+	g := buildDiagram(schema, options)
+
+	var out strings.Builder
+	out.WriteString("digraph objectweaver {\n")
+	out.WriteString("    rankdir=TB;\n")
+	out.WriteString("    node [fontname=\"Helvetica\"];\n")
+	out.WriteString("    edge [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, node := range g.nodes {
+		fmt.Fprintf(&out, "    %s [label=%s, shape=%s];\n", node.id, dotString(strings.Join(node.lines, "\n")), dotShapes[node.shape])
+	}
+	for _, edge := range g.edges {
+		var attributes []string
+		if edge.label != "" {
+			attributes = append(attributes, "label="+dotString(edge.label))
+		}
+		if style := dotStyles[edge.style]; style != "" {
+			attributes = append(attributes, style)
+		}
+		if len(attributes) == 0 {
+			fmt.Fprintf(&out, "    %s -> %s;\n", edge.from, edge.to)
+			continue
+		}
+		fmt.Fprintf(&out, "    %s -> %s [%s];\n", edge.from, edge.to, strings.Join(attributes, ", "))
+	}
+	out.WriteString("}\n")
+	return []byte(out.String()), nil
+}
+
+// dotString quotes text as a DOT string, lines separated with \n
+func dotString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
@@ -0,0 +1,282 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// GoOptions configures GenerateGo
+type GoOptions struct {
+	Package   string // package of the generated file
+	TypeName  string // name of the root type, defaults to the ComplexSystem name or Object
+	EmbedPath string // schema file embedded with go:embed, relative to the generated file. The schema is inlined when empty
+}
+
+// GenerateGo generates Go types for the object described by the schema, together with a function returning the
+// Definition (or ComplexSystem) and a typed Generate function sending it through a client.Client.
+// Objects become structs with json tags and the instructions as doc comments, Map and HashMap become maps,
+// Byte []byte and Vector []float32. String fields checked against a list of values by DecisionPoint conditions
+// using OpIn get a named string type with a constant per value. Property keys that encoding/json can't name in a
+// struct tag, such as empty keys or keys with a comma, quote or backtick, are reported as an error.
+func GenerateGo(schema *Schema, options GoOptions) ([]byte, error) {
+	if options.Package == "" {
+		return nil, fmt.Errorf("a package name is required")
+	}
+	typeName := options.TypeName
+	if typeName == "" {
+		typeName = defaultTypeName(schema)
+	}
+
+	generator := &goGenerator{enums: enumValues(schema.Definition), taken: make(map[string]bool)}
+	for _, reserved := range []string{typeName, "Generate" + typeName, typeName + "Definition", typeName + "System"} {
+		generator.taken[reserved] = true
+	}
+	if rootType := generator.goType(schema.Definition, typeName, "", "", true); rootType != typeName {
+		generator.declare(fmt.Sprintf("// %s is the object generated by ObjectWeaver\ntype %s %s\n", typeName, typeName, rootType))
+	}
+	if generator.err != nil {
+		return nil, generator.err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by objectweaver-gen. DO NOT EDIT.\n\npackage %s\n\n", options.Package)
+	out.WriteString("import (\n\t\"context\"\n")
+	if options.EmbedPath != "" {
+		out.WriteString("\t_ \"embed\"\n")
+	}
+	out.WriteString("\t\"encoding/json\"\n\t\"fmt\"\n\n\t\"github.com/objectweaver/go-sdk/client\"\n\t\"github.com/objectweaver/go-sdk/jsonSchema\"\n)\n\n")
+
+	schemaVar := lowerFirst(typeName) + "SchemaJSON"
+	if options.EmbedPath != "" {
+		fmt.Fprintf(&out, "//go:embed %s\nvar %s []byte\n\n", options.EmbedPath, schemaVar)
+	} else {
+		fmt.Fprintf(&out, "var %s = []byte(%s)\n\n", schemaVar, goStringLiteral(string(schema.JSON)))
+	}
+
+	for _, decl := range generator.decls {
+		out.WriteString(decl)
+		out.WriteString("\n")
+	}
+	writeGoGenerate(&out, schema, typeName, schemaVar)
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %v", err)
+	}
+	return source, nil
+}
+
+// writeGoGenerate writes the function returning the schema and the typed Generate function
+func writeGoGenerate(out *bytes.Buffer, schema *Schema, typeName, schemaVar string) {
+	schemaType, schemaFunc, send := "Definition", typeName+"Definition", "SendRequestContext"
+	if schema.System != nil {
+		schemaType, schemaFunc, send = "ComplexSystem", typeName+"System", "SendComplexRequestContext"
+	}
+
+	fmt.Fprintf(out, `// %[2]s returns the %[3]s the %[1]s type was generated from
+func %[2]s() (*jsonSchema.%[3]s, error) {
+	var schema jsonSchema.%[3]s
+	if err := json.Unmarshal(%[4]s, &schema); err != nil {
+		return nil, fmt.Errorf("error decoding %[1]s schema: %%v", err)
+	}
+	return &schema, nil
+}
+
+// Generate%[1]s sends the prompt with the %[1]s schema and decodes the generated object
+func Generate%[1]s(ctx context.Context, c *client.Client, prompt string) (*%[1]s, *client.Response, error) {
+	schema, err := %[2]s()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := c.%[5]s(ctx, prompt, schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(response.Data)
+	if err != nil {
+		return nil, response, fmt.Errorf("error encoding %[1]s data: %%v", err)
+	}
+	var result %[1]s
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, response, fmt.Errorf("error decoding %[1]s data: %%v", err)
+	}
+	return &result, response, nil
+}
+`, typeName, schemaFunc, schemaType, schemaVar, send)
+}
+
+// goGenerator collects the type declarations of a single GenerateGo call
+type goGenerator struct {
+	enums map[string][]string
+	taken map[string]bool
+	decls []string
+	err   error // the first property that can't be written as a struct field
+}
+
+func (g *goGenerator) declare(decl string) {
+	g.decls = append(g.decls, decl)
+}
+
+// goType returns the Go type of a Definition, declaring named types for objects and enums as it goes.
+// path is the dotted path of the value from the root and key its property name.
+func (g *goGenerator) goType(def *jsonSchema.Definition, name, path, key string, root bool) string {
+	if def == nil {
+		return "any"
+	}
+	if def.HashMap != nil || def.Type == jsonSchema.Map {
+		if def.HashMap == nil || def.HashMap.FieldDefinition == nil {
+			return "map[string]any"
+		}
+		return "map[string]" + g.goType(def.HashMap.FieldDefinition, name+"Value", joinPath(path, "*"), "", false)
+	}
+
+	switch def.Type {
+	case jsonSchema.Object:
+		if len(def.Properties) == 0 {
+			return "map[string]any"
+		}
+		return g.declareStruct(def, name, path, root)
+	case jsonSchema.Array:
+		if def.Items == nil {
+			return "[]any"
+		}
+		return "[]" + g.goType(def.Items, name+"Item", path+"[]", "", false)
+	case jsonSchema.String:
+		if values := g.enums[path]; len(values) > 0 {
+			return g.declareEnum(name, key, values)
+		}
+		return "string"
+	case jsonSchema.Integer:
+		return "int64"
+	case jsonSchema.Number:
+		return "float64"
+	case jsonSchema.Boolean:
+		return "bool"
+	case jsonSchema.Byte:
+		return "[]byte"
+	case jsonSchema.Vector:
+		return "[]float32"
+	}
+	return "any"
+}
+
+// declareStruct declares the struct of an object, reserving its place so parents are declared before their children
+func (g *goGenerator) declareStruct(def *jsonSchema.Definition, name, path string, root bool) string {
+	if !root {
+		name = uniqueName(name, g.taken)
+	}
+	index := len(g.decls)
+	g.declare("")
+
+	var decl strings.Builder
+	writeGoDoc(&decl, "", fmt.Sprintf("%s is the object generated for %s", name, displayPath(path)), def.Instruction)
+	fmt.Fprintf(&decl, "type %s struct {\n", name)
+
+	fieldNames := make(map[string]bool)
+	for _, key := range orderedKeys(def) {
+		property := def.Properties[key]
+		fieldName := uniqueName(exportedName(key), fieldNames)
+		fieldType := g.goType(&property, name+fieldName, joinPath(path, key), key, false)
+
+		tag, err := jsonTag(key)
+		if err != nil && g.err == nil {
+			g.err = fmt.Errorf("property %s: %v", displayPath(joinPath(path, key)), err)
+		}
+		writeGoDoc(&decl, "\t", "", property.Instruction)
+		fmt.Fprintf(&decl, "\t%s %s %s\n", fieldName, fieldType, tag)
+	}
+	decl.WriteString("}\n")
+
+	g.decls[index] = decl.String()
+	return name
+}
+
+// jsonTag returns the struct tag naming the JSON key, or an error when encoding/json can't read the key from a tag
+func jsonTag(key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("an empty key can't be named in a json tag")
+	}
+	for _, r := range key {
+		// the characters encoding/json accepts in a tag name, anything else makes it fall back to the field name
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) {
+			return "", fmt.Errorf("key %q has %q, which can't be named in a json tag", key, r)
+		}
+	}
+	if key == "-" {
+		// a lone dash would skip the field
+		return "`json:\"-,\"`", nil
+	}
+	return "`json:" + strconv.Quote(key) + "`", nil
+}
+
+// declareEnum declares a string type with a constant for each known value
+func (g *goGenerator) declareEnum(name, key string, values []string) string {
+	name = uniqueName(name, g.taken)
+
+	var decl strings.Builder
+	fmt.Fprintf(&decl, "// %s lists the values of %s that DecisionPoint conditions check for\ntype %s string\n\nconst (\n", name, key, name)
+	for _, value := range values {
+		constant := uniqueName(name+exportedName(value), g.taken)
+		fmt.Fprintf(&decl, "\t%s %s = %q\n", constant, name, value)
+	}
+	decl.WriteString(")\n")
+
+	g.declare(decl.String())
+	return name
+}
+
+// writeGoDoc writes a doc comment made of the summary, if any, followed by the instruction
+func writeGoDoc(out *strings.Builder, indent, summary, instruction string) {
+	lines := commentLines(instruction)
+	if summary != "" {
+		lines = append([]string{summary}, lines...)
+	}
+	for _, line := range lines {
+		fmt.Fprintf(out, "%s// %s\n", indent, line)
+	}
+}
+
+func defaultTypeName(schema *Schema) string {
+	if schema.System != nil && schema.System.Name != "" {
+		return exportedName(schema.System.Name)
+	}
+	return "Object"
+}
+
+// goStringLiteral quotes s as a raw string when it can be, keeping embedded JSON readable
+func goStringLiteral(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "the root"
+	}
+	return path
+}
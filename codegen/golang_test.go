@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestGenerateGo(t *testing.T) {
+	def := routed(map[string]jsonSchema.Definition{
+		"level": {Type: jsonSchema.String, Instruction: "The audience level"},
+		"topic": {Type: jsonSchema.String},
+		"score": {Type: jsonSchema.Integer},
+	}, jsonSchema.Condition{Field: "level", Operator: jsonSchema.OpIn, Value: []string{"Beginner", "Expert"}},
+		jsonSchema.Condition{Field: "topic", Operator: jsonSchema.OpEqual, Value: "go"})
+	data, err := json.Marshal(def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := ParseSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := GenerateGo(schema, GoOptions{Package: "lesson", TypeName: "Lesson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		fragment string
+		want     bool
+	}{
+		{"package lesson", true},
+		{"type Lesson struct", true},
+		{"type LessonLevel string", true},
+		{`LessonLevelBeginner LessonLevel = "Beginner"`, true},
+		{"Level LessonLevel `json:\"level\"`", true},
+		{"Topic string `json:\"topic\"`", true},
+		{"Score int64 `json:\"score\"`", true},
+		{"// The audience level", true},
+		{"func GenerateLesson(", true},
+		{"LessonTopic", false},
+	}
+	// gofmt aligns struct fields, so compare with runs of spaces collapsed
+	code := strings.Join(strings.Fields(string(source)), " ")
+	for _, tt := range tests {
+		if got := strings.Contains(code, tt.fragment); got != tt.want {
+			t.Errorf("generated code contains %q = %v, want %v\n%s", tt.fragment, got, tt.want, source)
+		}
+	}
+
+	if _, err := GenerateGo(schema, GoOptions{}); err == nil {
+		t.Error("no error without a package name")
+	}
+}
+
+func TestGenerateGoCompiles(t *testing.T) {
+	keys := []string{"level", "Level", "first name", "user-id", "2fa", "世界", "é", "-", "a.b", "x:y", "type", "{braces}"}
+	properties := make(map[string]jsonSchema.Definition, len(keys))
+	for _, key := range keys {
+		properties[key] = jsonSchema.Definition{Type: jsonSchema.String, Instruction: "Quote `" + key + "` */ here"}
+	}
+	properties["nested"] = jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+		"items": {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.Integer}},
+	}}
+	data, err := json.Marshal(jsonSchema.Definition{Type: jsonSchema.Object, Instruction: "A `raw` schema", Properties: properties})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := ParseSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := GenerateGo(schema, GoOptions{Package: "lesson", TypeName: "Lesson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "lesson.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, source)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "gc", exportData)}
+	pkg, err := config.Check("lesson", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("generated code doesn't type check: %v\n%s", err, source)
+	}
+
+	// every key is read by encoding/json from an exported field
+	lesson := pkg.Scope().Lookup("Lesson").Type().Underlying().(*types.Struct)
+	tagged := make(map[string]bool)
+	for i := 0; i < lesson.NumFields(); i++ {
+		if !lesson.Field(i).Exported() {
+			t.Errorf("field %s is not exported", lesson.Field(i).Name())
+		}
+		tag := reflect.StructTag(lesson.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		tagged[name] = true
+	}
+	for _, key := range append(keys, "nested") {
+		if !tagged[key] {
+			t.Errorf("no field is tagged with %q", key)
+		}
+	}
+}
+
+func TestGenerateGoUnnamableKeys(t *testing.T) {
+	for _, key := range []string{"back`tick", "a,b", `quo"te`, `back\slash`, ""} {
+		data, err := json.Marshal(jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+			"outer": {Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{key: {Type: jsonSchema.String}}},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema, err := ParseSchema(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = GenerateGo(schema, GoOptions{Package: "lesson"})
+		if err == nil || !strings.HasPrefix(err.Error(), "property outer."+key+": ") {
+			t.Errorf("key %q: error = %v, want one naming the property", key, err)
+		}
+	}
+}
+
+// exportData looks up compiled export data through the go command, which has built the imports of the test already
+func exportData(path string) (io.ReadCloser, error) {
+	out, err := exec.Command("go", "list", "-export", "-f", "{{.Export}}", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list %s: %v", path, err)
+	}
+	return os.Open(strings.TrimSpace(string(out)))
+}
@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mermaidShapes are the brackets around the label of each kind of node
+var mermaidShapes = map[nodeShape][2]string{
+	shapeObject:   {"[", "]"},
+	shapeField:    {"(", ")"},
+	shapeDecision: {"{", "}"},
+	shapeLoop:     {"([", "])"},
+	shapeThread:   {"[[", "]]"},
+	shapeRule:     {"{{", "}}"},
+}
+
+// mermaidArrows are the arrows of each kind of edge
+var mermaidArrows = map[edgeStyle]string{
+	edgeContains: "-->",
+	edgeOrder:    "-.->",
+	edgeBranch:   "==>",
+	edgeMonitor:  "-.->",
+}
+
+// GenerateMermaid renders the schema as a Mermaid flowchart, to review decision trees in Markdown.
+// Objects are boxes, other fields rounded, decision points diamonds with their branches as thick edges labelled
+// with the conditions, processing order as dotted "before" edges, and loops and main thread rules as dotted edges.
+func GenerateMermaid(schema *Schema, options DiagramOptions) ([]byte, error) {
+	g := buildDiagram(schema, options)
+
+	var out strings.Builder
+	out.WriteString("flowchart TD\n")
+	for _, node := range g.nodes {
+		shape := mermaidShapes[node.shape]
+		fmt.Fprintf(&out, "    %s%s\"%s\"%s\n", node.id, shape[0], mermaidText(strings.Join(node.lines, "\n")), shape[1])
+	}
+	for _, edge := range g.edges {
+		arrow := mermaidArrows[edge.style]
+		if edge.label == "" {
+			fmt.Fprintf(&out, "    %s %s %s\n", edge.from, arrow, edge.to)
+			continue
+		}
+		fmt.Fprintf(&out, "    %s %s|\"%s\"| %s\n", edge.from, arrow, mermaidText(edge.label), edge.to)
+	}
+	return []byte(out.String()), nil
+}
+
+// mermaidText escapes text for a quoted Mermaid label, using entity codes for the characters Mermaid interprets
+func mermaidText(text string) string {
+	return strings.NewReplacer(
+		"&", "#38;",
+		`"`, "#34;",
+		"<", "#60;",
+		">", "#62;",
+		"#", "#35;",
+		"\n", "<br/>",
+	).Replace(text)
+}
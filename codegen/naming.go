@@ -0,0 +1,89 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// initialisms are written in upper case in exported names, following the Go naming conventions
+var initialisms = map[string]bool{
+	"API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true, "HTML": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "LLM": true, "SQL": true, "TTL": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// exportedName turns a JSON key such as "user_id" or "first-name" into an exported identifier such as UserID or FirstName
+func exportedName(key string) string {
+	var builder strings.Builder
+	for _, word := range splitWords(key) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			builder.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		builder.WriteRune(unicode.ToUpper(runes[0]))
+		builder.WriteString(string(runes[1:]))
+	}
+
+	name := builder.String()
+	if name == "" {
+		return "Field"
+	}
+	// digits and letters without a case, such as CJK, can't start an exported name
+	if !unicode.IsUpper([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// splitWords splits a key on separators and lower to upper case boundaries
+func splitWords(key string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(key)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && len(current) > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// uniqueName returns name, or name suffixed with a number when it is already taken, and marks it as taken
+func uniqueName(name string, taken map[string]bool) string {
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// commentLines splits an instruction into trimmed, non-empty lines for a doc comment
+func commentLines(instruction string) []string {
+	var lines []string
+	for _, line := range strings.Split(instruction, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,190 @@
+// Package codegen generates typed code for the objects described by a jsonSchema.Definition or ComplexSystem.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// Schema is the input of the generators
+type Schema struct {
+	Definition *jsonSchema.Definition    // the Definition the generated types describe, the RootSchema of a ComplexSystem
+	System     *jsonSchema.ComplexSystem // set when the source is a ComplexSystem
+	JSON       []byte                    // the source document
+}
+
+// ParseSchema reads a Definition or ComplexSystem JSON document, a ComplexSystem being recognised by its rootSchema
+func ParseSchema(data []byte) (*Schema, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("error decoding schema: %v", err)
+	}
+
+	schema := &Schema{JSON: data}
+	if _, ok := fields["rootSchema"]; ok {
+		var system jsonSchema.ComplexSystem
+		if err := json.Unmarshal(data, &system); err != nil {
+			return nil, fmt.Errorf("error decoding complex system: %v", err)
+		}
+		schema.System = &system
+		schema.Definition = &system.RootSchema
+		return schema, nil
+	}
+
+	var definition jsonSchema.Definition
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("error decoding definition: %v", err)
+	}
+	schema.Definition = &definition
+	return schema, nil
+}
+
+// orderedKeys lists the properties of a Definition, those in its ProcessingOrder first and the rest alphabetically
+func orderedKeys(def *jsonSchema.Definition) []string {
+	keys := make([]string, 0, len(def.Properties))
+	listed := make(map[string]bool, len(def.ProcessingOrder))
+	for _, key := range def.ProcessingOrder {
+		if _, ok := def.Properties[key]; ok && !listed[key] {
+			keys = append(keys, key)
+			listed[key] = true
+		}
+	}
+
+	rest := make([]string, 0, len(def.Properties))
+	for key := range def.Properties {
+		if !listed[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// enumValues collects the string values that DecisionPoint conditions compare fields against with OpIn, keyed on
+// the dotted path of the field the condition's FieldPath (or Field when no path is given) points to from the
+// Definition holding the decision point. Conditions on fields outside the generated object, such as those of
+// branch Then definitions, are skipped.
+func enumValues(def *jsonSchema.Definition) map[string][]string {
+	values := make(map[string][]string)
+	seen := make(map[string]bool)
+
+	add := func(key, value string) {
+		if seen[key+"\x00"+value] {
+			return
+		}
+		seen[key+"\x00"+value] = true
+		values[key] = append(values[key], value)
+	}
+
+	var visit func(def *jsonSchema.Definition, path string)
+	visitDecision := func(decision *jsonSchema.DecisionPoint, def *jsonSchema.Definition, path string) {
+		if decision == nil {
+			return
+		}
+		for _, branch := range decision.Branches {
+			for _, condition := range branch.Conditions {
+				listed := conditionStrings(condition)
+				if len(listed) == 0 {
+					continue
+				}
+				if target, ok := conditionTarget(def, path, condition); ok {
+					for _, value := range listed {
+						add(target, value)
+					}
+				}
+			}
+		}
+	}
+	visit = func(def *jsonSchema.Definition, path string) {
+		if def == nil {
+			return
+		}
+		for _, key := range orderedKeys(def) {
+			property := def.Properties[key]
+			visit(&property, joinPath(path, key))
+		}
+		visit(def.Items, path+"[]")
+		if def.HashMap != nil {
+			visit(def.HashMap.FieldDefinition, joinPath(path, "*"))
+		}
+		visitDecision(def.DecisionPoint, def, path)
+		if def.RecursiveLoop != nil {
+			visitDecision(def.RecursiveLoop.TerminationPoint, def, path)
+		}
+	}
+
+	visit(def, "")
+	return values
+}
+
+// conditionTarget follows the condition's FieldPath from the Definition holding the decision point, the way
+// DecisionPoint.Evaluate resolves it in the generated data, and returns the path of the string field it points to
+func conditionTarget(def *jsonSchema.Definition, path string, condition jsonSchema.Condition) (string, bool) {
+	segments := strings.Split(condition.FieldPath, ".")
+	if condition.FieldPath == "" {
+		if condition.Field == "" {
+			return "", false
+		}
+		segments = []string{condition.Field}
+	}
+
+	current := def
+	for _, segment := range segments {
+		// numeric segments index a list, other segments collect the field from every item
+		indexed := false
+		for current.Type == jsonSchema.Array && current.Items != nil && current.HashMap == nil && !indexed {
+			current, path = current.Items, path+"[]"
+			_, err := strconv.Atoi(segment)
+			indexed = err == nil
+		}
+		if indexed {
+			continue
+		}
+
+		if current.HashMap != nil {
+			if current.HashMap.FieldDefinition == nil {
+				return "", false
+			}
+			current, path = current.HashMap.FieldDefinition, joinPath(path, "*")
+			continue
+		}
+		property, ok := current.Properties[segment]
+		if !ok {
+			return "", false
+		}
+		current, path = &property, joinPath(path, segment)
+	}
+
+	// a list field is compared item by item
+	for current.Type == jsonSchema.Array && current.Items != nil && current.HashMap == nil {
+		current, path = current.Items, path+"[]"
+	}
+	return path, current.Type == jsonSchema.String && current.HashMap == nil
+}
+
+// conditionStrings returns the string values of an OpIn condition, OpEqual conditions only naming some of the values
+func conditionStrings(condition jsonSchema.Condition) []string {
+	if condition.Operator != jsonSchema.OpIn {
+		return nil
+	}
+	switch list := condition.Value.(type) {
+	case []string:
+		return list
+	case []any:
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			value, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			values = append(values, value)
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// routed is a Definition whose decision point checks the given conditions
+func routed(properties map[string]jsonSchema.Definition, conditions ...jsonSchema.Condition) *jsonSchema.Definition {
+	return &jsonSchema.Definition{
+		Type:       jsonSchema.Object,
+		Properties: properties,
+		DecisionPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{{
+			Conditions: conditions,
+			Then:       jsonSchema.Definition{Type: jsonSchema.String},
+		}}},
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	level := map[string]jsonSchema.Definition{
+		"level": {Type: jsonSchema.String},
+		"meta":  {Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{"level": {Type: jsonSchema.String}}},
+		"tags":  {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.String}},
+		"rows":  {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{"kind": {Type: jsonSchema.String}}}},
+		"count": {Type: jsonSchema.Integer},
+	}
+	in := func(field, path string, values any) jsonSchema.Condition {
+		return jsonSchema.Condition{Field: field, FieldPath: path, Operator: jsonSchema.OpIn, Value: values}
+	}
+
+	tests := []struct {
+		name string
+		def  *jsonSchema.Definition
+		want map[string][]string
+	}{
+		{"top level field", routed(level, in("level", "", []string{"low", "high"})), map[string][]string{"level": {"low", "high"}}},
+		{"field path", routed(level, in("level", "meta.level", []any{"a", "b"})), map[string][]string{"meta.level": {"a", "b"}}},
+		{"equal is ignored", routed(level, jsonSchema.Condition{Field: "level", Operator: jsonSchema.OpEqual, Value: "low"}), map[string][]string{}},
+		{"list field", routed(level, in("tags", "", []string{"go"})), map[string][]string{"tags[]": {"go"}}},
+		{"collected from items", routed(level, in("kind", "rows.kind", []string{"x"})), map[string][]string{"rows[].kind": {"x"}}},
+		{"indexed item", routed(level, in("kind", "rows.0.kind", []string{"y"})), map[string][]string{"rows[].kind": {"y"}}},
+		{"unknown path", routed(level, in("level", "missing.level", []string{"z"})), map[string][]string{}},
+		{"not a string field", routed(level, in("count", "", []string{"1"})), map[string][]string{}},
+		{"mixed value types", routed(level, in("level", "", []any{"a", 1})), map[string][]string{}},
+		{"no tree wide match", routed(map[string]jsonSchema.Definition{"meta": level["meta"]}, in("level", "", []string{"a"})), map[string][]string{}},
+		{"repeated values", routed(level, in("level", "", []string{"a"}), in("level", "level", []string{"a", "b"})), map[string][]string{"level": {"a", "b"}}},
+		{"nested decision", &jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+			"meta": *routed(map[string]jsonSchema.Definition{"level": {Type: jsonSchema.String}}, in("level", "", []string{"c"})),
+		}}, map[string][]string{"meta.level": {"c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := enumValues(tt.def); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("enumValues = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// tsIdentifier matches property names that can be written in an interface without quotes
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScriptOptions configures GenerateTypeScript
+type TypeScriptOptions struct {
+	TypeName string // name of the root type, defaults to the ComplexSystem name or Object
+}
+
+// GenerateTypeScript generates TypeScript declarations (.d.ts) for the object described by the schema.
+// Objects become interfaces with the instructions as doc comments, Map and HashMap become Record<string, T>,
+// Integer and Number become number, Byte a base64 string and Vector number[]. String fields compared against
+// a list of values by DecisionPoint conditions using OpIn become a union of string literals, left open to other
+// strings as the conditions only name the values they route on.
+func GenerateTypeScript(schema *Schema, options TypeScriptOptions) ([]byte, error) {
+	typeName := options.TypeName
+	if typeName == "" {
+		typeName = defaultTypeName(schema)
+	}
+
+	generator := &tsGenerator{enums: enumValues(schema.Definition), taken: map[string]bool{typeName: true}}
+	if rootType := generator.tsType(schema.Definition, typeName, "", "", true); rootType != typeName {
+		generator.declare(fmt.Sprintf("/** %s is the object generated by ObjectWeaver */\nexport type %s = %s;\n", typeName, typeName, rootType))
+	}
+
+	var out strings.Builder
+	out.WriteString("// Code generated by objectweaver-gen. DO NOT EDIT.\n")
+	for _, decl := range generator.decls {
+		out.WriteString("\n")
+		out.WriteString(decl)
+	}
+	return []byte(out.String()), nil
+}
+
+// tsGenerator collects the declarations of a single GenerateTypeScript call
+type tsGenerator struct {
+	enums map[string][]string
+	taken map[string]bool
+	decls []string
+}
+
+func (g *tsGenerator) declare(decl string) {
+	g.decls = append(g.decls, decl)
+}
+
+// tsType returns the TypeScript type of a Definition, declaring interfaces and unions as it goes
+func (g *tsGenerator) tsType(def *jsonSchema.Definition, name, path, key string, root bool) string {
+	if def == nil {
+		return "unknown"
+	}
+	if def.HashMap != nil || def.Type == jsonSchema.Map {
+		if def.HashMap == nil || def.HashMap.FieldDefinition == nil {
+			return "Record<string, unknown>"
+		}
+		return "Record<string, " + g.tsType(def.HashMap.FieldDefinition, name+"Value", joinPath(path, "*"), "", false) + ">"
+	}
+
+	switch def.Type {
+	case jsonSchema.Object:
+		if len(def.Properties) == 0 {
+			return "Record<string, unknown>"
+		}
+		return g.declareInterface(def, name, path, root)
+	case jsonSchema.Array:
+		if def.Items == nil {
+			return "unknown[]"
+		}
+		return arrayOf(g.tsType(def.Items, name+"Item", path+"[]", "", false))
+	case jsonSchema.String, jsonSchema.Byte:
+		if values := g.enums[path]; def.Type == jsonSchema.String && len(values) > 0 {
+			return g.declareUnion(name, key, values)
+		}
+		return "string"
+	case jsonSchema.Integer, jsonSchema.Number:
+		return "number"
+	case jsonSchema.Boolean:
+		return "boolean"
+	case jsonSchema.Vector:
+		return "number[]"
+	case jsonSchema.Null:
+		return "null"
+	}
+	return "unknown"
+}
+
+// declareInterface declares the interface of an object, reserving its place so parents are declared before their children
+func (g *tsGenerator) declareInterface(def *jsonSchema.Definition, name, path string, root bool) string {
+	if !root {
+		name = uniqueName(name, g.taken)
+	}
+	index := len(g.decls)
+	g.declare("")
+
+	var decl strings.Builder
+	writeTSDoc(&decl, "", def.Instruction)
+	fmt.Fprintf(&decl, "export interface %s {\n", name)
+
+	fieldNames := make(map[string]bool)
+	for _, key := range orderedKeys(def) {
+		property := def.Properties[key]
+		fieldType := g.tsType(&property, name+uniqueName(exportedName(key), fieldNames), joinPath(path, key), key, false)
+
+		instruction := property.Instruction
+		if property.Type == jsonSchema.Byte && property.HashMap == nil {
+			instruction = strings.TrimSpace(instruction + "\nBase64 encoded bytes.")
+		}
+		writeTSDoc(&decl, "  ", instruction)
+		fmt.Fprintf(&decl, "  %s: %s;\n", tsPropertyName(key), fieldType)
+	}
+	decl.WriteString("}\n")
+
+	g.decls[index] = decl.String()
+	return name
+}
+
+// declareUnion declares a union of the string values DecisionPoint conditions check for. The union ends with
+// (string & {}) so the model can still generate other values, while editors keep suggesting the listed ones.
+func (g *tsGenerator) declareUnion(name, key string, values []string) string {
+	name = uniqueName(name, g.taken)
+
+	literals := make([]string, 0, len(values)+1)
+	for _, value := range values {
+		literals = append(literals, strconv.Quote(value))
+	}
+	literals = append(literals, "(string & {})")
+	g.declare(fmt.Sprintf("/** %s lists the values of %s that DecisionPoint conditions check for */\nexport type %s = %s;\n", name, key, name, strings.Join(literals, " | ")))
+	return name
+}
+
+// writeTSDoc writes the instruction as a JSDoc comment
+func writeTSDoc(out *strings.Builder, indent, instruction string) {
+	lines := commentLines(strings.ReplaceAll(instruction, "*/", "*\\/"))
+	switch len(lines) {
+	case 0:
+		return
+	case 1:
+		fmt.Fprintf(out, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(out, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(out, "%s * %s\n", indent, line)
+	}
+	fmt.Fprintf(out, "%s */\n", indent)
+}
+
+// tsPropertyName quotes property names that are not valid identifiers
+func tsPropertyName(key string) string {
+	if tsIdentifier.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// arrayOf uses the Array<T> form for composite element types so the array applies to the whole type
+func arrayOf(element string) string {
+	if strings.ContainsAny(element, " |<") {
+		return "Array<" + element + ">"
+	}
+	return element + "[]"
+}
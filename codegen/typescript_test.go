@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	tests := []struct {
+		name      string
+		def       jsonSchema.Definition
+		fragments []string
+		missing   []string
+	}{
+		{
+			name: "field based routing",
+			def:  jsonSchema.ExampleFieldBasedRouting(),
+			fragments: []string{
+				"export interface Lesson {",
+				"audience_level: string;",
+			},
+			missing: []string{`"Beginner"`},
+		},
+		{
+			name: "open union",
+			def: *routed(map[string]jsonSchema.Definition{"level": {Type: jsonSchema.String}},
+				jsonSchema.Condition{Field: "level", Operator: jsonSchema.OpIn, Value: []string{"Beginner", "Expert"}}),
+			fragments: []string{
+				`export type LessonLevel = "Beginner" | "Expert" | (string & {});`,
+				"level: LessonLevel;",
+			},
+		},
+		{
+			name: "types",
+			def: jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+				"avatar":    {Type: jsonSchema.Byte},
+				"embedding": {Type: jsonSchema.Vector},
+				"counts":    {Type: jsonSchema.Map, HashMap: &jsonSchema.HashMap{FieldDefinition: &jsonSchema.Definition{Type: jsonSchema.Integer}}},
+				"tags":      {Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.String}},
+				"odd-name":  {Type: jsonSchema.Boolean},
+			}},
+			fragments: []string{
+				"avatar: string;",
+				"Base64 encoded bytes.",
+				"embedding: number[];",
+				"counts: Record<string, number>;",
+				"tags: string[];",
+				`"odd-name": boolean;`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema, err := ParseSchema(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			source, err := GenerateTypeScript(schema, TypeScriptOptions{TypeName: "Lesson"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fragment := range tt.fragments {
+				if !strings.Contains(string(source), fragment) {
+					t.Errorf("missing %q in\n%s", fragment, source)
+				}
+			}
+			for _, fragment := range tt.missing {
+				if strings.Contains(string(source), fragment) {
+					t.Errorf("unexpected %q in\n%s", fragment, source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,520 @@
+package converison
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ConvertProtoToComplexSystem converts a protobuf ComplexSystem to the Go model ComplexSystem
+func ConvertProtoToComplexSystem(protoSystem *pb.ComplexSystem) *jsonSchema.ComplexSystem {
+	if protoSystem == nil {
+		return nil
+	}
+
+	var rootSchema jsonSchema.Definition
+	if protoSystem.RootSchema != nil {
+		rootSchema = *ConvertProtoToModel(protoSystem.RootSchema)
+	}
+
+	return &jsonSchema.ComplexSystem{
+		Name:                protoSystem.Name,
+		Description:         protoSystem.Description,
+		PrimarySystemPrompt: protoSystem.PrimarySystemPrompt,
+		RootSchema:          rootSchema,
+		MainThread:          convertProtoMainThreadConfig(protoSystem.MainThread),
+		Memory:              convertProtoMemoryConfig(protoSystem.Memory),
+		LazyConfig:          convertProtoLazyConfigMode(protoSystem.LazyConfig),
+		Version:             protoSystem.Version,
+	}
+}
+
+// ConvertModelToProtoComplexSystem converts a Go model ComplexSystem to a protobuf ComplexSystem
+func ConvertModelToProtoComplexSystem(modelSystem *jsonSchema.ComplexSystem) *pb.ComplexSystem {
+	if modelSystem == nil {
+		return nil
+	}
+
+	return &pb.ComplexSystem{
+		Name:                modelSystem.Name,
+		Description:         modelSystem.Description,
+		PrimarySystemPrompt: modelSystem.PrimarySystemPrompt,
+		RootSchema:          ConvertModelToProto(&modelSystem.RootSchema),
+		MainThread:          convertModelMainThreadConfig(modelSystem.MainThread),
+		Memory:              convertModelMemoryConfig(modelSystem.Memory),
+		LazyConfig:          convertModelLazyConfigMode(modelSystem.LazyConfig),
+		Version:             modelSystem.Version,
+	}
+}
+
+// ConvertModelToProtoComplexSystemChecked converts the ComplexSystem like ConvertModelToProtoComplexSystem, but returns
+// an error for the values ConvertModelToProtoChecked rejects anywhere in the system
+func ConvertModelToProtoComplexSystemChecked(modelSystem *jsonSchema.ComplexSystem) (*pb.ComplexSystem, error) {
+	if modelSystem == nil {
+		return nil, nil
+	}
+	if err := checkSystemConvertible("$", modelSystem); err != nil {
+		return nil, err
+	}
+	return ConvertModelToProtoComplexSystem(modelSystem), nil
+}
+
+// checkSystemConvertible checks the Definitions of the system, its memory triggers and its pattern library
+func checkSystemConvertible(path string, system *jsonSchema.ComplexSystem) error {
+	if err := checkConvertible(path+".rootSchema", &system.RootSchema); err != nil {
+		return err
+	}
+	threadPath := path + ".mainThread"
+	for thread := system.MainThread; thread != nil; thread = thread.ParentThread {
+		for i, rule := range thread.InterventionRules {
+			rulePath := fmt.Sprintf("%s.interventionRules[%d].action.modifyDefinition", threadPath, i)
+			if err := checkConvertible(rulePath, rule.Action.ModifyDefinition); err != nil {
+				return err
+			}
+		}
+		threadPath += ".parentThread"
+	}
+	if system.Memory != nil {
+		for i, trigger := range system.Memory.RetrievalTriggers {
+			if _, err := convertModelConditionChecked(trigger.ScoreThreshold); err != nil {
+				return fmt.Errorf("%s.memory.retrievalTriggers[%d].scoreThreshold: %v", path, i, err)
+			}
+		}
+	}
+	if system.LazyConfig == nil || system.LazyConfig.PatternLibrary == nil {
+		return nil
+	}
+	for i, pattern := range system.LazyConfig.PatternLibrary.CustomPatterns {
+		patternPath := fmt.Sprintf("%s.lazyConfig.patternLibrary.customPatterns[%d].schema", path, i)
+		var err error
+		switch schema := pattern.Schema.(type) {
+		case jsonSchema.Definition:
+			err = checkConvertible(patternPath, &schema)
+		case *jsonSchema.Definition:
+			err = checkConvertible(patternPath, schema)
+		case jsonSchema.ComplexSystem:
+			err = checkSystemConvertible(patternPath, &schema)
+		case *jsonSchema.ComplexSystem:
+			if schema != nil {
+				err = checkSystemConvertible(patternPath, schema)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper functions for MainThreadConfig and intervention rules
+
+func convertProtoMainThreadConfig(mt *pb.MainThreadConfig) *jsonSchema.MainThreadConfig {
+	if mt == nil {
+		return nil
+	}
+
+	var rules []jsonSchema.InterventionRule
+	if len(mt.InterventionRules) > 0 {
+		rules = make([]jsonSchema.InterventionRule, len(mt.InterventionRules))
+		for i, rule := range mt.InterventionRules {
+			rules[i] = jsonSchema.InterventionRule{
+				Name:     rule.GetName(),
+				Trigger:  convertProtoInterventionTrigger(rule.GetTrigger()),
+				Action:   convertProtoInterventionAction(rule.GetAction()),
+				Priority: int(rule.GetPriority()),
+			}
+		}
+	}
+
+	return &jsonSchema.MainThreadConfig{
+		MonitoringStrategy: jsonSchema.MonitoringStrategy(mt.MonitoringStrategy),
+		InterventionRules:  rules,
+		HierarchicalDepth:  int(mt.HierarchicalDepth),
+		ParentThread:       convertProtoMainThreadConfig(mt.ParentThread),
+		AggregationPrompt:  mt.AggregationPrompt,
+		Model:              mt.Model,
+	}
+}
+
+func convertProtoInterventionTrigger(trigger *pb.InterventionTrigger) jsonSchema.InterventionTrigger {
+	if trigger == nil {
+		return jsonSchema.InterventionTrigger{}
+	}
+
+	var pathDepth *int
+	if trigger.PathDepth != nil {
+		depth := int(*trigger.PathDepth)
+		pathDepth = &depth
+	}
+
+	var thresholds map[string]float64
+	if len(trigger.ScoreThresholds) > 0 {
+		thresholds = trigger.ScoreThresholds
+	}
+
+	return jsonSchema.InterventionTrigger{
+		Type:            jsonSchema.TriggerType(trigger.Type),
+		ScoreThresholds: thresholds,
+		PathDepth:       pathDepth,
+		CustomCondition: trigger.CustomCondition,
+	}
+}
+
+func convertProtoInterventionAction(action *pb.InterventionAction) jsonSchema.InterventionAction {
+	if action == nil {
+		return jsonSchema.InterventionAction{}
+	}
+
+	return jsonSchema.InterventionAction{
+		Type:              jsonSchema.ActionType(action.Type),
+		OverridePrompt:    copyStringPointer(action.OverridePrompt),
+		FetchMemory:       action.FetchMemory,
+		ResetToCheckpoint: copyStringPointer(action.ResetToCheckpoint),
+		CustomHandler:     action.CustomHandler,
+		ModifyDefinition:  ConvertProtoToModel(action.ModifyDefinition),
+	}
+}
+
+func convertModelMainThreadConfig(mt *jsonSchema.MainThreadConfig) *pb.MainThreadConfig {
+	if mt == nil {
+		return nil
+	}
+
+	rules := make([]*pb.InterventionRule, len(mt.InterventionRules))
+	for i, rule := range mt.InterventionRules {
+		rules[i] = &pb.InterventionRule{
+			Name:     rule.Name,
+			Trigger:  convertModelInterventionTrigger(&rule.Trigger),
+			Action:   convertModelInterventionAction(&rule.Action),
+			Priority: int32(rule.Priority),
+		}
+	}
+
+	return &pb.MainThreadConfig{
+		MonitoringStrategy: string(mt.MonitoringStrategy),
+		InterventionRules:  rules,
+		HierarchicalDepth:  int32(mt.HierarchicalDepth),
+		ParentThread:       convertModelMainThreadConfig(mt.ParentThread),
+		AggregationPrompt:  mt.AggregationPrompt,
+		Model:              mt.Model,
+	}
+}
+
+func convertModelInterventionTrigger(trigger *jsonSchema.InterventionTrigger) *pb.InterventionTrigger {
+	var pathDepth *int32
+	if trigger.PathDepth != nil {
+		depth := int32(*trigger.PathDepth)
+		pathDepth = &depth
+	}
+
+	return &pb.InterventionTrigger{
+		Type:            string(trigger.Type),
+		ScoreThresholds: trigger.ScoreThresholds,
+		PathDepth:       pathDepth,
+		CustomCondition: trigger.CustomCondition,
+	}
+}
+
+func convertModelInterventionAction(action *jsonSchema.InterventionAction) *pb.InterventionAction {
+	return &pb.InterventionAction{
+		Type:              string(action.Type),
+		OverridePrompt:    copyStringPointer(action.OverridePrompt),
+		FetchMemory:       action.FetchMemory,
+		ResetToCheckpoint: copyStringPointer(action.ResetToCheckpoint),
+		CustomHandler:     action.CustomHandler,
+		ModifyDefinition:  ConvertModelToProto(action.ModifyDefinition),
+	}
+}
+
+// Helper functions for MemoryConfig
+
+func convertProtoMemoryConfig(mc *pb.MemoryConfig) *jsonSchema.MemoryConfig {
+	if mc == nil {
+		return nil
+	}
+
+	var triggers []jsonSchema.MemoryTrigger
+	if len(mc.RetrievalTriggers) > 0 {
+		triggers = make([]jsonSchema.MemoryTrigger, len(mc.RetrievalTriggers))
+		for i, trigger := range mc.RetrievalTriggers {
+			triggers[i] = jsonSchema.MemoryTrigger{
+				DecisionPoint:  trigger.GetDecisionPoint(),
+				ScoreThreshold: convertProtoCondition(trigger.GetScoreThreshold()),
+				AlwaysFetch:    trigger.GetAlwaysFetch(),
+				QueryTemplate:  trigger.GetQueryTemplate(),
+			}
+		}
+	}
+
+	return &jsonSchema.MemoryConfig{
+		Enabled:           mc.Enabled,
+		SearchStrategy:    jsonSchema.SearchStrategy(mc.SearchStrategy),
+		MaxContextSize:    int(mc.MaxContextSize),
+		RetrievalTriggers: triggers,
+		KnowledgeGraph:    convertProtoKnowledgeGraphConfig(mc.KnowledgeGraph),
+		StorageBackend:    mc.StorageBackend,
+	}
+}
+
+func convertProtoKnowledgeGraphConfig(kg *pb.KnowledgeGraphConfig) *jsonSchema.KnowledgeGraphConfig {
+	if kg == nil {
+		return nil
+	}
+
+	return &jsonSchema.KnowledgeGraphConfig{
+		Enabled:           kg.Enabled,
+		NodeTypes:         kg.NodeTypes,
+		RelationshipTypes: kg.RelationshipTypes,
+		TraversalDepth:    int(kg.TraversalDepth),
+	}
+}
+
+func convertModelMemoryConfig(mc *jsonSchema.MemoryConfig) *pb.MemoryConfig {
+	if mc == nil {
+		return nil
+	}
+
+	triggers := make([]*pb.MemoryTrigger, len(mc.RetrievalTriggers))
+	for i, trigger := range mc.RetrievalTriggers {
+		triggers[i] = &pb.MemoryTrigger{
+			DecisionPoint:  trigger.DecisionPoint,
+			ScoreThreshold: convertModelCondition(trigger.ScoreThreshold),
+			AlwaysFetch:    trigger.AlwaysFetch,
+			QueryTemplate:  trigger.QueryTemplate,
+		}
+	}
+
+	return &pb.MemoryConfig{
+		Enabled:           mc.Enabled,
+		SearchStrategy:    string(mc.SearchStrategy),
+		MaxContextSize:    int32(mc.MaxContextSize),
+		RetrievalTriggers: triggers,
+		KnowledgeGraph:    convertModelKnowledgeGraphConfig(mc.KnowledgeGraph),
+		StorageBackend:    mc.StorageBackend,
+	}
+}
+
+func convertModelKnowledgeGraphConfig(kg *jsonSchema.KnowledgeGraphConfig) *pb.KnowledgeGraphConfig {
+	if kg == nil {
+		return nil
+	}
+
+	return &pb.KnowledgeGraphConfig{
+		Enabled:           kg.Enabled,
+		NodeTypes:         kg.NodeTypes,
+		RelationshipTypes: kg.RelationshipTypes,
+		TraversalDepth:    int32(kg.TraversalDepth),
+	}
+}
+
+// Helper functions for LazyConfigMode
+
+func convertProtoLazyConfigMode(lc *pb.LazyConfigMode) *jsonSchema.LazyConfigMode {
+	if lc == nil {
+		return nil
+	}
+
+	return &jsonSchema.LazyConfigMode{
+		Enabled:         lc.Enabled,
+		PatternLibrary:  convertProtoPatternLibraryConfig(lc.PatternLibrary),
+		CreationPrompt:  lc.CreationPrompt,
+		SelfImprovement: convertProtoSelfImprovementConfig(lc.SelfImprovement),
+		DeploymentMode:  jsonSchema.DeploymentMode(lc.DeploymentMode),
+		MaxComplexity:   int(lc.MaxComplexity),
+	}
+}
+
+func convertProtoPatternLibraryConfig(pl *pb.PatternLibraryConfig) *jsonSchema.PatternLibraryConfig {
+	if pl == nil {
+		return nil
+	}
+
+	var patterns []jsonSchema.DecisionPattern
+	if len(pl.CustomPatterns) > 0 {
+		patterns = make([]jsonSchema.DecisionPattern, len(pl.CustomPatterns))
+		for i, pattern := range pl.CustomPatterns {
+			patterns[i] = convertProtoDecisionPattern(pattern)
+		}
+	}
+
+	return &jsonSchema.PatternLibraryConfig{
+		SourceURL:      pl.SourceUrl,
+		SelectionModel: pl.SelectionModel,
+		Tags:           pl.Tags,
+		CustomPatterns: patterns,
+	}
+}
+
+func convertProtoDecisionPattern(dp *pb.DecisionPattern) jsonSchema.DecisionPattern {
+	var schema interface{}
+	switch s := dp.GetSchema().(type) {
+	case *pb.DecisionPattern_DefinitionSchema:
+		schema = *ConvertProtoToModel(s.DefinitionSchema)
+	case *pb.DecisionPattern_SystemSchema:
+		schema = *ConvertProtoToComplexSystem(s.SystemSchema)
+	case *pb.DecisionPattern_RawSchema:
+		schema = s.RawSchema.AsInterface()
+	}
+
+	var metrics map[string]float64
+	if len(dp.GetPerformanceMetrics()) > 0 {
+		metrics = dp.GetPerformanceMetrics()
+	}
+
+	return jsonSchema.DecisionPattern{
+		ID:                 dp.GetId(),
+		Name:               dp.GetName(),
+		Description:        dp.GetDescription(),
+		Tags:               dp.GetTags(),
+		Schema:             schema,
+		UseCases:           dp.GetUseCases(),
+		PerformanceMetrics: metrics,
+		CreatedAt:          dp.GetCreatedAt(),
+		Version:            dp.GetVersion(),
+	}
+}
+
+func convertProtoSelfImprovementConfig(si *pb.SelfImprovementConfig) *jsonSchema.SelfImprovementConfig {
+	if si == nil {
+		return nil
+	}
+
+	var safety *jsonSchema.SafetyConfig
+	if si.SafetyMechanisms != nil {
+		var baseline map[string]float64
+		if len(si.SafetyMechanisms.PerformanceBaseline) > 0 {
+			baseline = si.SafetyMechanisms.PerformanceBaseline
+		}
+		safety = &jsonSchema.SafetyConfig{
+			RequireApproval:     si.SafetyMechanisms.RequireApproval,
+			RollbackOnFailure:   si.SafetyMechanisms.RollbackOnFailure,
+			PerformanceBaseline: baseline,
+			MaxComplexity:       int(si.SafetyMechanisms.MaxComplexity),
+			ValidationSet:       si.SafetyMechanisms.ValidationSet,
+		}
+	}
+
+	return &jsonSchema.SelfImprovementConfig{
+		Enabled:                si.Enabled,
+		SaveSuccessfulPatterns: si.SaveSuccessfulPatterns,
+		SuccessThreshold:       si.SuccessThreshold,
+		RefinementStrategy:     jsonSchema.RefinementStrategy(si.RefinementStrategy),
+		SafetyMechanisms:       safety,
+		LearningRate:           si.LearningRate,
+	}
+}
+
+func convertModelLazyConfigMode(lc *jsonSchema.LazyConfigMode) *pb.LazyConfigMode {
+	if lc == nil {
+		return nil
+	}
+
+	return &pb.LazyConfigMode{
+		Enabled:         lc.Enabled,
+		PatternLibrary:  convertModelPatternLibraryConfig(lc.PatternLibrary),
+		CreationPrompt:  lc.CreationPrompt,
+		SelfImprovement: convertModelSelfImprovementConfig(lc.SelfImprovement),
+		DeploymentMode:  string(lc.DeploymentMode),
+		MaxComplexity:   int32(lc.MaxComplexity),
+	}
+}
+
+func convertModelPatternLibraryConfig(pl *jsonSchema.PatternLibraryConfig) *pb.PatternLibraryConfig {
+	if pl == nil {
+		return nil
+	}
+
+	patterns := make([]*pb.DecisionPattern, len(pl.CustomPatterns))
+	for i, pattern := range pl.CustomPatterns {
+		patterns[i] = convertModelDecisionPattern(&pattern)
+	}
+
+	return &pb.PatternLibraryConfig{
+		SourceUrl:      pl.SourceURL,
+		SelectionModel: pl.SelectionModel,
+		Tags:           pl.Tags,
+		CustomPatterns: patterns,
+	}
+}
+
+func convertModelDecisionPattern(dp *jsonSchema.DecisionPattern) *pb.DecisionPattern {
+	pattern := &pb.DecisionPattern{
+		Id:                 dp.ID,
+		Name:               dp.Name,
+		Description:        dp.Description,
+		Tags:               dp.Tags,
+		UseCases:           dp.UseCases,
+		PerformanceMetrics: dp.PerformanceMetrics,
+		CreatedAt:          dp.CreatedAt,
+		Version:            dp.Version,
+	}
+
+	// Schema holds either a Definition or a ComplexSystem, anything else is sent as a raw JSON value
+	switch schema := dp.Schema.(type) {
+	case nil:
+	case jsonSchema.Definition:
+		pattern.Schema = &pb.DecisionPattern_DefinitionSchema{DefinitionSchema: ConvertModelToProto(&schema)}
+	case *jsonSchema.Definition:
+		pattern.Schema = &pb.DecisionPattern_DefinitionSchema{DefinitionSchema: ConvertModelToProto(schema)}
+	case jsonSchema.ComplexSystem:
+		pattern.Schema = &pb.DecisionPattern_SystemSchema{SystemSchema: ConvertModelToProtoComplexSystem(&schema)}
+	case *jsonSchema.ComplexSystem:
+		pattern.Schema = &pb.DecisionPattern_SystemSchema{SystemSchema: ConvertModelToProtoComplexSystem(schema)}
+	default:
+		if raw := convertToStructpbValue(schema); raw != nil {
+			pattern.Schema = &pb.DecisionPattern_RawSchema{RawSchema: raw}
+		}
+	}
+
+	return pattern
+}
+
+func convertModelSelfImprovementConfig(si *jsonSchema.SelfImprovementConfig) *pb.SelfImprovementConfig {
+	if si == nil {
+		return nil
+	}
+
+	var safety *pb.SafetyConfig
+	if si.SafetyMechanisms != nil {
+		safety = &pb.SafetyConfig{
+			RequireApproval:     si.SafetyMechanisms.RequireApproval,
+			RollbackOnFailure:   si.SafetyMechanisms.RollbackOnFailure,
+			PerformanceBaseline: si.SafetyMechanisms.PerformanceBaseline,
+			MaxComplexity:       int32(si.SafetyMechanisms.MaxComplexity),
+			ValidationSet:       si.SafetyMechanisms.ValidationSet,
+		}
+	}
+
+	return &pb.SelfImprovementConfig{
+		Enabled:                si.Enabled,
+		SaveSuccessfulPatterns: si.SaveSuccessfulPatterns,
+		SuccessThreshold:       si.SuccessThreshold,
+		RefinementStrategy:     string(si.RefinementStrategy),
+		SafetyMechanisms:       safety,
+		LearningRate:           si.LearningRate,
+	}
+}
+
+// convertToStructpbValue converts any JSON serialisable value, returning nil when it cannot be represented
+func convertToStructpbValue(value interface{}) *structpb.Value {
+	if converted, err := structpb.NewValue(value); err == nil {
+		return converted
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var normalised interface{}
+	if err := json.Unmarshal(data, &normalised); err != nil {
+		return nil
+	}
+	converted, err := structpb.NewValue(normalised)
+	if err != nil {
+		return nil
+	}
+	return converted
+}
@@ -0,0 +1,140 @@
+package converison
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestDecisionPatternSchemaRoundTrip(t *testing.T) {
+	definition := jsonSchema.Definition{Type: jsonSchema.String, Instruction: "summary"}
+	system := jsonSchema.ComplexSystem{Name: "nested", RootSchema: definition}
+	tests := []struct {
+		name   string
+		schema any
+		want   any
+	}{
+		{"definition", definition, definition},
+		{"definition pointer", &definition, definition},
+		{"complex system", system, system},
+		{"complex system pointer", &system, system},
+		{"raw json", map[string]any{"type": "string", "minLength": 2.0}, map[string]any{"type": "string", "minLength": 2.0}},
+		{"raw struct", struct {
+			Type string `json:"type"`
+		}{"string"}, map[string]any{"type": "string"}},
+		{"no schema", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := jsonSchema.DecisionPattern{Name: "pattern", Schema: tt.schema}
+			got := convertProtoDecisionPattern(convertModelDecisionPattern(&pattern))
+			if !reflect.DeepEqual(got.Schema, tt.want) {
+				t.Errorf("schema = %#v, want %#v", got.Schema, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplexSystemConfigsRoundTrip(t *testing.T) {
+	system := jsonSchema.ExampleComplexSystem()
+	proto := ConvertModelToProtoComplexSystem(&system)
+
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"main thread", convertProtoMainThreadConfig(proto.MainThread), system.MainThread},
+		{"memory", convertProtoMemoryConfig(proto.Memory), system.Memory},
+		{"lazy config", convertProtoLazyConfigMode(proto.LazyConfig), system.LazyConfig},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %#v, want %#v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestComplexSystemNil(t *testing.T) {
+	if got := ConvertModelToProtoComplexSystem(nil); got != nil {
+		t.Errorf("ConvertModelToProtoComplexSystem(nil) = %v, want nil", got)
+	}
+	if got := ConvertProtoToComplexSystem(nil); got != nil {
+		t.Errorf("ConvertProtoToComplexSystem(nil) = %v, want nil", got)
+	}
+	got := ConvertProtoToComplexSystem(&pb.ComplexSystem{Name: "empty"})
+	if want := (&jsonSchema.ComplexSystem{Name: "empty"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("empty system = %#v, want %#v", got, want)
+	}
+}
+func TestComplexSystemRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		system jsonSchema.ComplexSystem
+	}{
+		{"complex system", jsonSchema.ExampleComplexSystem()},
+		{"lazy config", jsonSchema.ExampleWithLazyConfig()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proto, err := ConvertModelToProtoComplexSystemChecked(&tt.system)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ConvertProtoToComplexSystem(proto); !reflect.DeepEqual(*got, tt.system) {
+				t.Errorf("round trip changed the system\ngot  %#v\nwant %#v", *got, tt.system)
+			}
+		})
+	}
+}
+
+func TestConvertModelToProtoComplexSystemCheckedUnsupported(t *testing.T) {
+	unsupported := jsonSchema.Definition{
+		Type: jsonSchema.String,
+		DecisionPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{{
+			Conditions: []jsonSchema.Condition{{Field: "level", Operator: jsonSchema.OpEqual, Value: uint(3)}},
+		}}},
+	}
+	tests := []struct {
+		name   string
+		system jsonSchema.ComplexSystem
+		path   string
+	}{
+		{
+			name:   "root schema",
+			system: jsonSchema.ComplexSystem{RootSchema: unsupported},
+			path:   "$.rootSchema.decisionPoint.branches[0].conditions[0]",
+		},
+		{
+			name: "parent thread",
+			system: jsonSchema.ComplexSystem{MainThread: &jsonSchema.MainThreadConfig{ParentThread: &jsonSchema.MainThreadConfig{
+				InterventionRules: []jsonSchema.InterventionRule{{Action: jsonSchema.InterventionAction{ModifyDefinition: &unsupported}}},
+			}}},
+			path: "$.mainThread.parentThread.interventionRules[0].action.modifyDefinition.decisionPoint.branches[0].conditions[0]",
+		},
+		{
+			name: "memory trigger",
+			system: jsonSchema.ComplexSystem{Memory: &jsonSchema.MemoryConfig{RetrievalTriggers: []jsonSchema.MemoryTrigger{
+				{ScoreThreshold: &jsonSchema.Condition{Field: "quality", Operator: jsonSchema.OpLessThan, Value: uint(3)}},
+			}}},
+			path: "$.memory.retrievalTriggers[0].scoreThreshold",
+		},
+		{
+			name: "custom pattern",
+			system: jsonSchema.ComplexSystem{LazyConfig: &jsonSchema.LazyConfigMode{PatternLibrary: &jsonSchema.PatternLibraryConfig{
+				CustomPatterns: []jsonSchema.DecisionPattern{{Schema: jsonSchema.ComplexSystem{RootSchema: unsupported}}},
+			}}},
+			path: "$.lazyConfig.patternLibrary.customPatterns[0].schema.rootSchema.decisionPoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertModelToProtoComplexSystemChecked(&tt.system)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.path) {
+				t.Errorf("error = %v, want one at %s", err, tt.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package converison
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// conditionElementTypes are the Go types that are restored by name when converting back from proto,
+// for the elements of lists and for numeric values that are not int or float64
+var conditionElementTypes = map[string]reflect.Type{
+	"string":  reflect.TypeOf(""),
+	"bool":    reflect.TypeOf(false),
+	"int":     reflect.TypeOf(0),
+	"int32":   reflect.TypeOf(int32(0)),
+	"int64":   reflect.TypeOf(int64(0)),
+	"float32": reflect.TypeOf(float32(0)),
+	"float64": reflect.TypeOf(float64(0)),
+}
+
+// ConvertModelToProtoChecked converts the Definition like ConvertModelToProto, but returns an error when a condition
+// value or a free-form map (extra body, chat template kwargs, request body) can't be represented in proto,
+// instead of sending the value as a string or dropping the map
+func ConvertModelToProtoChecked(modelDef *jsonSchema.Definition) (*pb.Definition, error) {
+	if modelDef == nil {
+		return nil, nil
+	}
+	if err := checkConvertible("$", modelDef); err != nil {
+		return nil, err
+	}
+	return ConvertModelToProto(modelDef), nil
+}
+
+// checkConvertible returns the first condition or free-form map of the Definition tree that can't be converted
+func checkConvertible(path string, def *jsonSchema.Definition) error {
+	if def == nil {
+		return nil
+	}
+	if def.SpeechToText != nil {
+		if err := checkMap(path+".speechToText.extraBody", def.SpeechToText.ExtraBody); err != nil {
+			return err
+		}
+	}
+	if def.ModelConfig != nil {
+		if err := checkMap(path+".modelConfig.chat_template_kwargs", def.ModelConfig.ChatTemplateKwargs); err != nil {
+			return err
+		}
+	}
+	if def.Req != nil {
+		if err := checkMap(path+".req.body", def.Req.Body); err != nil {
+			return err
+		}
+	}
+	if err := checkDecisionConditions(path+".decisionPoint", def.DecisionPoint); err != nil {
+		return err
+	}
+	if def.RecursiveLoop != nil {
+		if err := checkDecisionConditions(path+".recursiveLoop.terminationPoint", def.RecursiveLoop.TerminationPoint); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(def.Properties))
+	for key := range def.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		property := def.Properties[key]
+		if err := checkConvertible(path+"."+key, &property); err != nil {
+			return err
+		}
+	}
+	if err := checkConvertible(path+"[]", def.Items); err != nil {
+		return err
+	}
+	if def.HashMap != nil {
+		return checkConvertible(path+".*", def.HashMap.FieldDefinition)
+	}
+	return nil
+}
+
+// checkMap returns an error when the map can't be converted to a protobuf Struct
+func checkMap(path string, m map[string]interface{}) error {
+	if _, err := convertOptionalMapToStruct(m); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// checkDecisionConditions checks the conditions of every branch and then the Definitions the branches lead to
+func checkDecisionConditions(path string, decision *jsonSchema.DecisionPoint) error {
+	if decision == nil {
+		return nil
+	}
+	for i, branch := range decision.Branches {
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+		for j, condition := range branch.Conditions {
+			if _, err := convertModelConditionChecked(&condition); err != nil {
+				return fmt.Errorf("%s.conditions[%d]: %v", branchPath, j, err)
+			}
+		}
+		if err := checkConvertible(branchPath+".logic", branch.Logic); err != nil {
+			return err
+		}
+		if err := checkConvertible(branchPath+".then", &branch.Then); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertModelConditionValueList converts a slice used with the in and nin operators, returning nil for non slices
+func convertModelConditionValueList(value interface{}) (*pb.ConditionValueList, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, nil
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		// byte slices are compared as strings
+		return nil, nil
+	}
+
+	list := &pb.ConditionValueList{}
+	if elem := rv.Type().Elem(); elem.Kind() != reflect.Interface {
+		if _, ok := conditionElementTypes[elem.String()]; ok {
+			list.ElementType = elem.String()
+		}
+	}
+	for i := 0; i < rv.Len(); i++ {
+		element, err := convertModelConditionValue(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("list element %d: %v", i, err)
+		}
+		list.Values = append(list.Values, element)
+	}
+	return list, nil
+}
+
+// convertModelConditionValue converts a scalar, keeping the Go type of numbers that are not int or float64
+func convertModelConditionValue(value interface{}) (*pb.ConditionValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return &pb.ConditionValue{}, nil
+	case float64:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_NumberValue{NumberValue: v}}, nil
+	case float32:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_NumberValue{NumberValue: float64(v)}, ValueType: "float32"}, nil
+	case int:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_IntValue{IntValue: int64(v)}}, nil
+	case int32:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_IntValue{IntValue: int64(v)}, ValueType: "int32"}, nil
+	case int64:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_IntValue{IntValue: v}, ValueType: "int64"}, nil
+	case string:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_StringValue{StringValue: v}}, nil
+	case bool:
+		return &pb.ConditionValue{Value: &pb.ConditionValue_BoolValue{BoolValue: v}}, nil
+	}
+	return nil, fmt.Errorf("unsupported condition value type %T", value)
+}
+
+// convertProtoConditionValueList restores the slice, typed when the original element type is known
+func convertProtoConditionValueList(list *pb.ConditionValueList) interface{} {
+	values := make([]interface{}, len(list.GetValues()))
+	for i, value := range list.GetValues() {
+		values[i] = convertProtoConditionValue(value)
+	}
+
+	elemType, ok := conditionElementTypes[list.GetElementType()]
+	if !ok {
+		return values
+	}
+	typed := reflect.MakeSlice(reflect.SliceOf(elemType), len(values), len(values))
+	for i, value := range values {
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || !rv.CanConvert(elemType) {
+			return values
+		}
+		typed.Index(i).Set(rv.Convert(elemType))
+	}
+	return typed.Interface()
+}
+
+func convertProtoConditionValue(value *pb.ConditionValue) interface{} {
+	switch v := value.GetValue().(type) {
+	case *pb.ConditionValue_NumberValue:
+		return restoreValueType(v.NumberValue, value.GetValueType())
+	case *pb.ConditionValue_StringValue:
+		return v.StringValue
+	case *pb.ConditionValue_BoolValue:
+		return v.BoolValue
+	case *pb.ConditionValue_IntValue:
+		if value.GetValueType() == "" {
+			return int(v.IntValue)
+		}
+		return restoreValueType(v.IntValue, value.GetValueType())
+	}
+	return nil
+}
+
+// restoreValueType converts a number back to the Go type it had before conversion, when one was recorded
+func restoreValueType(value interface{}, valueType string) interface{} {
+	typ, ok := conditionElementTypes[valueType]
+	if !ok {
+		return value
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.CanConvert(typ) {
+		return value
+	}
+	return rv.Convert(typ).Interface()
+}
@@ -5,7 +5,6 @@ import (
 
 	pb "github.com/objectweaver/go-sdk/grpc"
 	"github.com/objectweaver/go-sdk/jsonSchema"
-	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // ConvertProtoToModel converts a protobuf Definition to your Go model Definition
@@ -17,11 +16,10 @@ func ConvertProtoToModel(protoDef *pb.Definition) *jsonSchema.Definition {
 	modelDef := &jsonSchema.Definition{
 		Type:            jsonSchema.DataType(protoDef.Type),
 		Instruction:     protoDef.Instruction,
-		Properties:      make(map[string]jsonSchema.Definition),
 		Items:           ConvertProtoToModel(protoDef.GetItems()), // Use Getters to handle nil cases
 		Model:           protoDef.Model,
 		ProcessingOrder: protoDef.ProcessingOrder,
-		SystemPrompt:    getStringPointer(protoDef.GetSystemPrompt()), // Safe getter for pointers
+		SystemPrompt:    copyStringPointer(protoDef.SystemPrompt), // Kept even when empty
 		SelectFields:    protoDef.SelectFields,
 		HashMap:         ConvertProtoToHashMap(protoDef.GetHashMap()),   // Check with Getters
 		NarrowFocus:     ConvertProtoToFocus(protoDef.GetNarrowFocus()), // Handle nil safely
@@ -32,7 +30,7 @@ func ConvertProtoToModel(protoDef *pb.Definition) *jsonSchema.Definition {
 		SendImage:       convertProtoSendImage(protoDef.GetSendImage()), // Handle nil structs
 		Stream:          protoDef.Stream,
 		Priority:        protoDef.Priority,
-		OverridePrompt:  getStringPointer(protoDef.GetOverridePrompt()),
+		OverridePrompt:  copyStringPointer(protoDef.OverridePrompt),
 		DecisionPoint:   convertProtoDecisionPoint(protoDef.GetDecisionPoint()),
 		ScoringCriteria: convertProtoScoringCriteria(protoDef.GetScoringCriteria()),
 		RecursiveLoop:   convertProtoRecursiveLoop(protoDef.GetRecursiveLoop()),
@@ -40,8 +38,9 @@ func ConvertProtoToModel(protoDef *pb.Definition) *jsonSchema.Definition {
 		ModelConfig:     convertProtoModelConfig(protoDef.GetModelConfig()),
 	}
 
-	// Handle Properties map
-	if protoDef.Properties != nil {
+	// Handle Properties map, left nil when empty as proto can't tell an empty map from a missing one
+	if len(protoDef.Properties) > 0 {
+		modelDef.Properties = make(map[string]jsonSchema.Definition, len(protoDef.Properties))
 		for key, protoProperty := range protoDef.Properties {
 			modelDef.Properties[key] = *ConvertProtoToModel(protoProperty)
 		}
@@ -50,39 +49,26 @@ func ConvertProtoToModel(protoDef *pb.Definition) *jsonSchema.Definition {
 	return modelDef
 }
 
-// Helper function to safely get string pointers
-func getStringPointer(val string) *string {
-	if val == "" {
-		return nil
-	}
-	return &val
-}
-
-// Helper function to safely get int pointers
-func getIntPointer(val int32) *int {
-	if val == 0 {
+// Helper function to copy string pointers so that the two Definitions do not share memory
+func copyStringPointer(val *string) *string {
+	if val == nil {
 		return nil
 	}
-	intVal := int(val)
-	return &intVal
+	copied := *val
+	return &copied
 }
 
-// ConvertModelToProto converts your Go model Definition to a protobuf Definition
+// ConvertModelToProto converts your Go model Definition to a protobuf Definition, sending condition values of
+// unsupported types as strings, use ConvertModelToProtoChecked to get an error instead.
+// A round trip through ConvertProtoToModel keeps everything else except for two losses proto can't avoid:
+// the free-form maps (SpeechToText.ExtraBody, ModelConfig.ChatTemplateKwargs, Req.Body) travel as Structs,
+// so their numbers come back as float64 and nil maps nested in them as empty maps, and empty maps and lists
+// come back nil.
 func ConvertModelToProto(modelDef *jsonSchema.Definition) *pb.Definition {
 	if modelDef == nil {
 		return nil
 	}
 
-	systemPrompt := ""
-	if modelDef.SystemPrompt != nil {
-		systemPrompt = *modelDef.SystemPrompt
-	}
-
-	overridePrompt := ""
-	if modelDef.OverridePrompt != nil {
-		overridePrompt = *modelDef.OverridePrompt
-	}
-
 	protoDef := &pb.Definition{
 		Type:            string(modelDef.Type),
 		Instruction:     modelDef.Instruction,
@@ -90,7 +76,7 @@ func ConvertModelToProto(modelDef *jsonSchema.Definition) *pb.Definition {
 		Items:           ConvertModelToProto(modelDef.Items),
 		Model:           modelDef.Model,
 		ProcessingOrder: modelDef.ProcessingOrder,
-		SystemPrompt:    systemPrompt,
+		SystemPrompt:    copyStringPointer(modelDef.SystemPrompt),
 		SelectFields:    modelDef.SelectFields,
 		HashMap:         ConvertModelToProtoHashMap(modelDef.HashMap),
 		NarrowFocus:     ConvertModelToProtoFocus(modelDef.NarrowFocus),
@@ -101,7 +87,7 @@ func ConvertModelToProto(modelDef *jsonSchema.Definition) *pb.Definition {
 		SendImage:       convertModelSendImage(modelDef.SendImage),
 		Stream:          modelDef.Stream,
 		Priority:        modelDef.Priority,
-		OverridePrompt:  overridePrompt,
+		OverridePrompt:  copyStringPointer(modelDef.OverridePrompt),
 		DecisionPoint:   convertModelDecisionPoint(modelDef.DecisionPoint),
 		ScoringCriteria: convertModelScoringCriteria(modelDef.ScoringCriteria),
 		RecursiveLoop:   convertModelRecursiveLoop(modelDef.RecursiveLoop),
@@ -132,6 +118,8 @@ func convertProtoSpeechToText(speechToText *pb.SpeechToText) *jsonSchema.SpeechT
 		ToString:          speechToText.ToString,
 		ToCaptions:        speechToText.ToCaptions,
 		Format:            speechToText.Format,
+		ChunkingStrategy:  speechToText.ChunkingStrategy,
+		ExtraBody:         convertOptionalStructToMap(speechToText.ExtraBody),
 	}
 }
 
@@ -170,6 +158,8 @@ func convertModelSpeechToText(speechToText *jsonSchema.SpeechToText) *pb.SpeechT
 	if speechToText == nil {
 		return nil
 	}
+	// a map that can't be converted is reported by ConvertModelToProtoChecked
+	extraBody, _ := convertOptionalMapToStruct(speechToText.ExtraBody)
 	return &pb.SpeechToText{
 		Model:             string(speechToText.Model),
 		AudioToTranscribe: speechToText.AudioToTranscribe,
@@ -177,6 +167,8 @@ func convertModelSpeechToText(speechToText *jsonSchema.SpeechToText) *pb.SpeechT
 		ToString:          speechToText.ToString,
 		ToCaptions:        speechToText.ToCaptions,
 		Format:            string(speechToText.Format),
+		ChunkingStrategy:  speechToText.ChunkingStrategy,
+		ExtraBody:         extraBody,
 	}
 }
 
@@ -218,9 +210,12 @@ func convertProtoDecisionPoint(dp *pb.DecisionPoint) *jsonSchema.DecisionPoint {
 		return nil
 	}
 
-	branches := make([]jsonSchema.ConditionalBranch, len(dp.Branches))
-	for i, branch := range dp.Branches {
-		branches[i] = *convertProtoConditionalBranch(branch)
+	var branches []jsonSchema.ConditionalBranch
+	if len(dp.Branches) > 0 {
+		branches = make([]jsonSchema.ConditionalBranch, len(dp.Branches))
+		for i, branch := range dp.Branches {
+			branches[i] = *convertProtoConditionalBranch(branch)
+		}
 	}
 
 	return &jsonSchema.DecisionPoint{
@@ -236,16 +231,25 @@ func convertProtoConditionalBranch(cb *pb.ConditionalBranch) *jsonSchema.Conditi
 		return nil
 	}
 
-	conditions := make([]jsonSchema.Condition, len(cb.Conditions))
-	for i, cond := range cb.Conditions {
-		conditions[i] = *convertProtoCondition(cond)
+	var conditions []jsonSchema.Condition
+	if len(cb.Conditions) > 0 {
+		conditions = make([]jsonSchema.Condition, len(cb.Conditions))
+		for i, cond := range cb.Conditions {
+			conditions[i] = *convertProtoCondition(cond)
+		}
+	}
+
+	// Then is a value in the Go model so a missing proto Definition becomes the zero Definition
+	var then jsonSchema.Definition
+	if cb.Then != nil {
+		then = *ConvertProtoToModel(cb.Then)
 	}
 
 	return &jsonSchema.ConditionalBranch{
 		Name:       cb.Name,
 		Conditions: conditions,
 		Logic:      ConvertProtoToModel(cb.Logic),
-		Then:       *ConvertProtoToModel(cb.Then),
+		Then:       then,
 		Priority:   int(cb.Priority),
 	}
 }
@@ -259,11 +263,18 @@ func convertProtoCondition(c *pb.Condition) *jsonSchema.Condition {
 	// Handle oneof value field
 	switch v := c.Value.(type) {
 	case *pb.Condition_NumberValue:
-		value = v.NumberValue
+		value = restoreValueType(v.NumberValue, c.ValueType)
 	case *pb.Condition_StringValue:
 		value = v.StringValue
 	case *pb.Condition_BoolValue:
 		value = v.BoolValue
+	case *pb.Condition_IntValue:
+		value = int(v.IntValue)
+		if c.ValueType != "" {
+			value = restoreValueType(v.IntValue, c.ValueType)
+		}
+	case *pb.Condition_ListValue:
+		value = convertProtoConditionValueList(v.ListValue)
 	}
 
 	return &jsonSchema.Condition{
@@ -279,9 +290,12 @@ func convertProtoScoringCriteria(sc *pb.ScoringCriteria) *jsonSchema.ScoringCrit
 		return nil
 	}
 
-	dimensions := make(map[string]jsonSchema.ScoringDimension)
-	for key, dim := range sc.Dimensions {
-		dimensions[key] = *convertProtoScoringDimension(dim)
+	var dimensions map[string]jsonSchema.ScoringDimension
+	if len(sc.Dimensions) > 0 {
+		dimensions = make(map[string]jsonSchema.ScoringDimension)
+		for key, dim := range sc.Dimensions {
+			dimensions[key] = *convertProtoScoringDimension(dim)
+		}
 	}
 
 	return &jsonSchema.ScoringCriteria{
@@ -368,9 +382,18 @@ func convertModelConditionalBranch(cb *jsonSchema.ConditionalBranch) *pb.Conditi
 	}
 }
 
+// convertModelCondition converts the condition, sending values of unsupported types as strings,
+// the error is reported by ConvertModelToProtoChecked
 func convertModelCondition(c *jsonSchema.Condition) *pb.Condition {
+	condition, _ := convertModelConditionChecked(c)
+	return condition
+}
+
+// convertModelConditionChecked converts the condition, returning an error alongside a best effort conversion
+// when its value has a type the proto messages can't represent
+func convertModelConditionChecked(c *jsonSchema.Condition) (*pb.Condition, error) {
 	if c == nil {
-		return nil
+		return nil, nil
 	}
 
 	condition := &pb.Condition{
@@ -378,31 +401,41 @@ func convertModelCondition(c *jsonSchema.Condition) *pb.Condition {
 		Operator:  string(c.Operator),
 		FieldPath: c.FieldPath,
 	}
+	if c.Value == nil {
+		return condition, nil
+	}
 
-	// Set the appropriate oneof field based on the value type
-	if c.Value != nil {
-		switch v := c.Value.(type) {
-		case float64:
-			condition.Value = &pb.Condition_NumberValue{NumberValue: v}
-		case float32:
-			condition.Value = &pb.Condition_NumberValue{NumberValue: float64(v)}
-		case int:
-			condition.Value = &pb.Condition_NumberValue{NumberValue: float64(v)}
-		case int32:
-			condition.Value = &pb.Condition_NumberValue{NumberValue: float64(v)}
-		case int64:
-			condition.Value = &pb.Condition_NumberValue{NumberValue: float64(v)}
-		case string:
-			condition.Value = &pb.Condition_StringValue{StringValue: v}
-		case bool:
-			condition.Value = &pb.Condition_BoolValue{BoolValue: v}
-		default:
-			// For other types, try to convert to string as fallback
-			condition.Value = &pb.Condition_StringValue{StringValue: fmt.Sprintf("%v", v)}
-		}
+	// Lists are used with the in and nin operators
+	list, err := convertModelConditionValueList(c.Value)
+	if err != nil {
+		condition.Value = &pb.Condition_StringValue{StringValue: fmt.Sprintf("%v", c.Value)}
+		return condition, fmt.Errorf("condition on %s: %v", c.Field, err)
+	}
+	if list != nil {
+		condition.Value = &pb.Condition_ListValue{ListValue: list}
+		return condition, nil
 	}
 
-	return condition
+	value, err := convertModelConditionValue(c.Value)
+	if err != nil {
+		// For other types, convert to string as fallback
+		condition.Value = &pb.Condition_StringValue{StringValue: fmt.Sprintf("%v", c.Value)}
+		return condition, fmt.Errorf("condition on %s: %v", c.Field, err)
+	}
+
+	// Set the appropriate oneof field based on the value type
+	condition.ValueType = value.ValueType
+	switch v := value.Value.(type) {
+	case *pb.ConditionValue_NumberValue:
+		condition.Value = &pb.Condition_NumberValue{NumberValue: v.NumberValue}
+	case *pb.ConditionValue_IntValue:
+		condition.Value = &pb.Condition_IntValue{IntValue: v.IntValue}
+	case *pb.ConditionValue_StringValue:
+		condition.Value = &pb.Condition_StringValue{StringValue: v.StringValue}
+	case *pb.ConditionValue_BoolValue:
+		condition.Value = &pb.Condition_BoolValue{BoolValue: v.BoolValue}
+	}
+	return condition, nil
 }
 
 func convertModelScoringCriteria(sc *jsonSchema.ScoringCriteria) *pb.ScoringCriteria {
@@ -492,23 +525,23 @@ func convertProtoModelConfig(mc *pb.ModelConfig) *jsonSchema.ModelConfig {
 	}
 
 	// Convert logit bias map from int32 to int
-	logitBias := make(map[string]int)
-	for k, v := range mc.LogitBias {
-		logitBias[k] = int(v)
+	var logitBias map[string]int
+	if len(mc.LogitBias) > 0 {
+		logitBias = make(map[string]int)
+		for k, v := range mc.LogitBias {
+			logitBias[k] = int(v)
+		}
 	}
 
-	// Convert seed from int32 to *int
+	// Convert seed from *int32 to *int, a seed of 0 is kept
 	var seed *int
-	if mc.Seed != 0 {
-		seedVal := int(mc.Seed)
+	if mc.Seed != nil {
+		seedVal := int(*mc.Seed)
 		seed = &seedVal
 	}
 
 	// Convert chatTemplateKwargs from protobuf Struct to map[string]any
-	var chatTemplateKwargs map[string]any
-	if mc.ChatTemplateKwargs != nil {
-		chatTemplateKwargs = mc.ChatTemplateKwargs.AsMap()
-	}
+	chatTemplateKwargs := convertOptionalStructToMap(mc.ChatTemplateKwargs)
 
 	return &jsonSchema.ModelConfig{
 		MaxCompletionTokens: int(mc.MaxCompletionTokens),
@@ -542,22 +575,16 @@ func convertModelModelConfig(mc *jsonSchema.ModelConfig) *pb.ModelConfig {
 		logitBias[k] = int32(v)
 	}
 
-	// Convert seed from *int to int32
-	seed := int32(0)
+	// Convert seed from *int to *int32
+	var seed *int32
 	if mc.Seed != nil {
-		seed = int32(*mc.Seed)
+		seedVal := int32(*mc.Seed)
+		seed = &seedVal
 	}
 
-	// Convert chatTemplateKwargs from map[string]any to protobuf Struct
-	var chatTemplateKwargs *structpb.Struct
-	if mc.ChatTemplateKwargs != nil {
-		var err error
-		chatTemplateKwargs, err = structpb.NewStruct(mc.ChatTemplateKwargs)
-		if err != nil {
-			// If conversion fails, leave it as nil
-			chatTemplateKwargs = nil
-		}
-	}
+	// Convert chatTemplateKwargs from map[string]any to protobuf Struct, a map that can't be converted
+	// is reported by ConvertModelToProtoChecked
+	chatTemplateKwargs, _ := convertOptionalMapToStruct(mc.ChatTemplateKwargs)
 
 	return &pb.ModelConfig{
 		MaxCompletionTokens: int32(mc.MaxCompletionTokens),
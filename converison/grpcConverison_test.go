@@ -0,0 +1,490 @@
+package converison
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	pb "github.com/objectweaver/go-sdk/grpc"
+	"github.com/objectweaver/go-sdk/jsonSchema"
+	"google.golang.org/protobuf/proto"
+)
+
+// typedConditions is a Definition whose conditions use numbers that are not int or float64
+func typedConditions() jsonSchema.Definition {
+	return jsonSchema.Definition{
+		Type:        jsonSchema.Object,
+		Instruction: "Route by level",
+		Properties: map[string]jsonSchema.Definition{
+			"level": {Type: jsonSchema.Integer},
+			"notes": {Type: jsonSchema.String},
+		},
+		DecisionPoint: &jsonSchema.DecisionPoint{
+			Name:     "route",
+			Strategy: jsonSchema.RouteByField,
+			Branches: []jsonSchema.ConditionalBranch{
+				{
+					Name: "int32",
+					Conditions: []jsonSchema.Condition{
+						{Field: "level", FieldPath: "level", Operator: jsonSchema.OpGreaterThan, Value: int32(3)},
+						{Field: "level", FieldPath: "level", Operator: jsonSchema.OpLessThan, Value: int64(1 << 40)},
+					},
+					Then: jsonSchema.Definition{Type: jsonSchema.String, Instruction: "int"},
+				},
+				{
+					Name: "floats",
+					Conditions: []jsonSchema.Condition{
+						{Field: "score", Operator: jsonSchema.OpEqual, Value: float32(0.5)},
+						{Field: "score", Operator: jsonSchema.OpIn, Value: []float32{1.5, 2.5}},
+						{Field: "level", Operator: jsonSchema.OpIn, Value: []int64{1, 2}},
+						{Field: "level", Operator: jsonSchema.OpEqual, Value: 7},
+						{Field: "ratio", Operator: jsonSchema.OpEqual, Value: 0.25},
+					},
+					Then: jsonSchema.Definition{Type: jsonSchema.String, Instruction: "float"},
+				},
+			},
+		},
+	}
+}
+
+func TestDefinitionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		def  jsonSchema.Definition
+	}{
+		{"scalar without properties", jsonSchema.Definition{Type: jsonSchema.String, Instruction: "name"}},
+		{"typed conditions", typedConditions()},
+		{"score based routing", jsonSchema.ExampleSimpleScoreBasedRouting()},
+		{"field based routing", jsonSchema.ExampleFieldBasedRouting()},
+		{"hybrid routing", jsonSchema.ExampleHybridRouting()},
+		{"recursive loop", jsonSchema.ExampleRecursiveLoop()},
+		{"nested decision tree", jsonSchema.ExampleNestedDecisionTree()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proto, err := ConvertModelToProtoChecked(&tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ConvertProtoToModel(proto); !reflect.DeepEqual(*got, tt.def) {
+				t.Errorf("round trip changed the definition\ngot  %#v\nwant %#v", *got, tt.def)
+			}
+		})
+	}
+}
+
+func TestConvertModelToProtoCheckedUnsupported(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		path  string
+	}{
+		{"struct", struct{ Level int }{3}, "$.decisionPoint.branches[0].conditions[0]"},
+		{"uint", uint(3), "$.decisionPoint.branches[0].conditions[0]"},
+		{"list of maps", []map[string]int{{"a": 1}}, "$.decisionPoint.branches[0].conditions[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := jsonSchema.Definition{
+				Type: jsonSchema.String,
+				DecisionPoint: &jsonSchema.DecisionPoint{Branches: []jsonSchema.ConditionalBranch{{
+					Conditions: []jsonSchema.Condition{{Field: "level", Operator: jsonSchema.OpEqual, Value: tt.value}},
+				}}},
+			}
+			_, err := ConvertModelToProtoChecked(&def)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.path) {
+				t.Fatalf("error = %v, want one at %s", err, tt.path)
+			}
+
+			// the unchecked conversion still sends the value, as a string
+			proto := ConvertModelToProto(&def)
+			if got := proto.DecisionPoint.Branches[0].Conditions[0].GetStringValue(); got == "" {
+				t.Error("unchecked conversion dropped the value")
+			}
+		})
+	}
+}
+
+// quickDefinition generates random Definitions within what proto can carry without loss,
+// see ConvertModelToProto for the documented losses
+type quickDefinition struct {
+	def jsonSchema.Definition
+}
+
+// quickRunes are the characters of generated strings, including multi byte ones
+var quickRunes = []rune("abcXYZ019 _-.\n\"é世🙂")
+
+func (quickDefinition) Generate(r *rand.Rand, size int) reflect.Value {
+	g := definitionGenerator{r: r}
+	return reflect.ValueOf(quickDefinition{g.definition(3)})
+}
+
+type definitionGenerator struct {
+	r *rand.Rand
+}
+
+func (g definitionGenerator) chance() bool {
+	return g.r.Intn(2) == 0
+}
+
+func (g definitionGenerator) string() string {
+	runes := make([]rune, g.r.Intn(8))
+	for i := range runes {
+		runes[i] = quickRunes[g.r.Intn(len(quickRunes))]
+	}
+	return string(runes)
+}
+
+// strings returns nil or a non-empty list, as an empty list comes back nil
+func (g definitionGenerator) strings() []string {
+	if g.chance() {
+		return nil
+	}
+	values := make([]string, 1+g.r.Intn(3))
+	for i := range values {
+		values[i] = g.string()
+	}
+	return values
+}
+
+func (g definitionGenerator) stringPointer() *string {
+	if g.chance() {
+		return nil
+	}
+	value := g.string()
+	return &value
+}
+
+func (g definitionGenerator) int32() int32 {
+	return int32(g.r.Uint32())
+}
+
+// structValue returns a value a protobuf Struct carries unchanged, numbers only as float64
+func (g definitionGenerator) structValue(depth int) interface{} {
+	switch g.r.Intn(6) {
+	case 0:
+		return nil
+	case 1:
+		return g.r.NormFloat64() * 1e6
+	case 2:
+		return g.chance()
+	case 3:
+		if depth > 0 {
+			values := make([]interface{}, 1+g.r.Intn(3))
+			for i := range values {
+				values[i] = g.structValue(depth - 1)
+			}
+			return values
+		}
+	case 4:
+		if depth > 0 {
+			return g.structFields(depth - 1)
+		}
+	}
+	return g.string()
+}
+
+// structMap returns nil or a non-empty map, as an empty map comes back nil
+// while a nil map nested in a Struct comes back empty
+func (g definitionGenerator) structMap(depth int) map[string]interface{} {
+	if g.chance() {
+		return nil
+	}
+	return g.structFields(depth)
+}
+
+func (g definitionGenerator) structFields(depth int) map[string]interface{} {
+	m := make(map[string]interface{})
+	for i := 0; i <= g.r.Intn(3); i++ {
+		m[g.string()] = g.structValue(depth)
+	}
+	return m
+}
+
+// conditionValue returns a scalar or non-empty list of a type convertModelConditionChecked supports
+func (g definitionGenerator) conditionValue() interface{} {
+	switch g.r.Intn(10) {
+	case 0:
+		return nil
+	case 1:
+		return g.r.Int()
+	case 2:
+		return g.int32()
+	case 3:
+		return g.r.Int63()
+	case 4:
+		return g.r.Float32()
+	case 5:
+		return g.r.NormFloat64()
+	case 6:
+		return g.chance()
+	case 7:
+		return append(g.strings(), g.string())
+	case 8:
+		return []int64{g.r.Int63(), -g.r.Int63()}
+	case 9:
+		return []interface{}{g.string(), g.r.Int(), g.r.NormFloat64(), g.chance()}
+	}
+	return g.string()
+}
+
+func (g definitionGenerator) decision(depth int) *jsonSchema.DecisionPoint {
+	if depth == 0 || g.chance() {
+		return nil
+	}
+	decision := &jsonSchema.DecisionPoint{
+		Name:             g.string(),
+		EvaluationPrompt: g.string(),
+		Strategy:         jsonSchema.RoutingStrategy(g.string()),
+	}
+	for i := 0; i <= g.r.Intn(2); i++ {
+		branch := jsonSchema.ConditionalBranch{
+			Name:     g.string(),
+			Then:     g.definition(depth - 1),
+			Priority: int(g.int32()),
+		}
+		if g.chance() {
+			logic := g.definition(depth - 1)
+			branch.Logic = &logic
+		}
+		for j := 0; j < g.r.Intn(3); j++ {
+			branch.Conditions = append(branch.Conditions, jsonSchema.Condition{
+				Field:     g.string(),
+				Operator:  jsonSchema.ComparisonOperator(g.string()),
+				Value:     g.conditionValue(),
+				FieldPath: g.string(),
+			})
+		}
+		decision.Branches = append(decision.Branches, branch)
+	}
+	return decision
+}
+
+func (g definitionGenerator) definition(depth int) jsonSchema.Definition {
+	def := jsonSchema.Definition{
+		Type:            jsonSchema.DataType(g.string()),
+		Instruction:     g.string(),
+		Model:           g.string(),
+		ProcessingOrder: g.strings(),
+		SystemPrompt:    g.stringPointer(),
+		SelectFields:    g.strings(),
+		Stream:          g.chance(),
+		OverridePrompt:  g.stringPointer(),
+		Priority:        g.int32(),
+		DecisionPoint:   g.decision(depth),
+		Epistemic:       jsonSchema.EpistemicValidation{Active: g.chance(), Judges: int(g.int32())},
+	}
+	if depth > 0 && g.chance() {
+		def.Properties = make(map[string]jsonSchema.Definition)
+		for i := 0; i <= g.r.Intn(2); i++ {
+			def.Properties[g.string()] = g.definition(depth - 1)
+		}
+	}
+	if depth > 0 && g.chance() {
+		items := g.definition(depth - 1)
+		def.Items = &items
+	}
+	if g.chance() {
+		def.HashMap = &jsonSchema.HashMap{KeyInstruction: g.string()}
+		if depth > 0 && g.chance() {
+			field := g.definition(depth - 1)
+			def.HashMap.FieldDefinition = &field
+		}
+	}
+	if g.chance() {
+		def.TextToSpeech = &jsonSchema.TextToSpeech{Model: g.string(), StringToAudio: g.string(), Voice: g.string(), Format: g.string()}
+	}
+	if g.chance() {
+		def.SpeechToText = &jsonSchema.SpeechToText{
+			Model:            g.string(),
+			Language:         g.string(),
+			Format:           g.string(),
+			ToString:         g.chance(),
+			ToCaptions:       g.chance(),
+			ChunkingStrategy: g.string(),
+			ExtraBody:        g.structMap(2),
+		}
+		if g.chance() {
+			def.SpeechToText.AudioToTranscribe = []byte(g.string() + "x")
+		}
+	}
+	if g.chance() {
+		def.Image = &jsonSchema.Image{Model: g.string(), Size: g.string()}
+	}
+	if g.chance() {
+		def.SendImage = &jsonSchema.SendImage{ImagesData: [][]byte{[]byte(g.string() + "x")}}
+	}
+	if g.chance() {
+		def.Req = &jsonSchema.RequestFormat{
+			URL:           g.string(),
+			Method:        jsonSchema.HTTPMethod(g.string()),
+			Body:          g.structMap(2),
+			Authorization: g.string(),
+			RequireFields: g.strings(),
+		}
+		if g.chance() {
+			def.Req.Headers = map[string]string{g.string(): g.string()}
+		}
+	}
+	if g.chance() {
+		def.NarrowFocus = &jsonSchema.Focus{Prompt: g.string(), Fields: g.strings(), KeepOriginal: g.chance()}
+	}
+	if g.chance() {
+		def.ScoringCriteria = &jsonSchema.ScoringCriteria{
+			EvaluationModel:   g.string(),
+			AggregationMethod: jsonSchema.AggregationMethod(g.string()),
+		}
+		if g.chance() {
+			dimension := jsonSchema.ScoringDimension{Description: g.string(), Type: jsonSchema.ScoreType(g.string()), Weight: g.r.Float64()}
+			if g.chance() {
+				dimension.Scale = &jsonSchema.ScoreScale{Min: int(g.int32()), Max: int(g.int32())}
+			}
+			def.ScoringCriteria.Dimensions = map[string]jsonSchema.ScoringDimension{g.string(): dimension}
+		}
+	}
+	if g.chance() {
+		def.RecursiveLoop = &jsonSchema.RecursiveLoop{
+			MaxIterations:           int(g.int32()),
+			Selection:               jsonSchema.SelectionStrategy(g.string()),
+			TerminationPoint:        g.decision(depth),
+			FeedbackPrompt:          g.string(),
+			IncludePreviousAttempts: g.chance(),
+		}
+	}
+	if g.chance() {
+		seed := int(g.int32())
+		def.ModelConfig = &jsonSchema.ModelConfig{
+			MaxCompletionTokens: int(g.int32()),
+			Temperature:         g.r.Float32(),
+			TopP:                g.r.Float32(),
+			N:                   int(g.int32()),
+			Stream:              g.chance(),
+			Stop:                g.strings(),
+			PresencePenalty:     g.r.Float32(),
+			Seed:                &seed,
+			FrequencyPenalty:    g.r.Float32(),
+			LogProbs:            g.chance(),
+			TopLogProbs:         int(g.int32()),
+			User:                g.string(),
+			Store:               g.chance(),
+			ReasoningEffort:     g.string(),
+			ChatTemplateKwargs:  g.structMap(2),
+		}
+		if g.chance() {
+			def.ModelConfig.LogitBias = map[string]int{g.string(): int(g.int32())}
+			def.ModelConfig.Metadata = map[string]string{g.string(): g.string()}
+		}
+	}
+	return def
+}
+
+// wireRoundTrip converts the Definition to proto, through its wire encoding and back
+func wireRoundTrip(def *jsonSchema.Definition) (*jsonSchema.Definition, error) {
+	converted, err := ConvertModelToProtoChecked(def)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+	var received pb.Definition
+	if err := proto.Unmarshal(data, &received); err != nil {
+		return nil, err
+	}
+	return ConvertProtoToModel(&received), nil
+}
+
+func TestDefinitionWireRoundTrip(t *testing.T) {
+	roundTrip := func(q quickDefinition) bool {
+		got, err := wireRoundTrip(&q.def)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		if !reflect.DeepEqual(*got, q.def) {
+			want, _ := json.Marshal(q.def)
+			changed, _ := json.Marshal(got)
+			t.Logf("round trip changed the definition\ngot  %s\nwant %s", changed, want)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDefinitionRoundTripLosses(t *testing.T) {
+	tests := []struct {
+		name string
+		def  jsonSchema.Definition
+		want jsonSchema.Definition
+	}{
+		{
+			name: "extra body numbers",
+			def:  jsonSchema.Definition{SpeechToText: &jsonSchema.SpeechToText{ExtraBody: map[string]any{"n": 1}}},
+			want: jsonSchema.Definition{SpeechToText: &jsonSchema.SpeechToText{ExtraBody: map[string]any{"n": 1.0}}},
+		},
+		{
+			name: "chat template kwargs numbers",
+			def:  jsonSchema.Definition{ModelConfig: &jsonSchema.ModelConfig{ChatTemplateKwargs: map[string]any{"depth": int64(2)}}},
+			want: jsonSchema.Definition{ModelConfig: &jsonSchema.ModelConfig{ChatTemplateKwargs: map[string]any{"depth": 2.0}}},
+		},
+		{
+			name: "nested nil map",
+			def:  jsonSchema.Definition{Req: &jsonSchema.RequestFormat{Body: map[string]any{"options": map[string]any(nil)}}},
+			want: jsonSchema.Definition{Req: &jsonSchema.RequestFormat{Body: map[string]any{"options": map[string]any{}}}},
+		},
+		{
+			name: "empty properties",
+			def:  jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{}},
+			want: jsonSchema.Definition{Type: jsonSchema.Object},
+		},
+		{
+			name: "empty dimensions",
+			def:  jsonSchema.Definition{ScoringCriteria: &jsonSchema.ScoringCriteria{Dimensions: map[string]jsonSchema.ScoringDimension{}}},
+			want: jsonSchema.Definition{ScoringCriteria: &jsonSchema.ScoringCriteria{}},
+		},
+		{
+			name: "empty list",
+			def:  jsonSchema.Definition{ProcessingOrder: []string{}},
+			want: jsonSchema.Definition{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wireRoundTrip(&tt.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("round trip = %#v, want %#v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertModelToProtoCheckedMaps(t *testing.T) {
+	unconvertible := map[string]any{"callback": func() {}}
+	tests := []struct {
+		name string
+		def  jsonSchema.Definition
+		path string
+	}{
+		{"extra body", jsonSchema.Definition{SpeechToText: &jsonSchema.SpeechToText{ExtraBody: unconvertible}}, "$.speechToText.extraBody"},
+		{"chat template kwargs", jsonSchema.Definition{ModelConfig: &jsonSchema.ModelConfig{ChatTemplateKwargs: unconvertible}}, "$.modelConfig.chat_template_kwargs"},
+		{"request body", jsonSchema.Definition{Items: &jsonSchema.Definition{Req: &jsonSchema.RequestFormat{Body: unconvertible}}}, "$[].req.body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertModelToProtoChecked(&tt.def)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.path+":") {
+				t.Errorf("error = %v, want one at %s", err, tt.path)
+			}
+		})
+	}
+}
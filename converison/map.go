@@ -1,13 +1,56 @@
 package converison
 
-import "google.golang.org/protobuf/types/known/structpb"
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
 
 // Convert map[string]interface{} to *structpb.Struct
+// Values that structpb cannot represent directly, such as []string, are normalised through JSON first.
 func ConvertMapToStruct(m map[string]interface{}) (*structpb.Struct, error) {
-	return structpb.NewStruct(m)
+	s, err := structpb.NewStruct(m)
+	if err == nil {
+		return s, nil
+	}
+
+	normalised, jsonErr := normaliseJSONMap(m)
+	if jsonErr != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(normalised)
 }
 
 // Convert *structpb.Struct to map[string]interface{}
 func ConvertStructToMap(s *structpb.Struct) (map[string]interface{}, error) {
 	return s.AsMap(), nil
 }
+
+// convertOptionalMapToStruct keeps a nil map as a nil Struct and returns an error for maps that cannot be converted
+func convertOptionalMapToStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return ConvertMapToStruct(m)
+}
+
+// convertOptionalStructToMap keeps a nil Struct as a nil map
+func convertOptionalStructToMap(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+// normaliseJSONMap round trips the map through JSON so that only JSON types remain
+func normaliseJSONMap(m map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var normalised map[string]interface{}
+	if err := json.Unmarshal(data, &normalised); err != nil {
+		return nil, err
+	}
+	return normalised, nil
+}
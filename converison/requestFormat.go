@@ -11,7 +11,7 @@ func ConvertProtoToRequestFormat(protoReq *pb.RequestFormat) *jsonSchema.Request
 		return nil
 	}
 
-	body, _ := ConvertStructToMap(protoReq.Body)
+	body := convertOptionalStructToMap(protoReq.Body)
 
 	return &jsonSchema.RequestFormat{
 		URL:           protoReq.Url,
@@ -29,7 +29,8 @@ func ConvertModelToProtoRequestFormat(modelReq *jsonSchema.RequestFormat) *pb.Re
 		return nil
 	}
 
-	body, _ := ConvertMapToStruct(modelReq.Body)
+	// a body that can't be converted is reported by ConvertModelToProtoChecked
+	body, _ := convertOptionalMapToStruct(modelReq.Body)
 
 	return &pb.RequestFormat{
 		Url:           modelReq.URL,
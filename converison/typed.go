@@ -0,0 +1,281 @@
+package converison
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+// integerTolerance is how far a float may be from a whole number and still be accepted as an Integer
+const integerTolerance = 1e-6
+
+// TypeMismatch describes a generated value that does not match the type declared by its Definition
+type TypeMismatch struct {
+	Path     string              `json:"path"`
+	Expected jsonSchema.DataType `json:"expected"`
+	Value    any                 `json:"value"`
+	Reason   string              `json:"reason"`
+}
+
+// Error implements the error interface so mismatches can be returned or joined as errors
+func (m TypeMismatch) Error() string {
+	return fmt.Sprintf("%s: expected %s, %s", m.Path, m.Expected, m.Reason)
+}
+
+// ApplyDefinitionTypes converts generated data to the Go types declared by the Definition.
+// Integer becomes int64, Number float64, Byte is base64 decoded into []byte and Vector becomes []float32.
+// Numbers may be given as float64 or json.Number; values that cannot be converted are left untouched and reported.
+func ApplyDefinitionTypes(data map[string]any, def *jsonSchema.Definition) (map[string]any, []TypeMismatch) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var mismatches []TypeMismatch
+	converted := convertTypedValue(data, def, "", &mismatches)
+	// map iteration order is random so order the report by path
+	sort.SliceStable(mismatches, func(i, j int) bool { return mismatches[i].Path < mismatches[j].Path })
+	if result, ok := converted.(map[string]any); ok {
+		return result, mismatches
+	}
+	return data, mismatches
+}
+
+// convertTypedValue converts a single value against its Definition, appending any mismatch found
+func convertTypedValue(value any, def *jsonSchema.Definition, path string, mismatches *[]TypeMismatch) any {
+	if value == nil {
+		return nil
+	}
+	if def == nil {
+		return normaliseUntypedValue(value)
+	}
+	if def.HashMap != nil {
+		return convertTypedMap(value, def, def.HashMap.FieldDefinition, path, mismatches)
+	}
+
+	mismatch := func(reason string) any {
+		*mismatches = append(*mismatches, TypeMismatch{Path: displayPath(path), Expected: def.Type, Value: value, Reason: reason})
+		return normaliseUntypedValue(value)
+	}
+
+	switch def.Type {
+	case jsonSchema.Object:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return mismatch(fmt.Sprintf("got %T", value))
+		}
+		result := make(map[string]any, len(object))
+		for key, field := range object {
+			var fieldDef *jsonSchema.Definition
+			if property, exists := def.Properties[key]; exists {
+				fieldDef = &property
+			}
+			result[key] = convertTypedValue(field, fieldDef, joinPath(path, key), mismatches)
+		}
+		return result
+	case jsonSchema.Map:
+		return convertTypedMap(value, def, nil, path, mismatches)
+	case jsonSchema.Array:
+		list, ok := value.([]any)
+		if !ok {
+			return mismatch(fmt.Sprintf("got %T", value))
+		}
+		result := make([]any, len(list))
+		for i, item := range list {
+			result[i] = convertTypedValue(item, def.Items, fmt.Sprintf("%s[%d]", path, i), mismatches)
+		}
+		return result
+	case jsonSchema.Integer:
+		integer, err := toInt64(value)
+		if err != nil {
+			return mismatch(err.Error())
+		}
+		return integer
+	case jsonSchema.Number:
+		number, err := toFloat64(value)
+		if err != nil {
+			return mismatch(err.Error())
+		}
+		return number
+	case jsonSchema.Byte:
+		switch v := value.(type) {
+		case []byte:
+			return v
+		case string:
+			decoded, err := decodeBase64(v)
+			if err != nil {
+				return mismatch("invalid base64: " + err.Error())
+			}
+			return decoded
+		}
+		return mismatch(fmt.Sprintf("got %T", value))
+	case jsonSchema.Vector:
+		return convertVector(value, mismatch)
+	case jsonSchema.Boolean:
+		if _, ok := value.(bool); !ok {
+			return mismatch(fmt.Sprintf("got %T", value))
+		}
+		return value
+	case jsonSchema.String:
+		if _, ok := value.(string); !ok {
+			return mismatch(fmt.Sprintf("got %T", value))
+		}
+		return value
+	}
+	return normaliseUntypedValue(value)
+}
+
+// convertTypedMap converts every value of a map against the same field Definition
+func convertTypedMap(value any, def, fieldDef *jsonSchema.Definition, path string, mismatches *[]TypeMismatch) any {
+	object, ok := value.(map[string]any)
+	if !ok {
+		*mismatches = append(*mismatches, TypeMismatch{Path: displayPath(path), Expected: def.Type, Value: value, Reason: fmt.Sprintf("got %T", value)})
+		return normaliseUntypedValue(value)
+	}
+	result := make(map[string]any, len(object))
+	for key, field := range object {
+		result[key] = convertTypedValue(field, fieldDef, joinPath(path, key), mismatches)
+	}
+	return result
+}
+
+// convertVector converts a list of numbers to []float32
+func convertVector(value any, mismatch func(string) any) any {
+	switch v := value.(type) {
+	case []float32:
+		return v
+	case []float64:
+		vector := make([]float32, len(v))
+		for i, f := range v {
+			vector[i] = float32(f)
+		}
+		return vector
+	case []any:
+		vector := make([]float32, len(v))
+		for i, item := range v {
+			f, err := toFloat64(item)
+			if err != nil {
+				return mismatch(fmt.Sprintf("element %d: %v", i, err))
+			}
+			vector[i] = float32(f)
+		}
+		return vector
+	}
+	return mismatch(fmt.Sprintf("got %T", value))
+}
+
+// normaliseUntypedValue turns json.Number into float64 so untyped values match the behaviour of ConvertStructpbToMap
+func normaliseUntypedValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, field := range v {
+			result[key] = normaliseUntypedValue(field)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = normaliseUntypedValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v.String())
+		}
+		return floatToInt64(f)
+	case float64:
+		return floatToInt64(v)
+	case float32:
+		return floatToInt64(float64(v))
+	}
+	return 0, fmt.Errorf("got %T", value)
+}
+
+func floatToInt64(f float64) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%v is not a finite number", f)
+	}
+	rounded := math.Round(f)
+	if math.Abs(f-rounded) > integerTolerance {
+		return 0, fmt.Errorf("%v is not a whole number", f)
+	}
+	// math.MaxInt64 rounds up to 2^63 as a float64, which no longer fits
+	if rounded >= math.MaxInt64 || rounded < math.MinInt64 {
+		return 0, fmt.Errorf("%v overflows int64", f)
+	}
+	return int64(rounded), nil
+}
+
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v.String())
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("got %T", value)
+}
+
+// decodeBase64 accepts both the standard and URL alphabets, padded or not
+func decodeBase64(value string) ([]byte, error) {
+	var firstErr error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		decoded, err := encoding.DecodeString(value)
+		if err == nil {
+			return decoded, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return path
+}
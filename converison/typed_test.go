@@ -0,0 +1,109 @@
+package converison
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/objectweaver/go-sdk/jsonSchema"
+)
+
+func TestApplyDefinitionTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		def  jsonSchema.Definition
+		data any
+		want any
+		path string //path of the single mismatch expected, empty for none
+	}{
+		{"integer from float", jsonSchema.Definition{Type: jsonSchema.Integer}, 3.0000000001, int64(3), ""},
+		{"integer from large json.Number", jsonSchema.Definition{Type: jsonSchema.Integer}, json.Number("9007199254740993"), int64(9007199254740993), ""},
+		{"integer from exponent json.Number", jsonSchema.Definition{Type: jsonSchema.Integer}, json.Number("1e3"), int64(1000), ""},
+		{"fractional integer", jsonSchema.Definition{Type: jsonSchema.Integer}, 2.5, 2.5, "value"},
+		{"integer from string", jsonSchema.Definition{Type: jsonSchema.Integer}, "3", "3", "value"},
+		{"number from json.Number", jsonSchema.Definition{Type: jsonSchema.Number}, json.Number("0.25"), 0.25, ""},
+		{"byte from base64", jsonSchema.Definition{Type: jsonSchema.Byte}, "aGk=", []byte("hi"), ""},
+		{"byte from unpadded url base64", jsonSchema.Definition{Type: jsonSchema.Byte}, "_w", []byte{0xff}, ""},
+		{"invalid base64", jsonSchema.Definition{Type: jsonSchema.Byte}, "!!", "!!", "value"},
+		{"vector", jsonSchema.Definition{Type: jsonSchema.Vector}, []any{1.0, json.Number("0.5")}, []float32{1, 0.5}, ""},
+		{"vector with text", jsonSchema.Definition{Type: jsonSchema.Vector}, []any{1.0, "x"}, []any{1.0, "x"}, "value"},
+		{"boolean mismatch", jsonSchema.Definition{Type: jsonSchema.Boolean}, "yes", "yes", "value"},
+		{"array of integers", jsonSchema.Definition{Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.Integer}}, []any{1.0, json.Number("2")}, []any{int64(1), int64(2)}, ""},
+		{"array mismatch at index", jsonSchema.Definition{Type: jsonSchema.Array, Items: &jsonSchema.Definition{Type: jsonSchema.Integer}}, []any{1.0, 1.5}, []any{int64(1), 1.5}, "value[1]"},
+		{"hash map values", jsonSchema.Definition{Type: jsonSchema.Object, HashMap: &jsonSchema.HashMap{FieldDefinition: &jsonSchema.Definition{Type: jsonSchema.Integer}}}, map[string]any{"a": 1.0}, map[string]any{"a": int64(1)}, ""},
+		{"untyped json.Number", jsonSchema.Definition{Type: jsonSchema.Map}, map[string]any{"a": json.Number("1.5")}, map[string]any{"a": 1.5}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{"value": tt.def}}
+			got, mismatches := ApplyDefinitionTypes(map[string]any{"value": tt.data}, &def)
+			if !reflect.DeepEqual(got["value"], tt.want) {
+				t.Errorf("value = %#v, want %#v", got["value"], tt.want)
+			}
+			switch {
+			case tt.path == "" && len(mismatches) > 0:
+				t.Errorf("unexpected mismatches %v", mismatches)
+			case tt.path != "" && (len(mismatches) != 1 || mismatches[0].Path != tt.path):
+				t.Errorf("mismatches = %v, want one at %s", mismatches, tt.path)
+			}
+		})
+	}
+}
+
+func TestApplyDefinitionTypesReport(t *testing.T) {
+	def := jsonSchema.Definition{Type: jsonSchema.Object, Properties: map[string]jsonSchema.Definition{
+		"age":  {Type: jsonSchema.Integer},
+		"name": {Type: jsonSchema.String},
+	}}
+	data := map[string]any{"name": 1.0, "age": "old", "extra": json.Number("2")}
+
+	got, mismatches := ApplyDefinitionTypes(data, &def)
+	paths := make([]string, len(mismatches))
+	for i, mismatch := range mismatches {
+		paths[i] = mismatch.Path
+	}
+	if want := []string{"age", "name"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("mismatch paths = %v, want %v", paths, want)
+	}
+	if got["extra"] != 2.0 {
+		t.Errorf("undeclared field = %#v, want 2.0", got["extra"])
+	}
+	if nilData, nilMismatches := ApplyDefinitionTypes(nil, &def); nilData != nil || nilMismatches != nil {
+		t.Error("nil data was not returned as is")
+	}
+}
+
+func TestFloatToInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		want    int64
+		wantErr string
+	}{
+		{"whole", 42, 42, ""},
+		{"within tolerance", -3.0000000001, -3, ""},
+		{"smallest int64", math.MinInt64, math.MinInt64, ""},
+		{"largest float below 2^63", math.Nextafter(math.MaxInt64, 0), 1<<63 - 1024, ""},
+		{"max int64 rounds to 2^63", math.MaxInt64, 0, "overflows int64"},
+		{"below min int64", -1e19, 0, "overflows int64"},
+		{"fraction", 1.5, 0, "is not a whole number"},
+		{"nan", math.NaN(), 0, "NaN is not a finite number"},
+		{"infinity", math.Inf(-1), 0, "-Inf is not a finite number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := floatToInt64(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("floatToInt64(%v) = %d, %v, want error %q", tt.value, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("floatToInt64(%v) = %d, %v, want %d", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
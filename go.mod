@@ -3,8 +3,10 @@ module github.com/objectweaver/go-sdk
 go 1.22
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	google.golang.org/grpc v1.66.0
 	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
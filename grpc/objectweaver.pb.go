@@ -31,7 +31,7 @@ type Definition struct {
 	Items           *Definition            `protobuf:"bytes,4,opt,name=items,proto3" json:"items,omitempty"`                                                                                     // Corresponds to Go's Items field
 	Model           string                 `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`                                                                                     // Corresponds to Go's ModelType field (as a string)
 	ProcessingOrder []string               `protobuf:"bytes,6,rep,name=processingOrder,proto3" json:"processingOrder,omitempty"`                                                                 // Corresponds to Go's ProcessingOrder field
-	SystemPrompt    string                 `protobuf:"bytes,7,opt,name=systemPrompt,proto3" json:"systemPrompt,omitempty"`                                                                       // Corresponds to Go's SystemPrompt field
+	SystemPrompt    *string                `protobuf:"bytes,7,opt,name=systemPrompt,proto3,oneof" json:"systemPrompt,omitempty"`                                                                 // Corresponds to Go's SystemPrompt field (optional so that an empty prompt is kept)
 	Req             *RequestFormat         `protobuf:"bytes,8,opt,name=req,proto3" json:"req,omitempty"`                                                                                         // Corresponds to Go's Req field
 	NarrowFocus     *Focus                 `protobuf:"bytes,9,opt,name=narrowFocus,proto3" json:"narrowFocus,omitempty"`                                                                         // Corresponds to Go's NarrowFocus field
 	SelectFields    []string               `protobuf:"bytes,10,rep,name=selectFields,proto3" json:"selectFields,omitempty"`                                                                      // Corresponds to Go's SelectFields field
@@ -41,7 +41,7 @@ type Definition struct {
 	Image           *Image                 `protobuf:"bytes,14,opt,name=image,proto3" json:"image,omitempty"`                                                                                    // Corresponds to Go's Image field
 	SendImage       *SendImage             `protobuf:"bytes,15,opt,name=sendImage,proto3" json:"sendImage,omitempty"`                                                                            // Corresponds to Go's SendImage field
 	Stream          bool                   `protobuf:"varint,16,opt,name=stream,proto3" json:"stream,omitempty"`
-	OverridePrompt  string                 `protobuf:"bytes,17,opt,name=overridePrompt,proto3" json:"overridePrompt,omitempty"` // Corresponds to Go's OverridePrompt field
+	OverridePrompt  *string                `protobuf:"bytes,17,opt,name=overridePrompt,proto3,oneof" json:"overridePrompt,omitempty"` // Corresponds to Go's OverridePrompt field (optional so that an empty prompt is kept)
 	Priority        int32                  `protobuf:"varint,18,opt,name=priority,proto3" json:"priority,omitempty"`
 	DecisionPoint   *DecisionPoint         `protobuf:"bytes,19,opt,name=decisionPoint,proto3" json:"decisionPoint,omitempty"`     // Corresponds to Go's DecisionPoint field
 	ScoringCriteria *ScoringCriteria       `protobuf:"bytes,20,opt,name=scoringCriteria,proto3" json:"scoringCriteria,omitempty"` // Corresponds to Go's ScoringCriteria field
@@ -125,8 +125,8 @@ func (x *Definition) GetProcessingOrder() []string {
 }
 
 func (x *Definition) GetSystemPrompt() string {
-	if x != nil {
-		return x.SystemPrompt
+	if x != nil && x.SystemPrompt != nil {
+		return *x.SystemPrompt
 	}
 	return ""
 }
@@ -195,8 +195,8 @@ func (x *Definition) GetStream() bool {
 }
 
 func (x *Definition) GetOverridePrompt() string {
-	if x != nil {
-		return x.OverridePrompt
+	if x != nil && x.OverridePrompt != nil {
+		return *x.OverridePrompt
 	}
 	return ""
 }
@@ -321,6 +321,8 @@ type SpeechToText struct {
 	Format            string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                       // Corresponds to Go's Format field
 	ToString          bool                   `protobuf:"varint,5,opt,name=toString,proto3" json:"toString,omitempty"`                  // Corresponds to Go's ToString field
 	ToCaptions        bool                   `protobuf:"varint,6,opt,name=toCaptions,proto3" json:"toCaptions,omitempty"`              // Corresponds to Go's ToCaptions field
+	ChunkingStrategy  string                 `protobuf:"bytes,7,opt,name=chunkingStrategy,proto3" json:"chunkingStrategy,omitempty"`   // Corresponds to Go's ChunkingStrategy field
+	ExtraBody         *structpb.Struct       `protobuf:"bytes,8,opt,name=extraBody,proto3" json:"extraBody,omitempty"`                 // Corresponds to Go's ExtraBody field as map[string]any
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -397,6 +399,20 @@ func (x *SpeechToText) GetToCaptions() bool {
 	return false
 }
 
+func (x *SpeechToText) GetChunkingStrategy() string {
+	if x != nil {
+		return x.ChunkingStrategy
+	}
+	return ""
+}
+
+func (x *SpeechToText) GetExtraBody() *structpb.Struct {
+	if x != nil {
+		return x.ExtraBody
+	}
+	return nil
+}
+
 // Image message
 type Image struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -903,8 +919,11 @@ type Condition struct {
 	//	*Condition_NumberValue
 	//	*Condition_StringValue
 	//	*Condition_BoolValue
+	//	*Condition_IntValue
+	//	*Condition_ListValue
 	Value         isCondition_Value `protobuf_oneof:"value"`
 	FieldPath     string            `protobuf:"bytes,6,opt,name=fieldPath,proto3" json:"fieldPath,omitempty"`
+	ValueType     string            `protobuf:"bytes,9,opt,name=valueType,proto3" json:"valueType,omitempty"` // Go type of a numeric value when it is not int or float64, ie int32 or float32
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -987,6 +1006,24 @@ func (x *Condition) GetBoolValue() bool {
 	return false
 }
 
+func (x *Condition) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*Condition_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Condition) GetListValue() *ConditionValueList {
+	if x != nil {
+		if x, ok := x.Value.(*Condition_ListValue); ok {
+			return x.ListValue
+		}
+	}
+	return nil
+}
+
 func (x *Condition) GetFieldPath() string {
 	if x != nil {
 		return x.FieldPath
@@ -994,6 +1031,13 @@ func (x *Condition) GetFieldPath() string {
 	return ""
 }
 
+func (x *Condition) GetValueType() string {
+	if x != nil {
+		return x.ValueType
+	}
+	return ""
+}
+
 type isCondition_Value interface {
 	isCondition_Value()
 }
@@ -1010,12 +1054,200 @@ type Condition_BoolValue struct {
 	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3,oneof"`
 }
 
+type Condition_IntValue struct {
+	IntValue int64 `protobuf:"varint,7,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Condition_ListValue struct {
+	ListValue *ConditionValueList `protobuf:"bytes,8,opt,name=list_value,json=listValue,proto3,oneof"` // Used with the in and nin operators
+}
+
 func (*Condition_NumberValue) isCondition_Value() {}
 
 func (*Condition_StringValue) isCondition_Value() {}
 
 func (*Condition_BoolValue) isCondition_Value() {}
 
+func (*Condition_IntValue) isCondition_Value() {}
+
+func (*Condition_ListValue) isCondition_Value() {}
+
+// ConditionValue message holds a single scalar of a list condition value
+type ConditionValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*ConditionValue_NumberValue
+	//	*ConditionValue_StringValue
+	//	*ConditionValue_BoolValue
+	//	*ConditionValue_IntValue
+	Value         isConditionValue_Value `protobuf_oneof:"value"`
+	ValueType     string                 `protobuf:"bytes,5,opt,name=valueType,proto3" json:"valueType,omitempty"` // Go type of a numeric value when it is not int or float64, ie int32 or float32
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionValue) Reset() {
+	*x = ConditionValue{}
+	mi := &file_objectweaver_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionValue) ProtoMessage() {}
+
+func (x *ConditionValue) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionValue.ProtoReflect.Descriptor instead.
+func (*ConditionValue) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConditionValue) GetValue() isConditionValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ConditionValue) GetNumberValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*ConditionValue_NumberValue); ok {
+			return x.NumberValue
+		}
+	}
+	return 0
+}
+
+func (x *ConditionValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*ConditionValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *ConditionValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*ConditionValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *ConditionValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*ConditionValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *ConditionValue) GetValueType() string {
+	if x != nil {
+		return x.ValueType
+	}
+	return ""
+}
+
+type isConditionValue_Value interface {
+	isConditionValue_Value()
+}
+
+type ConditionValue_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,1,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type ConditionValue_StringValue struct {
+	StringValue string `protobuf:"bytes,2,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type ConditionValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,3,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type ConditionValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,4,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+func (*ConditionValue_NumberValue) isConditionValue_Value() {}
+
+func (*ConditionValue_StringValue) isConditionValue_Value() {}
+
+func (*ConditionValue_BoolValue) isConditionValue_Value() {}
+
+func (*ConditionValue_IntValue) isConditionValue_Value() {}
+
+// ConditionValueList message holds the list compared against by the in and nin operators
+type ConditionValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*ConditionValue      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	ElementType   string                 `protobuf:"bytes,2,opt,name=elementType,proto3" json:"elementType,omitempty"` // Go element type of the original slice, empty for []interface{}
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionValueList) Reset() {
+	*x = ConditionValueList{}
+	mi := &file_objectweaver_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionValueList) ProtoMessage() {}
+
+func (x *ConditionValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionValueList.ProtoReflect.Descriptor instead.
+func (*ConditionValueList) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConditionValueList) GetValues() []*ConditionValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ConditionValueList) GetElementType() string {
+	if x != nil {
+		return x.ElementType
+	}
+	return ""
+}
+
 // ScoringCriteria message
 type ScoringCriteria struct {
 	state             protoimpl.MessageState       `protogen:"open.v1"`
@@ -1028,7 +1260,7 @@ type ScoringCriteria struct {
 
 func (x *ScoringCriteria) Reset() {
 	*x = ScoringCriteria{}
-	mi := &file_objectweaver_proto_msgTypes[12]
+	mi := &file_objectweaver_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1040,7 +1272,7 @@ func (x *ScoringCriteria) String() string {
 func (*ScoringCriteria) ProtoMessage() {}
 
 func (x *ScoringCriteria) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[12]
+	mi := &file_objectweaver_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1053,7 +1285,7 @@ func (x *ScoringCriteria) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoringCriteria.ProtoReflect.Descriptor instead.
 func (*ScoringCriteria) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{12}
+	return file_objectweaver_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ScoringCriteria) GetDimensions() map[string]*ScoringDimension {
@@ -1090,7 +1322,7 @@ type ScoringDimension struct {
 
 func (x *ScoringDimension) Reset() {
 	*x = ScoringDimension{}
-	mi := &file_objectweaver_proto_msgTypes[13]
+	mi := &file_objectweaver_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1102,7 +1334,7 @@ func (x *ScoringDimension) String() string {
 func (*ScoringDimension) ProtoMessage() {}
 
 func (x *ScoringDimension) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[13]
+	mi := &file_objectweaver_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,7 +1347,7 @@ func (x *ScoringDimension) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoringDimension.ProtoReflect.Descriptor instead.
 func (*ScoringDimension) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{13}
+	return file_objectweaver_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ScoringDimension) GetDescription() string {
@@ -1157,7 +1389,7 @@ type ScoreScale struct {
 
 func (x *ScoreScale) Reset() {
 	*x = ScoreScale{}
-	mi := &file_objectweaver_proto_msgTypes[14]
+	mi := &file_objectweaver_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1169,7 +1401,7 @@ func (x *ScoreScale) String() string {
 func (*ScoreScale) ProtoMessage() {}
 
 func (x *ScoreScale) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[14]
+	mi := &file_objectweaver_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,7 +1414,7 @@ func (x *ScoreScale) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoreScale.ProtoReflect.Descriptor instead.
 func (*ScoreScale) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{14}
+	return file_objectweaver_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ScoreScale) GetMin() int32 {
@@ -1213,7 +1445,7 @@ type RecursiveLoop struct {
 
 func (x *RecursiveLoop) Reset() {
 	*x = RecursiveLoop{}
-	mi := &file_objectweaver_proto_msgTypes[15]
+	mi := &file_objectweaver_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1225,7 +1457,7 @@ func (x *RecursiveLoop) String() string {
 func (*RecursiveLoop) ProtoMessage() {}
 
 func (x *RecursiveLoop) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[15]
+	mi := &file_objectweaver_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1238,7 +1470,7 @@ func (x *RecursiveLoop) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecursiveLoop.ProtoReflect.Descriptor instead.
 func (*RecursiveLoop) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{15}
+	return file_objectweaver_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RecursiveLoop) GetMaxIterations() int32 {
@@ -1287,7 +1519,7 @@ type EpistemicValidation struct {
 
 func (x *EpistemicValidation) Reset() {
 	*x = EpistemicValidation{}
-	mi := &file_objectweaver_proto_msgTypes[16]
+	mi := &file_objectweaver_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1299,7 +1531,7 @@ func (x *EpistemicValidation) String() string {
 func (*EpistemicValidation) ProtoMessage() {}
 
 func (x *EpistemicValidation) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[16]
+	mi := &file_objectweaver_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1312,7 +1544,7 @@ func (x *EpistemicValidation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EpistemicValidation.ProtoReflect.Descriptor instead.
 func (*EpistemicValidation) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{16}
+	return file_objectweaver_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *EpistemicValidation) GetActive() bool {
@@ -1339,7 +1571,7 @@ type ModelConfig struct {
 	Stream              bool                   `protobuf:"varint,5,opt,name=stream,proto3" json:"stream,omitempty"`
 	Stop                []string               `protobuf:"bytes,6,rep,name=stop,proto3" json:"stop,omitempty"`
 	PresencePenalty     float32                `protobuf:"fixed32,7,opt,name=presencePenalty,proto3" json:"presencePenalty,omitempty"`
-	Seed                int32                  `protobuf:"varint,8,opt,name=seed,proto3" json:"seed,omitempty"`
+	Seed                *int32                 `protobuf:"varint,8,opt,name=seed,proto3,oneof" json:"seed,omitempty"` // optional so that a seed of 0 is kept
 	FrequencyPenalty    float32                `protobuf:"fixed32,9,opt,name=frequencyPenalty,proto3" json:"frequencyPenalty,omitempty"`
 	LogitBias           map[string]int32       `protobuf:"bytes,10,rep,name=logitBias,proto3" json:"logitBias,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	LogProbs            bool                   `protobuf:"varint,11,opt,name=logProbs,proto3" json:"logProbs,omitempty"`
@@ -1355,7 +1587,7 @@ type ModelConfig struct {
 
 func (x *ModelConfig) Reset() {
 	*x = ModelConfig{}
-	mi := &file_objectweaver_proto_msgTypes[17]
+	mi := &file_objectweaver_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1367,7 +1599,7 @@ func (x *ModelConfig) String() string {
 func (*ModelConfig) ProtoMessage() {}
 
 func (x *ModelConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[17]
+	mi := &file_objectweaver_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,7 +1612,7 @@ func (x *ModelConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelConfig.ProtoReflect.Descriptor instead.
 func (*ModelConfig) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{17}
+	return file_objectweaver_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ModelConfig) GetMaxCompletionTokens() int32 {
@@ -1433,8 +1665,8 @@ func (x *ModelConfig) GetPresencePenalty() float32 {
 }
 
 func (x *ModelConfig) GetSeed() int32 {
-	if x != nil {
-		return x.Seed
+	if x != nil && x.Seed != nil {
+		return *x.Seed
 	}
 	return 0
 }
@@ -1515,7 +1747,7 @@ type Choice struct {
 
 func (x *Choice) Reset() {
 	*x = Choice{}
-	mi := &file_objectweaver_proto_msgTypes[18]
+	mi := &file_objectweaver_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1527,7 +1759,7 @@ func (x *Choice) String() string {
 func (*Choice) ProtoMessage() {}
 
 func (x *Choice) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[18]
+	mi := &file_objectweaver_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1540,7 +1772,7 @@ func (x *Choice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Choice.ProtoReflect.Descriptor instead.
 func (*Choice) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{18}
+	return file_objectweaver_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Choice) GetScore() int32 {
@@ -1584,7 +1816,7 @@ type FieldMetadata struct {
 
 func (x *FieldMetadata) Reset() {
 	*x = FieldMetadata{}
-	mi := &file_objectweaver_proto_msgTypes[19]
+	mi := &file_objectweaver_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1596,7 +1828,7 @@ func (x *FieldMetadata) String() string {
 func (*FieldMetadata) ProtoMessage() {}
 
 func (x *FieldMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[19]
+	mi := &file_objectweaver_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1609,7 +1841,7 @@ func (x *FieldMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FieldMetadata.ProtoReflect.Descriptor instead.
 func (*FieldMetadata) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{19}
+	return file_objectweaver_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *FieldMetadata) GetTokensUsed() int32 {
@@ -1651,7 +1883,7 @@ type DetailedField struct {
 
 func (x *DetailedField) Reset() {
 	*x = DetailedField{}
-	mi := &file_objectweaver_proto_msgTypes[20]
+	mi := &file_objectweaver_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1663,7 +1895,7 @@ func (x *DetailedField) String() string {
 func (*DetailedField) ProtoMessage() {}
 
 func (x *DetailedField) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[20]
+	mi := &file_objectweaver_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1676,7 +1908,7 @@ func (x *DetailedField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailedField.ProtoReflect.Descriptor instead.
 func (*DetailedField) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{20}
+	return file_objectweaver_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DetailedField) GetValue() *structpb.Struct {
@@ -1693,30 +1925,36 @@ func (x *DetailedField) GetMetadata() *FieldMetadata {
 	return nil
 }
 
-// RequestBody message for the GenerateObject RPC
-type RequestBody struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prompt        string                 `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`         // Corresponds to Go's Prompt field
-	Definition    *Definition            `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"` // Corresponds to Go's Definition field
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ComplexSystem message
+type ComplexSystem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                               // Corresponds to Go's Name field
+	Description         string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                 // Corresponds to Go's Description field
+	PrimarySystemPrompt string                 `protobuf:"bytes,3,opt,name=primarySystemPrompt,proto3" json:"primarySystemPrompt,omitempty"` // Corresponds to Go's PrimarySystemPrompt field
+	RootSchema          *Definition            `protobuf:"bytes,4,opt,name=rootSchema,proto3" json:"rootSchema,omitempty"`                   // Corresponds to Go's RootSchema field
+	MainThread          *MainThreadConfig      `protobuf:"bytes,5,opt,name=mainThread,proto3" json:"mainThread,omitempty"`                   // Corresponds to Go's MainThread field
+	Memory              *MemoryConfig          `protobuf:"bytes,6,opt,name=memory,proto3" json:"memory,omitempty"`                           // Corresponds to Go's Memory field
+	LazyConfig          *LazyConfigMode        `protobuf:"bytes,7,opt,name=lazyConfig,proto3" json:"lazyConfig,omitempty"`                   // Corresponds to Go's LazyConfig field
+	Version             string                 `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`                         // Corresponds to Go's Version field
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *RequestBody) Reset() {
-	*x = RequestBody{}
-	mi := &file_objectweaver_proto_msgTypes[21]
+func (x *ComplexSystem) Reset() {
+	*x = ComplexSystem{}
+	mi := &file_objectweaver_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestBody) String() string {
+func (x *ComplexSystem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestBody) ProtoMessage() {}
+func (*ComplexSystem) ProtoMessage() {}
 
-func (x *RequestBody) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[21]
+func (x *ComplexSystem) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1727,40 +1965,1198 @@ func (x *RequestBody) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestBody.ProtoReflect.Descriptor instead.
-func (*RequestBody) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ComplexSystem.ProtoReflect.Descriptor instead.
+func (*ComplexSystem) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RequestBody) GetPrompt() string {
+func (x *ComplexSystem) GetName() string {
 	if x != nil {
-		return x.Prompt
+		return x.Name
 	}
 	return ""
 }
 
-func (x *RequestBody) GetDefinition() *Definition {
+func (x *ComplexSystem) GetDescription() string {
 	if x != nil {
-		return x.Definition
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-// Updated Response message for the GenerateObject RPC
-type Response struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	Data          *structpb.Struct          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // Use Struct to hold a dynamic map<string, any>
-	UsdCost       float64                   `protobuf:"fixed64,2,opt,name=usdCost,proto3" json:"usdCost,omitempty"`
-	DetailedData  map[string]*DetailedField `protobuf:"bytes,3,rep,name=detailedData,proto3" json:"detailedData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Detailed data with metadata per field
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ComplexSystem) GetPrimarySystemPrompt() string {
+	if x != nil {
+		return x.PrimarySystemPrompt
+	}
+	return ""
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_objectweaver_proto_msgTypes[22]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ComplexSystem) GetRootSchema() *Definition {
+	if x != nil {
+		return x.RootSchema
+	}
+	return nil
+}
+
+func (x *ComplexSystem) GetMainThread() *MainThreadConfig {
+	if x != nil {
+		return x.MainThread
+	}
+	return nil
+}
+
+func (x *ComplexSystem) GetMemory() *MemoryConfig {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *ComplexSystem) GetLazyConfig() *LazyConfigMode {
+	if x != nil {
+		return x.LazyConfig
+	}
+	return nil
+}
+
+func (x *ComplexSystem) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// MainThreadConfig message
+type MainThreadConfig struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	MonitoringStrategy string                 `protobuf:"bytes,1,opt,name=monitoringStrategy,proto3" json:"monitoringStrategy,omitempty"` // MonitoringStrategy as string
+	InterventionRules  []*InterventionRule    `protobuf:"bytes,2,rep,name=interventionRules,proto3" json:"interventionRules,omitempty"`
+	HierarchicalDepth  int32                  `protobuf:"varint,3,opt,name=hierarchicalDepth,proto3" json:"hierarchicalDepth,omitempty"`
+	ParentThread       *MainThreadConfig      `protobuf:"bytes,4,opt,name=parentThread,proto3" json:"parentThread,omitempty"`
+	AggregationPrompt  string                 `protobuf:"bytes,5,opt,name=aggregationPrompt,proto3" json:"aggregationPrompt,omitempty"`
+	Model              string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MainThreadConfig) Reset() {
+	*x = MainThreadConfig{}
+	mi := &file_objectweaver_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MainThreadConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MainThreadConfig) ProtoMessage() {}
+
+func (x *MainThreadConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MainThreadConfig.ProtoReflect.Descriptor instead.
+func (*MainThreadConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MainThreadConfig) GetMonitoringStrategy() string {
+	if x != nil {
+		return x.MonitoringStrategy
+	}
+	return ""
+}
+
+func (x *MainThreadConfig) GetInterventionRules() []*InterventionRule {
+	if x != nil {
+		return x.InterventionRules
+	}
+	return nil
+}
+
+func (x *MainThreadConfig) GetHierarchicalDepth() int32 {
+	if x != nil {
+		return x.HierarchicalDepth
+	}
+	return 0
+}
+
+func (x *MainThreadConfig) GetParentThread() *MainThreadConfig {
+	if x != nil {
+		return x.ParentThread
+	}
+	return nil
+}
+
+func (x *MainThreadConfig) GetAggregationPrompt() string {
+	if x != nil {
+		return x.AggregationPrompt
+	}
+	return ""
+}
+
+func (x *MainThreadConfig) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// InterventionRule message
+type InterventionRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Trigger       *InterventionTrigger   `protobuf:"bytes,2,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Action        *InterventionAction    `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterventionRule) Reset() {
+	*x = InterventionRule{}
+	mi := &file_objectweaver_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterventionRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterventionRule) ProtoMessage() {}
+
+func (x *InterventionRule) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterventionRule.ProtoReflect.Descriptor instead.
+func (*InterventionRule) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InterventionRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterventionRule) GetTrigger() *InterventionTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *InterventionRule) GetAction() *InterventionAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *InterventionRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// InterventionTrigger message
+type InterventionTrigger struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // TriggerType as string
+	ScoreThresholds map[string]float64     `protobuf:"bytes,2,rep,name=scoreThresholds,proto3" json:"scoreThresholds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	PathDepth       *int32                 `protobuf:"varint,3,opt,name=pathDepth,proto3,oneof" json:"pathDepth,omitempty"`
+	CustomCondition string                 `protobuf:"bytes,4,opt,name=customCondition,proto3" json:"customCondition,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *InterventionTrigger) Reset() {
+	*x = InterventionTrigger{}
+	mi := &file_objectweaver_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterventionTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterventionTrigger) ProtoMessage() {}
+
+func (x *InterventionTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterventionTrigger.ProtoReflect.Descriptor instead.
+func (*InterventionTrigger) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InterventionTrigger) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *InterventionTrigger) GetScoreThresholds() map[string]float64 {
+	if x != nil {
+		return x.ScoreThresholds
+	}
+	return nil
+}
+
+func (x *InterventionTrigger) GetPathDepth() int32 {
+	if x != nil && x.PathDepth != nil {
+		return *x.PathDepth
+	}
+	return 0
+}
+
+func (x *InterventionTrigger) GetCustomCondition() string {
+	if x != nil {
+		return x.CustomCondition
+	}
+	return ""
+}
+
+// InterventionAction message
+type InterventionAction struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Type              string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // ActionType as string
+	OverridePrompt    *string                `protobuf:"bytes,2,opt,name=overridePrompt,proto3,oneof" json:"overridePrompt,omitempty"`
+	FetchMemory       bool                   `protobuf:"varint,3,opt,name=fetchMemory,proto3" json:"fetchMemory,omitempty"`
+	ResetToCheckpoint *string                `protobuf:"bytes,4,opt,name=resetToCheckpoint,proto3,oneof" json:"resetToCheckpoint,omitempty"`
+	CustomHandler     string                 `protobuf:"bytes,5,opt,name=customHandler,proto3" json:"customHandler,omitempty"`
+	ModifyDefinition  *Definition            `protobuf:"bytes,6,opt,name=modifyDefinition,proto3" json:"modifyDefinition,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InterventionAction) Reset() {
+	*x = InterventionAction{}
+	mi := &file_objectweaver_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterventionAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterventionAction) ProtoMessage() {}
+
+func (x *InterventionAction) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterventionAction.ProtoReflect.Descriptor instead.
+func (*InterventionAction) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *InterventionAction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *InterventionAction) GetOverridePrompt() string {
+	if x != nil && x.OverridePrompt != nil {
+		return *x.OverridePrompt
+	}
+	return ""
+}
+
+func (x *InterventionAction) GetFetchMemory() bool {
+	if x != nil {
+		return x.FetchMemory
+	}
+	return false
+}
+
+func (x *InterventionAction) GetResetToCheckpoint() string {
+	if x != nil && x.ResetToCheckpoint != nil {
+		return *x.ResetToCheckpoint
+	}
+	return ""
+}
+
+func (x *InterventionAction) GetCustomHandler() string {
+	if x != nil {
+		return x.CustomHandler
+	}
+	return ""
+}
+
+func (x *InterventionAction) GetModifyDefinition() *Definition {
+	if x != nil {
+		return x.ModifyDefinition
+	}
+	return nil
+}
+
+// MemoryConfig message
+type MemoryConfig struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	SearchStrategy    string                 `protobuf:"bytes,2,opt,name=searchStrategy,proto3" json:"searchStrategy,omitempty"` // SearchStrategy as string
+	MaxContextSize    int32                  `protobuf:"varint,3,opt,name=maxContextSize,proto3" json:"maxContextSize,omitempty"`
+	RetrievalTriggers []*MemoryTrigger       `protobuf:"bytes,4,rep,name=retrievalTriggers,proto3" json:"retrievalTriggers,omitempty"`
+	KnowledgeGraph    *KnowledgeGraphConfig  `protobuf:"bytes,5,opt,name=knowledgeGraph,proto3" json:"knowledgeGraph,omitempty"`
+	StorageBackend    string                 `protobuf:"bytes,6,opt,name=storageBackend,proto3" json:"storageBackend,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MemoryConfig) Reset() {
+	*x = MemoryConfig{}
+	mi := &file_objectweaver_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryConfig) ProtoMessage() {}
+
+func (x *MemoryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryConfig.ProtoReflect.Descriptor instead.
+func (*MemoryConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MemoryConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *MemoryConfig) GetSearchStrategy() string {
+	if x != nil {
+		return x.SearchStrategy
+	}
+	return ""
+}
+
+func (x *MemoryConfig) GetMaxContextSize() int32 {
+	if x != nil {
+		return x.MaxContextSize
+	}
+	return 0
+}
+
+func (x *MemoryConfig) GetRetrievalTriggers() []*MemoryTrigger {
+	if x != nil {
+		return x.RetrievalTriggers
+	}
+	return nil
+}
+
+func (x *MemoryConfig) GetKnowledgeGraph() *KnowledgeGraphConfig {
+	if x != nil {
+		return x.KnowledgeGraph
+	}
+	return nil
+}
+
+func (x *MemoryConfig) GetStorageBackend() string {
+	if x != nil {
+		return x.StorageBackend
+	}
+	return ""
+}
+
+// MemoryTrigger message
+type MemoryTrigger struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DecisionPoint  string                 `protobuf:"bytes,1,opt,name=decisionPoint,proto3" json:"decisionPoint,omitempty"`
+	ScoreThreshold *Condition             `protobuf:"bytes,2,opt,name=scoreThreshold,proto3" json:"scoreThreshold,omitempty"`
+	AlwaysFetch    bool                   `protobuf:"varint,3,opt,name=alwaysFetch,proto3" json:"alwaysFetch,omitempty"`
+	QueryTemplate  string                 `protobuf:"bytes,4,opt,name=queryTemplate,proto3" json:"queryTemplate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MemoryTrigger) Reset() {
+	*x = MemoryTrigger{}
+	mi := &file_objectweaver_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryTrigger) ProtoMessage() {}
+
+func (x *MemoryTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryTrigger.ProtoReflect.Descriptor instead.
+func (*MemoryTrigger) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MemoryTrigger) GetDecisionPoint() string {
+	if x != nil {
+		return x.DecisionPoint
+	}
+	return ""
+}
+
+func (x *MemoryTrigger) GetScoreThreshold() *Condition {
+	if x != nil {
+		return x.ScoreThreshold
+	}
+	return nil
+}
+
+func (x *MemoryTrigger) GetAlwaysFetch() bool {
+	if x != nil {
+		return x.AlwaysFetch
+	}
+	return false
+}
+
+func (x *MemoryTrigger) GetQueryTemplate() string {
+	if x != nil {
+		return x.QueryTemplate
+	}
+	return ""
+}
+
+// KnowledgeGraphConfig message
+type KnowledgeGraphConfig struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	NodeTypes         []string               `protobuf:"bytes,2,rep,name=nodeTypes,proto3" json:"nodeTypes,omitempty"`
+	RelationshipTypes []string               `protobuf:"bytes,3,rep,name=relationshipTypes,proto3" json:"relationshipTypes,omitempty"`
+	TraversalDepth    int32                  `protobuf:"varint,4,opt,name=traversalDepth,proto3" json:"traversalDepth,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *KnowledgeGraphConfig) Reset() {
+	*x = KnowledgeGraphConfig{}
+	mi := &file_objectweaver_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeGraphConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeGraphConfig) ProtoMessage() {}
+
+func (x *KnowledgeGraphConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeGraphConfig.ProtoReflect.Descriptor instead.
+func (*KnowledgeGraphConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *KnowledgeGraphConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *KnowledgeGraphConfig) GetNodeTypes() []string {
+	if x != nil {
+		return x.NodeTypes
+	}
+	return nil
+}
+
+func (x *KnowledgeGraphConfig) GetRelationshipTypes() []string {
+	if x != nil {
+		return x.RelationshipTypes
+	}
+	return nil
+}
+
+func (x *KnowledgeGraphConfig) GetTraversalDepth() int32 {
+	if x != nil {
+		return x.TraversalDepth
+	}
+	return 0
+}
+
+// LazyConfigMode message
+type LazyConfigMode struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Enabled         bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	PatternLibrary  *PatternLibraryConfig  `protobuf:"bytes,2,opt,name=patternLibrary,proto3" json:"patternLibrary,omitempty"`
+	CreationPrompt  string                 `protobuf:"bytes,3,opt,name=creationPrompt,proto3" json:"creationPrompt,omitempty"`
+	SelfImprovement *SelfImprovementConfig `protobuf:"bytes,4,opt,name=selfImprovement,proto3" json:"selfImprovement,omitempty"`
+	DeploymentMode  string                 `protobuf:"bytes,5,opt,name=deploymentMode,proto3" json:"deploymentMode,omitempty"` // DeploymentMode as string
+	MaxComplexity   int32                  `protobuf:"varint,6,opt,name=maxComplexity,proto3" json:"maxComplexity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LazyConfigMode) Reset() {
+	*x = LazyConfigMode{}
+	mi := &file_objectweaver_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LazyConfigMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LazyConfigMode) ProtoMessage() {}
+
+func (x *LazyConfigMode) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LazyConfigMode.ProtoReflect.Descriptor instead.
+func (*LazyConfigMode) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LazyConfigMode) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *LazyConfigMode) GetPatternLibrary() *PatternLibraryConfig {
+	if x != nil {
+		return x.PatternLibrary
+	}
+	return nil
+}
+
+func (x *LazyConfigMode) GetCreationPrompt() string {
+	if x != nil {
+		return x.CreationPrompt
+	}
+	return ""
+}
+
+func (x *LazyConfigMode) GetSelfImprovement() *SelfImprovementConfig {
+	if x != nil {
+		return x.SelfImprovement
+	}
+	return nil
+}
+
+func (x *LazyConfigMode) GetDeploymentMode() string {
+	if x != nil {
+		return x.DeploymentMode
+	}
+	return ""
+}
+
+func (x *LazyConfigMode) GetMaxComplexity() int32 {
+	if x != nil {
+		return x.MaxComplexity
+	}
+	return 0
+}
+
+// PatternLibraryConfig message
+type PatternLibraryConfig struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SourceUrl      string                 `protobuf:"bytes,1,opt,name=sourceUrl,proto3" json:"sourceUrl,omitempty"`
+	SelectionModel string                 `protobuf:"bytes,2,opt,name=selectionModel,proto3" json:"selectionModel,omitempty"`
+	Tags           []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomPatterns []*DecisionPattern     `protobuf:"bytes,4,rep,name=customPatterns,proto3" json:"customPatterns,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PatternLibraryConfig) Reset() {
+	*x = PatternLibraryConfig{}
+	mi := &file_objectweaver_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatternLibraryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternLibraryConfig) ProtoMessage() {}
+
+func (x *PatternLibraryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternLibraryConfig.ProtoReflect.Descriptor instead.
+func (*PatternLibraryConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PatternLibraryConfig) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *PatternLibraryConfig) GetSelectionModel() string {
+	if x != nil {
+		return x.SelectionModel
+	}
+	return ""
+}
+
+func (x *PatternLibraryConfig) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PatternLibraryConfig) GetCustomPatterns() []*DecisionPattern {
+	if x != nil {
+		return x.CustomPatterns
+	}
+	return nil
+}
+
+// DecisionPattern message
+type DecisionPattern struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Tags        []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Types that are valid to be assigned to Schema:
+	//
+	//	*DecisionPattern_DefinitionSchema
+	//	*DecisionPattern_SystemSchema
+	//	*DecisionPattern_RawSchema
+	Schema             isDecisionPattern_Schema `protobuf_oneof:"schema"`
+	UseCases           []string                 `protobuf:"bytes,6,rep,name=useCases,proto3" json:"useCases,omitempty"`
+	PerformanceMetrics map[string]float64       `protobuf:"bytes,7,rep,name=performanceMetrics,proto3" json:"performanceMetrics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	CreatedAt          string                   `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Version            string                   `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DecisionPattern) Reset() {
+	*x = DecisionPattern{}
+	mi := &file_objectweaver_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecisionPattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecisionPattern) ProtoMessage() {}
+
+func (x *DecisionPattern) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecisionPattern.ProtoReflect.Descriptor instead.
+func (*DecisionPattern) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DecisionPattern) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecisionPattern) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DecisionPattern) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DecisionPattern) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetSchema() isDecisionPattern_Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetDefinitionSchema() *Definition {
+	if x != nil {
+		if x, ok := x.Schema.(*DecisionPattern_DefinitionSchema); ok {
+			return x.DefinitionSchema
+		}
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetSystemSchema() *ComplexSystem {
+	if x != nil {
+		if x, ok := x.Schema.(*DecisionPattern_SystemSchema); ok {
+			return x.SystemSchema
+		}
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetRawSchema() *structpb.Value {
+	if x != nil {
+		if x, ok := x.Schema.(*DecisionPattern_RawSchema); ok {
+			return x.RawSchema
+		}
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetUseCases() []string {
+	if x != nil {
+		return x.UseCases
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetPerformanceMetrics() map[string]float64 {
+	if x != nil {
+		return x.PerformanceMetrics
+	}
+	return nil
+}
+
+func (x *DecisionPattern) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DecisionPattern) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type isDecisionPattern_Schema interface {
+	isDecisionPattern_Schema()
+}
+
+type DecisionPattern_DefinitionSchema struct {
+	DefinitionSchema *Definition `protobuf:"bytes,5,opt,name=definitionSchema,proto3,oneof"`
+}
+
+type DecisionPattern_SystemSchema struct {
+	SystemSchema *ComplexSystem `protobuf:"bytes,10,opt,name=systemSchema,proto3,oneof"`
+}
+
+type DecisionPattern_RawSchema struct {
+	RawSchema *structpb.Value `protobuf:"bytes,11,opt,name=rawSchema,proto3,oneof"`
+}
+
+func (*DecisionPattern_DefinitionSchema) isDecisionPattern_Schema() {}
+
+func (*DecisionPattern_SystemSchema) isDecisionPattern_Schema() {}
+
+func (*DecisionPattern_RawSchema) isDecisionPattern_Schema() {}
+
+// SelfImprovementConfig message
+type SelfImprovementConfig struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Enabled                bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	SaveSuccessfulPatterns bool                   `protobuf:"varint,2,opt,name=saveSuccessfulPatterns,proto3" json:"saveSuccessfulPatterns,omitempty"`
+	SuccessThreshold       float64                `protobuf:"fixed64,3,opt,name=successThreshold,proto3" json:"successThreshold,omitempty"`
+	RefinementStrategy     string                 `protobuf:"bytes,4,opt,name=refinementStrategy,proto3" json:"refinementStrategy,omitempty"` // RefinementStrategy as string
+	SafetyMechanisms       *SafetyConfig          `protobuf:"bytes,5,opt,name=safetyMechanisms,proto3" json:"safetyMechanisms,omitempty"`
+	LearningRate           float64                `protobuf:"fixed64,6,opt,name=learningRate,proto3" json:"learningRate,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SelfImprovementConfig) Reset() {
+	*x = SelfImprovementConfig{}
+	mi := &file_objectweaver_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfImprovementConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfImprovementConfig) ProtoMessage() {}
+
+func (x *SelfImprovementConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfImprovementConfig.ProtoReflect.Descriptor instead.
+func (*SelfImprovementConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SelfImprovementConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SelfImprovementConfig) GetSaveSuccessfulPatterns() bool {
+	if x != nil {
+		return x.SaveSuccessfulPatterns
+	}
+	return false
+}
+
+func (x *SelfImprovementConfig) GetSuccessThreshold() float64 {
+	if x != nil {
+		return x.SuccessThreshold
+	}
+	return 0
+}
+
+func (x *SelfImprovementConfig) GetRefinementStrategy() string {
+	if x != nil {
+		return x.RefinementStrategy
+	}
+	return ""
+}
+
+func (x *SelfImprovementConfig) GetSafetyMechanisms() *SafetyConfig {
+	if x != nil {
+		return x.SafetyMechanisms
+	}
+	return nil
+}
+
+func (x *SelfImprovementConfig) GetLearningRate() float64 {
+	if x != nil {
+		return x.LearningRate
+	}
+	return 0
+}
+
+// SafetyConfig message
+type SafetyConfig struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	RequireApproval     bool                   `protobuf:"varint,1,opt,name=requireApproval,proto3" json:"requireApproval,omitempty"`
+	RollbackOnFailure   bool                   `protobuf:"varint,2,opt,name=rollbackOnFailure,proto3" json:"rollbackOnFailure,omitempty"`
+	PerformanceBaseline map[string]float64     `protobuf:"bytes,3,rep,name=performanceBaseline,proto3" json:"performanceBaseline,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	MaxComplexity       int32                  `protobuf:"varint,4,opt,name=maxComplexity,proto3" json:"maxComplexity,omitempty"`
+	ValidationSet       []string               `protobuf:"bytes,5,rep,name=validationSet,proto3" json:"validationSet,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SafetyConfig) Reset() {
+	*x = SafetyConfig{}
+	mi := &file_objectweaver_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SafetyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SafetyConfig) ProtoMessage() {}
+
+func (x *SafetyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SafetyConfig.ProtoReflect.Descriptor instead.
+func (*SafetyConfig) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SafetyConfig) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+func (x *SafetyConfig) GetRollbackOnFailure() bool {
+	if x != nil {
+		return x.RollbackOnFailure
+	}
+	return false
+}
+
+func (x *SafetyConfig) GetPerformanceBaseline() map[string]float64 {
+	if x != nil {
+		return x.PerformanceBaseline
+	}
+	return nil
+}
+
+func (x *SafetyConfig) GetMaxComplexity() int32 {
+	if x != nil {
+		return x.MaxComplexity
+	}
+	return 0
+}
+
+func (x *SafetyConfig) GetValidationSet() []string {
+	if x != nil {
+		return x.ValidationSet
+	}
+	return nil
+}
+
+// RequestBody message for the GenerateObject RPC
+type RequestBody struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prompt        string                 `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`         // Corresponds to Go's Prompt field
+	Definition    *Definition            `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"` // Corresponds to Go's Definition field
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestBody) Reset() {
+	*x = RequestBody{}
+	mi := &file_objectweaver_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestBody) ProtoMessage() {}
+
+func (x *RequestBody) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestBody.ProtoReflect.Descriptor instead.
+func (*RequestBody) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RequestBody) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *RequestBody) GetDefinition() *Definition {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+// ComplexSystemRequestBody message for the GenerateComplexSystem RPC
+type ComplexSystemRequestBody struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prompt        string                 `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	ComplexSystem *ComplexSystem         `protobuf:"bytes,2,opt,name=complexSystem,proto3" json:"complexSystem,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplexSystemRequestBody) Reset() {
+	*x = ComplexSystemRequestBody{}
+	mi := &file_objectweaver_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplexSystemRequestBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplexSystemRequestBody) ProtoMessage() {}
+
+func (x *ComplexSystemRequestBody) ProtoReflect() protoreflect.Message {
+	mi := &file_objectweaver_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplexSystemRequestBody.ProtoReflect.Descriptor instead.
+func (*ComplexSystemRequestBody) Descriptor() ([]byte, []int) {
+	return file_objectweaver_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ComplexSystemRequestBody) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *ComplexSystemRequestBody) GetComplexSystem() *ComplexSystem {
+	if x != nil {
+		return x.ComplexSystem
+	}
+	return nil
+}
+
+// Updated Response message for the GenerateObject RPC
+type Response struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Data          *structpb.Struct          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // Use Struct to hold a dynamic map<string, any>
+	UsdCost       float64                   `protobuf:"fixed64,2,opt,name=usdCost,proto3" json:"usdCost,omitempty"`
+	DetailedData  map[string]*DetailedField `protobuf:"bytes,3,rep,name=detailedData,proto3" json:"detailedData,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Detailed data with metadata per field
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_objectweaver_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Response) String() string {
@@ -1770,7 +3166,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[22]
+	mi := &file_objectweaver_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1783,7 +3179,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{22}
+	return file_objectweaver_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *Response) GetData() *structpb.Struct {
@@ -1820,7 +3216,7 @@ type StreamingResponse struct {
 
 func (x *StreamingResponse) Reset() {
 	*x = StreamingResponse{}
-	mi := &file_objectweaver_proto_msgTypes[23]
+	mi := &file_objectweaver_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1832,7 +3228,7 @@ func (x *StreamingResponse) String() string {
 func (*StreamingResponse) ProtoMessage() {}
 
 func (x *StreamingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_objectweaver_proto_msgTypes[23]
+	mi := &file_objectweaver_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1845,7 +3241,7 @@ func (x *StreamingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamingResponse.ProtoReflect.Descriptor instead.
 func (*StreamingResponse) Descriptor() ([]byte, []int) {
-	return file_objectweaver_proto_rawDescGZIP(), []int{23}
+	return file_objectweaver_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *StreamingResponse) GetData() *structpb.Struct {
@@ -1881,7 +3277,7 @@ var File_objectweaver_proto protoreflect.FileDescriptor
 const file_objectweaver_proto_rawDesc = "" +
 	"\n" +
 	"\x12objectweaver.proto\x12\n" +
-	"jsonSchema\x1a\x1cgoogle/protobuf/struct.proto\"\xa1\t\n" +
+	"jsonSchema\x1a\x1cgoogle/protobuf/struct.proto\"\xcf\t\n" +
 	"\n" +
 	"Definition\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
@@ -1891,8 +3287,8 @@ const file_objectweaver_proto_rawDesc = "" +
 	"properties\x12,\n" +
 	"\x05items\x18\x04 \x01(\v2\x16.jsonSchema.DefinitionR\x05items\x12\x14\n" +
 	"\x05model\x18\x05 \x01(\tR\x05model\x12(\n" +
-	"\x0fprocessingOrder\x18\x06 \x03(\tR\x0fprocessingOrder\x12\"\n" +
-	"\fsystemPrompt\x18\a \x01(\tR\fsystemPrompt\x12+\n" +
+	"\x0fprocessingOrder\x18\x06 \x03(\tR\x0fprocessingOrder\x12'\n" +
+	"\fsystemPrompt\x18\a \x01(\tH\x00R\fsystemPrompt\x88\x01\x01\x12+\n" +
 	"\x03req\x18\b \x01(\v2\x19.jsonSchema.RequestFormatR\x03req\x123\n" +
 	"\vnarrowFocus\x18\t \x01(\v2\x11.jsonSchema.FocusR\vnarrowFocus\x12\"\n" +
 	"\fselectFields\x18\n" +
@@ -1902,8 +3298,8 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\fspeechToText\x18\r \x01(\v2\x18.jsonSchema.SpeechToTextR\fspeechToText\x12'\n" +
 	"\x05image\x18\x0e \x01(\v2\x11.jsonSchema.ImageR\x05image\x123\n" +
 	"\tsendImage\x18\x0f \x01(\v2\x15.jsonSchema.SendImageR\tsendImage\x12\x16\n" +
-	"\x06stream\x18\x10 \x01(\bR\x06stream\x12&\n" +
-	"\x0eoverridePrompt\x18\x11 \x01(\tR\x0eoverridePrompt\x12\x1a\n" +
+	"\x06stream\x18\x10 \x01(\bR\x06stream\x12+\n" +
+	"\x0eoverridePrompt\x18\x11 \x01(\tH\x01R\x0eoverridePrompt\x88\x01\x01\x12\x1a\n" +
 	"\bpriority\x18\x12 \x01(\x05R\bpriority\x12?\n" +
 	"\rdecisionPoint\x18\x13 \x01(\v2\x19.jsonSchema.DecisionPointR\rdecisionPoint\x12E\n" +
 	"\x0fscoringCriteria\x18\x14 \x01(\v2\x1b.jsonSchema.ScoringCriteriaR\x0fscoringCriteria\x12?\n" +
@@ -1912,12 +3308,14 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\vmodelConfig\x18\x17 \x01(\v2\x17.jsonSchema.ModelConfigR\vmodelConfig\x1aU\n" +
 	"\x0fPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
-	"\x05value\x18\x02 \x01(\v2\x16.jsonSchema.DefinitionR\x05value:\x028\x01\"x\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.jsonSchema.DefinitionR\x05value:\x028\x01B\x0f\n" +
+	"\r_systemPromptB\x11\n" +
+	"\x0f_overridePrompt\"x\n" +
 	"\fTextToSpeech\x12\x14\n" +
 	"\x05model\x18\x01 \x01(\tR\x05model\x12$\n" +
 	"\rstringToAudio\x18\x02 \x01(\tR\rstringToAudio\x12\x16\n" +
 	"\x06format\x18\x03 \x01(\tR\x06format\x12\x14\n" +
-	"\x05voice\x18\x04 \x01(\tR\x05voice\"\xc2\x01\n" +
+	"\x05voice\x18\x04 \x01(\tR\x05voice\"\xa5\x02\n" +
 	"\fSpeechToText\x12\x14\n" +
 	"\x05model\x18\x01 \x01(\tR\x05model\x12,\n" +
 	"\x11audioToTranscribe\x18\x02 \x01(\fR\x11audioToTranscribe\x12\x1a\n" +
@@ -1926,7 +3324,9 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\btoString\x18\x05 \x01(\bR\btoString\x12\x1e\n" +
 	"\n" +
 	"toCaptions\x18\x06 \x01(\bR\n" +
-	"toCaptions\"1\n" +
+	"toCaptions\x12*\n" +
+	"\x10chunkingStrategy\x18\a \x01(\tR\x10chunkingStrategy\x125\n" +
+	"\textraBody\x18\b \x01(\v2\x17.google.protobuf.StructR\textraBody\"1\n" +
 	"\x05Image\x12\x14\n" +
 	"\x05model\x18\x01 \x01(\tR\x05model\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\tR\x04size\";\n" +
@@ -1966,16 +3366,31 @@ const file_objectweaver_proto_rawDesc = "" +
 	"conditions\x12,\n" +
 	"\x05logic\x18\x03 \x01(\v2\x16.jsonSchema.DefinitionR\x05logic\x12*\n" +
 	"\x04then\x18\x04 \x01(\v2\x16.jsonSchema.DefinitionR\x04then\x12\x1a\n" +
-	"\bpriority\x18\x05 \x01(\x05R\bpriority\"\xcf\x01\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\"\xcd\x02\n" +
 	"\tCondition\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
 	"\boperator\x18\x02 \x01(\tR\boperator\x12#\n" +
 	"\fnumber_value\x18\x03 \x01(\x01H\x00R\vnumberValue\x12#\n" +
 	"\fstring_value\x18\x04 \x01(\tH\x00R\vstringValue\x12\x1f\n" +
 	"\n" +
-	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12\x1c\n" +
-	"\tfieldPath\x18\x06 \x01(\tR\tfieldPathB\a\n" +
-	"\x05value\"\x93\x02\n" +
+	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\a \x01(\x03H\x00R\bintValue\x12?\n" +
+	"\n" +
+	"list_value\x18\b \x01(\v2\x1e.jsonSchema.ConditionValueListH\x00R\tlistValue\x12\x1c\n" +
+	"\tfieldPath\x18\x06 \x01(\tR\tfieldPath\x12\x1c\n" +
+	"\tvalueType\x18\t \x01(\tR\tvalueTypeB\a\n" +
+	"\x05value\"\xc1\x01\n" +
+	"\x0eConditionValue\x12#\n" +
+	"\fnumber_value\x18\x01 \x01(\x01H\x00R\vnumberValue\x12#\n" +
+	"\fstring_value\x18\x02 \x01(\tH\x00R\vstringValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x03 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x04 \x01(\x03H\x00R\bintValue\x12\x1c\n" +
+	"\tvalueType\x18\x05 \x01(\tR\tvalueTypeB\a\n" +
+	"\x05value\"j\n" +
+	"\x12ConditionValueList\x122\n" +
+	"\x06values\x18\x01 \x03(\v2\x1a.jsonSchema.ConditionValueR\x06values\x12 \n" +
+	"\velementType\x18\x02 \x01(\tR\velementType\"\x93\x02\n" +
 	"\x0fScoringCriteria\x12K\n" +
 	"\n" +
 	"dimensions\x18\x01 \x03(\v2+.jsonSchema.ScoringCriteria.DimensionsEntryR\n" +
@@ -2002,7 +3417,7 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\x17includePreviousAttempts\x18\x05 \x01(\bR\x17includePreviousAttempts\"E\n" +
 	"\x13EpistemicValidation\x12\x16\n" +
 	"\x06active\x18\x01 \x01(\bR\x06active\x12\x16\n" +
-	"\x06judges\x18\x02 \x01(\x05R\x06judges\"\xf8\x05\n" +
+	"\x06judges\x18\x02 \x01(\x05R\x06judges\"\x86\x06\n" +
 	"\vModelConfig\x120\n" +
 	"\x13maxCompletionTokens\x18\x01 \x01(\x05R\x13maxCompletionTokens\x12 \n" +
 	"\vtemperature\x18\x02 \x01(\x02R\vtemperature\x12\x12\n" +
@@ -2010,8 +3425,8 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\x01n\x18\x04 \x01(\x05R\x01n\x12\x16\n" +
 	"\x06stream\x18\x05 \x01(\bR\x06stream\x12\x12\n" +
 	"\x04stop\x18\x06 \x03(\tR\x04stop\x12(\n" +
-	"\x0fpresencePenalty\x18\a \x01(\x02R\x0fpresencePenalty\x12\x12\n" +
-	"\x04seed\x18\b \x01(\x05R\x04seed\x12*\n" +
+	"\x0fpresencePenalty\x18\a \x01(\x02R\x0fpresencePenalty\x12\x17\n" +
+	"\x04seed\x18\b \x01(\x05H\x00R\x04seed\x88\x01\x01\x12*\n" +
 	"\x10frequencyPenalty\x18\t \x01(\x02R\x10frequencyPenalty\x12D\n" +
 	"\tlogitBias\x18\n" +
 	" \x03(\v2&.jsonSchema.ModelConfig.LogitBiasEntryR\tlogitBias\x12\x1a\n" +
@@ -2027,7 +3442,8 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_seed\"\x8b\x01\n" +
 	"\x06Choice\x12\x14\n" +
 	"\x05score\x18\x01 \x01(\x05R\x05score\x12\x1e\n" +
 	"\n" +
@@ -2044,12 +3460,123 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\achoices\x18\x04 \x03(\v2\x12.jsonSchema.ChoiceR\achoices\"u\n" +
 	"\rDetailedField\x12-\n" +
 	"\x05value\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x05value\x125\n" +
-	"\bmetadata\x18\x02 \x01(\v2\x19.jsonSchema.FieldMetadataR\bmetadata\"]\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x19.jsonSchema.FieldMetadataR\bmetadata\"\xf5\x02\n" +
+	"\rComplexSystem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x120\n" +
+	"\x13primarySystemPrompt\x18\x03 \x01(\tR\x13primarySystemPrompt\x126\n" +
+	"\n" +
+	"rootSchema\x18\x04 \x01(\v2\x16.jsonSchema.DefinitionR\n" +
+	"rootSchema\x12<\n" +
+	"\n" +
+	"mainThread\x18\x05 \x01(\v2\x1c.jsonSchema.MainThreadConfigR\n" +
+	"mainThread\x120\n" +
+	"\x06memory\x18\x06 \x01(\v2\x18.jsonSchema.MemoryConfigR\x06memory\x12:\n" +
+	"\n" +
+	"lazyConfig\x18\a \x01(\v2\x1a.jsonSchema.LazyConfigModeR\n" +
+	"lazyConfig\x12\x18\n" +
+	"\aversion\x18\b \x01(\tR\aversion\"\xc2\x02\n" +
+	"\x10MainThreadConfig\x12.\n" +
+	"\x12monitoringStrategy\x18\x01 \x01(\tR\x12monitoringStrategy\x12J\n" +
+	"\x11interventionRules\x18\x02 \x03(\v2\x1c.jsonSchema.InterventionRuleR\x11interventionRules\x12,\n" +
+	"\x11hierarchicalDepth\x18\x03 \x01(\x05R\x11hierarchicalDepth\x12@\n" +
+	"\fparentThread\x18\x04 \x01(\v2\x1c.jsonSchema.MainThreadConfigR\fparentThread\x12,\n" +
+	"\x11aggregationPrompt\x18\x05 \x01(\tR\x11aggregationPrompt\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\"\xb5\x01\n" +
+	"\x10InterventionRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\atrigger\x18\x02 \x01(\v2\x1f.jsonSchema.InterventionTriggerR\atrigger\x126\n" +
+	"\x06action\x18\x03 \x01(\v2\x1e.jsonSchema.InterventionActionR\x06action\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\"\xa8\x02\n" +
+	"\x13InterventionTrigger\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12^\n" +
+	"\x0fscoreThresholds\x18\x02 \x03(\v24.jsonSchema.InterventionTrigger.ScoreThresholdsEntryR\x0fscoreThresholds\x12!\n" +
+	"\tpathDepth\x18\x03 \x01(\x05H\x00R\tpathDepth\x88\x01\x01\x12(\n" +
+	"\x0fcustomCondition\x18\x04 \x01(\tR\x0fcustomCondition\x1aB\n" +
+	"\x14ScoreThresholdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_pathDepth\"\xbd\x02\n" +
+	"\x12InterventionAction\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12+\n" +
+	"\x0eoverridePrompt\x18\x02 \x01(\tH\x00R\x0eoverridePrompt\x88\x01\x01\x12 \n" +
+	"\vfetchMemory\x18\x03 \x01(\bR\vfetchMemory\x121\n" +
+	"\x11resetToCheckpoint\x18\x04 \x01(\tH\x01R\x11resetToCheckpoint\x88\x01\x01\x12$\n" +
+	"\rcustomHandler\x18\x05 \x01(\tR\rcustomHandler\x12B\n" +
+	"\x10modifyDefinition\x18\x06 \x01(\v2\x16.jsonSchema.DefinitionR\x10modifyDefinitionB\x11\n" +
+	"\x0f_overridePromptB\x14\n" +
+	"\x12_resetToCheckpoint\"\xb3\x02\n" +
+	"\fMemoryConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12&\n" +
+	"\x0esearchStrategy\x18\x02 \x01(\tR\x0esearchStrategy\x12&\n" +
+	"\x0emaxContextSize\x18\x03 \x01(\x05R\x0emaxContextSize\x12G\n" +
+	"\x11retrievalTriggers\x18\x04 \x03(\v2\x19.jsonSchema.MemoryTriggerR\x11retrievalTriggers\x12H\n" +
+	"\x0eknowledgeGraph\x18\x05 \x01(\v2 .jsonSchema.KnowledgeGraphConfigR\x0eknowledgeGraph\x12&\n" +
+	"\x0estorageBackend\x18\x06 \x01(\tR\x0estorageBackend\"\xbc\x01\n" +
+	"\rMemoryTrigger\x12$\n" +
+	"\rdecisionPoint\x18\x01 \x01(\tR\rdecisionPoint\x12=\n" +
+	"\x0escoreThreshold\x18\x02 \x01(\v2\x15.jsonSchema.ConditionR\x0escoreThreshold\x12 \n" +
+	"\valwaysFetch\x18\x03 \x01(\bR\valwaysFetch\x12$\n" +
+	"\rqueryTemplate\x18\x04 \x01(\tR\rqueryTemplate\"\xa4\x01\n" +
+	"\x14KnowledgeGraphConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1c\n" +
+	"\tnodeTypes\x18\x02 \x03(\tR\tnodeTypes\x12,\n" +
+	"\x11relationshipTypes\x18\x03 \x03(\tR\x11relationshipTypes\x12&\n" +
+	"\x0etraversalDepth\x18\x04 \x01(\x05R\x0etraversalDepth\"\xb7\x02\n" +
+	"\x0eLazyConfigMode\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12H\n" +
+	"\x0epatternLibrary\x18\x02 \x01(\v2 .jsonSchema.PatternLibraryConfigR\x0epatternLibrary\x12&\n" +
+	"\x0ecreationPrompt\x18\x03 \x01(\tR\x0ecreationPrompt\x12K\n" +
+	"\x0fselfImprovement\x18\x04 \x01(\v2!.jsonSchema.SelfImprovementConfigR\x0fselfImprovement\x12&\n" +
+	"\x0edeploymentMode\x18\x05 \x01(\tR\x0edeploymentMode\x12$\n" +
+	"\rmaxComplexity\x18\x06 \x01(\x05R\rmaxComplexity\"\xb5\x01\n" +
+	"\x14PatternLibraryConfig\x12\x1c\n" +
+	"\tsourceUrl\x18\x01 \x01(\tR\tsourceUrl\x12&\n" +
+	"\x0eselectionModel\x18\x02 \x01(\tR\x0eselectionModel\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12C\n" +
+	"\x0ecustomPatterns\x18\x04 \x03(\v2\x1b.jsonSchema.DecisionPatternR\x0ecustomPatterns\"\xb4\x04\n" +
+	"\x0fDecisionPattern\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12D\n" +
+	"\x10definitionSchema\x18\x05 \x01(\v2\x16.jsonSchema.DefinitionH\x00R\x10definitionSchema\x12?\n" +
+	"\fsystemSchema\x18\n" +
+	" \x01(\v2\x19.jsonSchema.ComplexSystemH\x00R\fsystemSchema\x126\n" +
+	"\trawSchema\x18\v \x01(\v2\x16.google.protobuf.ValueH\x00R\trawSchema\x12\x1a\n" +
+	"\buseCases\x18\x06 \x03(\tR\buseCases\x12c\n" +
+	"\x12performanceMetrics\x18\a \x03(\v23.jsonSchema.DecisionPattern.PerformanceMetricsEntryR\x12performanceMetrics\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\aversion\x18\t \x01(\tR\aversion\x1aE\n" +
+	"\x17PerformanceMetricsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01B\b\n" +
+	"\x06schema\"\xaf\x02\n" +
+	"\x15SelfImprovementConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x126\n" +
+	"\x16saveSuccessfulPatterns\x18\x02 \x01(\bR\x16saveSuccessfulPatterns\x12*\n" +
+	"\x10successThreshold\x18\x03 \x01(\x01R\x10successThreshold\x12.\n" +
+	"\x12refinementStrategy\x18\x04 \x01(\tR\x12refinementStrategy\x12D\n" +
+	"\x10safetyMechanisms\x18\x05 \x01(\v2\x18.jsonSchema.SafetyConfigR\x10safetyMechanisms\x12\"\n" +
+	"\flearningRate\x18\x06 \x01(\x01R\flearningRate\"\xdf\x02\n" +
+	"\fSafetyConfig\x12(\n" +
+	"\x0frequireApproval\x18\x01 \x01(\bR\x0frequireApproval\x12,\n" +
+	"\x11rollbackOnFailure\x18\x02 \x01(\bR\x11rollbackOnFailure\x12c\n" +
+	"\x13performanceBaseline\x18\x03 \x03(\v21.jsonSchema.SafetyConfig.PerformanceBaselineEntryR\x13performanceBaseline\x12$\n" +
+	"\rmaxComplexity\x18\x04 \x01(\x05R\rmaxComplexity\x12$\n" +
+	"\rvalidationSet\x18\x05 \x03(\tR\rvalidationSet\x1aF\n" +
+	"\x18PerformanceBaselineEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"]\n" +
 	"\vRequestBody\x12\x16\n" +
 	"\x06prompt\x18\x01 \x01(\tR\x06prompt\x126\n" +
 	"\n" +
 	"definition\x18\x02 \x01(\v2\x16.jsonSchema.DefinitionR\n" +
-	"definition\"\xf9\x01\n" +
+	"definition\"s\n" +
+	"\x18ComplexSystemRequestBody\x12\x16\n" +
+	"\x06prompt\x18\x01 \x01(\tR\x06prompt\x12?\n" +
+	"\rcomplexSystem\x18\x02 \x01(\v2\x19.jsonSchema.ComplexSystemR\rcomplexSystem\"\xf9\x01\n" +
 	"\bResponse\x12+\n" +
 	"\x04data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04data\x12\x18\n" +
 	"\ausdCost\x18\x02 \x01(\x01R\ausdCost\x12J\n" +
@@ -2064,10 +3591,12 @@ const file_objectweaver_proto_rawDesc = "" +
 	"\fdetailedData\x18\x04 \x03(\v2/.jsonSchema.StreamingResponse.DetailedDataEntryR\fdetailedData\x1aZ\n" +
 	"\x11DetailedDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
-	"\x05value\x18\x02 \x01(\v2\x19.jsonSchema.DetailedFieldR\x05value:\x028\x012\xa8\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.jsonSchema.DetailedFieldR\x05value:\x028\x012\xdb\x02\n" +
 	"\x11JSONSchemaService\x12?\n" +
 	"\x0eGenerateObject\x12\x17.jsonSchema.RequestBody\x1a\x14.jsonSchema.Response\x12R\n" +
-	"\x16StreamGeneratedObjects\x12\x17.jsonSchema.RequestBody\x1a\x1d.jsonSchema.StreamingResponse0\x01B\bZ\x06./grpcb\x06proto3"
+	"\x16StreamGeneratedObjects\x12\x17.jsonSchema.RequestBody\x1a\x1d.jsonSchema.StreamingResponse0\x01\x12S\n" +
+	"\x15GenerateComplexSystem\x12$.jsonSchema.ComplexSystemRequestBody\x1a\x14.jsonSchema.Response\x12\\\n" +
+	"\x13StreamComplexSystem\x12$.jsonSchema.ComplexSystemRequestBody\x1a\x1d.jsonSchema.StreamingResponse0\x01B\bZ\x06./grpcb\x06proto3"
 
 var (
 	file_objectweaver_proto_rawDescOnce sync.Once
@@ -2081,43 +3610,63 @@ func file_objectweaver_proto_rawDescGZIP() []byte {
 	return file_objectweaver_proto_rawDescData
 }
 
-var file_objectweaver_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_objectweaver_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_objectweaver_proto_goTypes = []any{
-	(*Definition)(nil),          // 0: jsonSchema.Definition
-	(*TextToSpeech)(nil),        // 1: jsonSchema.TextToSpeech
-	(*SpeechToText)(nil),        // 2: jsonSchema.SpeechToText
-	(*Image)(nil),               // 3: jsonSchema.Image
-	(*Choices)(nil),             // 4: jsonSchema.Choices
-	(*HashMap)(nil),             // 5: jsonSchema.HashMap
-	(*Focus)(nil),               // 6: jsonSchema.Focus
-	(*SendImage)(nil),           // 7: jsonSchema.SendImage
-	(*RequestFormat)(nil),       // 8: jsonSchema.RequestFormat
-	(*DecisionPoint)(nil),       // 9: jsonSchema.DecisionPoint
-	(*ConditionalBranch)(nil),   // 10: jsonSchema.ConditionalBranch
-	(*Condition)(nil),           // 11: jsonSchema.Condition
-	(*ScoringCriteria)(nil),     // 12: jsonSchema.ScoringCriteria
-	(*ScoringDimension)(nil),    // 13: jsonSchema.ScoringDimension
-	(*ScoreScale)(nil),          // 14: jsonSchema.ScoreScale
-	(*RecursiveLoop)(nil),       // 15: jsonSchema.RecursiveLoop
-	(*EpistemicValidation)(nil), // 16: jsonSchema.EpistemicValidation
-	(*ModelConfig)(nil),         // 17: jsonSchema.ModelConfig
-	(*Choice)(nil),              // 18: jsonSchema.Choice
-	(*FieldMetadata)(nil),       // 19: jsonSchema.FieldMetadata
-	(*DetailedField)(nil),       // 20: jsonSchema.DetailedField
-	(*RequestBody)(nil),         // 21: jsonSchema.RequestBody
-	(*Response)(nil),            // 22: jsonSchema.Response
-	(*StreamingResponse)(nil),   // 23: jsonSchema.StreamingResponse
-	nil,                         // 24: jsonSchema.Definition.PropertiesEntry
-	nil,                         // 25: jsonSchema.RequestFormat.HeadersEntry
-	nil,                         // 26: jsonSchema.ScoringCriteria.DimensionsEntry
-	nil,                         // 27: jsonSchema.ModelConfig.LogitBiasEntry
-	nil,                         // 28: jsonSchema.ModelConfig.MetadataEntry
-	nil,                         // 29: jsonSchema.Response.DetailedDataEntry
-	nil,                         // 30: jsonSchema.StreamingResponse.DetailedDataEntry
-	(*structpb.Struct)(nil),     // 31: google.protobuf.Struct
+	(*Definition)(nil),               // 0: jsonSchema.Definition
+	(*TextToSpeech)(nil),             // 1: jsonSchema.TextToSpeech
+	(*SpeechToText)(nil),             // 2: jsonSchema.SpeechToText
+	(*Image)(nil),                    // 3: jsonSchema.Image
+	(*Choices)(nil),                  // 4: jsonSchema.Choices
+	(*HashMap)(nil),                  // 5: jsonSchema.HashMap
+	(*Focus)(nil),                    // 6: jsonSchema.Focus
+	(*SendImage)(nil),                // 7: jsonSchema.SendImage
+	(*RequestFormat)(nil),            // 8: jsonSchema.RequestFormat
+	(*DecisionPoint)(nil),            // 9: jsonSchema.DecisionPoint
+	(*ConditionalBranch)(nil),        // 10: jsonSchema.ConditionalBranch
+	(*Condition)(nil),                // 11: jsonSchema.Condition
+	(*ConditionValue)(nil),           // 12: jsonSchema.ConditionValue
+	(*ConditionValueList)(nil),       // 13: jsonSchema.ConditionValueList
+	(*ScoringCriteria)(nil),          // 14: jsonSchema.ScoringCriteria
+	(*ScoringDimension)(nil),         // 15: jsonSchema.ScoringDimension
+	(*ScoreScale)(nil),               // 16: jsonSchema.ScoreScale
+	(*RecursiveLoop)(nil),            // 17: jsonSchema.RecursiveLoop
+	(*EpistemicValidation)(nil),      // 18: jsonSchema.EpistemicValidation
+	(*ModelConfig)(nil),              // 19: jsonSchema.ModelConfig
+	(*Choice)(nil),                   // 20: jsonSchema.Choice
+	(*FieldMetadata)(nil),            // 21: jsonSchema.FieldMetadata
+	(*DetailedField)(nil),            // 22: jsonSchema.DetailedField
+	(*ComplexSystem)(nil),            // 23: jsonSchema.ComplexSystem
+	(*MainThreadConfig)(nil),         // 24: jsonSchema.MainThreadConfig
+	(*InterventionRule)(nil),         // 25: jsonSchema.InterventionRule
+	(*InterventionTrigger)(nil),      // 26: jsonSchema.InterventionTrigger
+	(*InterventionAction)(nil),       // 27: jsonSchema.InterventionAction
+	(*MemoryConfig)(nil),             // 28: jsonSchema.MemoryConfig
+	(*MemoryTrigger)(nil),            // 29: jsonSchema.MemoryTrigger
+	(*KnowledgeGraphConfig)(nil),     // 30: jsonSchema.KnowledgeGraphConfig
+	(*LazyConfigMode)(nil),           // 31: jsonSchema.LazyConfigMode
+	(*PatternLibraryConfig)(nil),     // 32: jsonSchema.PatternLibraryConfig
+	(*DecisionPattern)(nil),          // 33: jsonSchema.DecisionPattern
+	(*SelfImprovementConfig)(nil),    // 34: jsonSchema.SelfImprovementConfig
+	(*SafetyConfig)(nil),             // 35: jsonSchema.SafetyConfig
+	(*RequestBody)(nil),              // 36: jsonSchema.RequestBody
+	(*ComplexSystemRequestBody)(nil), // 37: jsonSchema.ComplexSystemRequestBody
+	(*Response)(nil),                 // 38: jsonSchema.Response
+	(*StreamingResponse)(nil),        // 39: jsonSchema.StreamingResponse
+	nil,                              // 40: jsonSchema.Definition.PropertiesEntry
+	nil,                              // 41: jsonSchema.RequestFormat.HeadersEntry
+	nil,                              // 42: jsonSchema.ScoringCriteria.DimensionsEntry
+	nil,                              // 43: jsonSchema.ModelConfig.LogitBiasEntry
+	nil,                              // 44: jsonSchema.ModelConfig.MetadataEntry
+	nil,                              // 45: jsonSchema.InterventionTrigger.ScoreThresholdsEntry
+	nil,                              // 46: jsonSchema.DecisionPattern.PerformanceMetricsEntry
+	nil,                              // 47: jsonSchema.SafetyConfig.PerformanceBaselineEntry
+	nil,                              // 48: jsonSchema.Response.DetailedDataEntry
+	nil,                              // 49: jsonSchema.StreamingResponse.DetailedDataEntry
+	(*structpb.Struct)(nil),          // 50: google.protobuf.Struct
+	(*structpb.Value)(nil),           // 51: google.protobuf.Value
 }
 var file_objectweaver_proto_depIdxs = []int32{
-	24, // 0: jsonSchema.Definition.properties:type_name -> jsonSchema.Definition.PropertiesEntry
+	40, // 0: jsonSchema.Definition.properties:type_name -> jsonSchema.Definition.PropertiesEntry
 	0,  // 1: jsonSchema.Definition.items:type_name -> jsonSchema.Definition
 	8,  // 2: jsonSchema.Definition.req:type_name -> jsonSchema.RequestFormat
 	6,  // 3: jsonSchema.Definition.narrowFocus:type_name -> jsonSchema.Focus
@@ -2127,45 +3676,75 @@ var file_objectweaver_proto_depIdxs = []int32{
 	3,  // 7: jsonSchema.Definition.image:type_name -> jsonSchema.Image
 	7,  // 8: jsonSchema.Definition.sendImage:type_name -> jsonSchema.SendImage
 	9,  // 9: jsonSchema.Definition.decisionPoint:type_name -> jsonSchema.DecisionPoint
-	12, // 10: jsonSchema.Definition.scoringCriteria:type_name -> jsonSchema.ScoringCriteria
-	15, // 11: jsonSchema.Definition.recursiveLoop:type_name -> jsonSchema.RecursiveLoop
-	16, // 12: jsonSchema.Definition.epistemic:type_name -> jsonSchema.EpistemicValidation
-	17, // 13: jsonSchema.Definition.modelConfig:type_name -> jsonSchema.ModelConfig
-	0,  // 14: jsonSchema.HashMap.fieldDefinition:type_name -> jsonSchema.Definition
-	25, // 15: jsonSchema.RequestFormat.headers:type_name -> jsonSchema.RequestFormat.HeadersEntry
-	31, // 16: jsonSchema.RequestFormat.body:type_name -> google.protobuf.Struct
-	10, // 17: jsonSchema.DecisionPoint.branches:type_name -> jsonSchema.ConditionalBranch
-	11, // 18: jsonSchema.ConditionalBranch.conditions:type_name -> jsonSchema.Condition
-	0,  // 19: jsonSchema.ConditionalBranch.logic:type_name -> jsonSchema.Definition
-	0,  // 20: jsonSchema.ConditionalBranch.then:type_name -> jsonSchema.Definition
-	26, // 21: jsonSchema.ScoringCriteria.dimensions:type_name -> jsonSchema.ScoringCriteria.DimensionsEntry
-	14, // 22: jsonSchema.ScoringDimension.scale:type_name -> jsonSchema.ScoreScale
-	9,  // 23: jsonSchema.RecursiveLoop.terminationPoint:type_name -> jsonSchema.DecisionPoint
-	27, // 24: jsonSchema.ModelConfig.logitBias:type_name -> jsonSchema.ModelConfig.LogitBiasEntry
-	28, // 25: jsonSchema.ModelConfig.metadata:type_name -> jsonSchema.ModelConfig.MetadataEntry
-	31, // 26: jsonSchema.ModelConfig.chatTemplateKwargs:type_name -> google.protobuf.Struct
-	31, // 27: jsonSchema.Choice.value:type_name -> google.protobuf.Struct
-	18, // 28: jsonSchema.FieldMetadata.choices:type_name -> jsonSchema.Choice
-	31, // 29: jsonSchema.DetailedField.value:type_name -> google.protobuf.Struct
-	19, // 30: jsonSchema.DetailedField.metadata:type_name -> jsonSchema.FieldMetadata
-	0,  // 31: jsonSchema.RequestBody.definition:type_name -> jsonSchema.Definition
-	31, // 32: jsonSchema.Response.data:type_name -> google.protobuf.Struct
-	29, // 33: jsonSchema.Response.detailedData:type_name -> jsonSchema.Response.DetailedDataEntry
-	31, // 34: jsonSchema.StreamingResponse.data:type_name -> google.protobuf.Struct
-	30, // 35: jsonSchema.StreamingResponse.detailedData:type_name -> jsonSchema.StreamingResponse.DetailedDataEntry
-	0,  // 36: jsonSchema.Definition.PropertiesEntry.value:type_name -> jsonSchema.Definition
-	13, // 37: jsonSchema.ScoringCriteria.DimensionsEntry.value:type_name -> jsonSchema.ScoringDimension
-	20, // 38: jsonSchema.Response.DetailedDataEntry.value:type_name -> jsonSchema.DetailedField
-	20, // 39: jsonSchema.StreamingResponse.DetailedDataEntry.value:type_name -> jsonSchema.DetailedField
-	21, // 40: jsonSchema.JSONSchemaService.GenerateObject:input_type -> jsonSchema.RequestBody
-	21, // 41: jsonSchema.JSONSchemaService.StreamGeneratedObjects:input_type -> jsonSchema.RequestBody
-	22, // 42: jsonSchema.JSONSchemaService.GenerateObject:output_type -> jsonSchema.Response
-	23, // 43: jsonSchema.JSONSchemaService.StreamGeneratedObjects:output_type -> jsonSchema.StreamingResponse
-	42, // [42:44] is the sub-list for method output_type
-	40, // [40:42] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	14, // 10: jsonSchema.Definition.scoringCriteria:type_name -> jsonSchema.ScoringCriteria
+	17, // 11: jsonSchema.Definition.recursiveLoop:type_name -> jsonSchema.RecursiveLoop
+	18, // 12: jsonSchema.Definition.epistemic:type_name -> jsonSchema.EpistemicValidation
+	19, // 13: jsonSchema.Definition.modelConfig:type_name -> jsonSchema.ModelConfig
+	50, // 14: jsonSchema.SpeechToText.extraBody:type_name -> google.protobuf.Struct
+	0,  // 15: jsonSchema.HashMap.fieldDefinition:type_name -> jsonSchema.Definition
+	41, // 16: jsonSchema.RequestFormat.headers:type_name -> jsonSchema.RequestFormat.HeadersEntry
+	50, // 17: jsonSchema.RequestFormat.body:type_name -> google.protobuf.Struct
+	10, // 18: jsonSchema.DecisionPoint.branches:type_name -> jsonSchema.ConditionalBranch
+	11, // 19: jsonSchema.ConditionalBranch.conditions:type_name -> jsonSchema.Condition
+	0,  // 20: jsonSchema.ConditionalBranch.logic:type_name -> jsonSchema.Definition
+	0,  // 21: jsonSchema.ConditionalBranch.then:type_name -> jsonSchema.Definition
+	13, // 22: jsonSchema.Condition.list_value:type_name -> jsonSchema.ConditionValueList
+	12, // 23: jsonSchema.ConditionValueList.values:type_name -> jsonSchema.ConditionValue
+	42, // 24: jsonSchema.ScoringCriteria.dimensions:type_name -> jsonSchema.ScoringCriteria.DimensionsEntry
+	16, // 25: jsonSchema.ScoringDimension.scale:type_name -> jsonSchema.ScoreScale
+	9,  // 26: jsonSchema.RecursiveLoop.terminationPoint:type_name -> jsonSchema.DecisionPoint
+	43, // 27: jsonSchema.ModelConfig.logitBias:type_name -> jsonSchema.ModelConfig.LogitBiasEntry
+	44, // 28: jsonSchema.ModelConfig.metadata:type_name -> jsonSchema.ModelConfig.MetadataEntry
+	50, // 29: jsonSchema.ModelConfig.chatTemplateKwargs:type_name -> google.protobuf.Struct
+	50, // 30: jsonSchema.Choice.value:type_name -> google.protobuf.Struct
+	20, // 31: jsonSchema.FieldMetadata.choices:type_name -> jsonSchema.Choice
+	50, // 32: jsonSchema.DetailedField.value:type_name -> google.protobuf.Struct
+	21, // 33: jsonSchema.DetailedField.metadata:type_name -> jsonSchema.FieldMetadata
+	0,  // 34: jsonSchema.ComplexSystem.rootSchema:type_name -> jsonSchema.Definition
+	24, // 35: jsonSchema.ComplexSystem.mainThread:type_name -> jsonSchema.MainThreadConfig
+	28, // 36: jsonSchema.ComplexSystem.memory:type_name -> jsonSchema.MemoryConfig
+	31, // 37: jsonSchema.ComplexSystem.lazyConfig:type_name -> jsonSchema.LazyConfigMode
+	25, // 38: jsonSchema.MainThreadConfig.interventionRules:type_name -> jsonSchema.InterventionRule
+	24, // 39: jsonSchema.MainThreadConfig.parentThread:type_name -> jsonSchema.MainThreadConfig
+	26, // 40: jsonSchema.InterventionRule.trigger:type_name -> jsonSchema.InterventionTrigger
+	27, // 41: jsonSchema.InterventionRule.action:type_name -> jsonSchema.InterventionAction
+	45, // 42: jsonSchema.InterventionTrigger.scoreThresholds:type_name -> jsonSchema.InterventionTrigger.ScoreThresholdsEntry
+	0,  // 43: jsonSchema.InterventionAction.modifyDefinition:type_name -> jsonSchema.Definition
+	29, // 44: jsonSchema.MemoryConfig.retrievalTriggers:type_name -> jsonSchema.MemoryTrigger
+	30, // 45: jsonSchema.MemoryConfig.knowledgeGraph:type_name -> jsonSchema.KnowledgeGraphConfig
+	11, // 46: jsonSchema.MemoryTrigger.scoreThreshold:type_name -> jsonSchema.Condition
+	32, // 47: jsonSchema.LazyConfigMode.patternLibrary:type_name -> jsonSchema.PatternLibraryConfig
+	34, // 48: jsonSchema.LazyConfigMode.selfImprovement:type_name -> jsonSchema.SelfImprovementConfig
+	33, // 49: jsonSchema.PatternLibraryConfig.customPatterns:type_name -> jsonSchema.DecisionPattern
+	0,  // 50: jsonSchema.DecisionPattern.definitionSchema:type_name -> jsonSchema.Definition
+	23, // 51: jsonSchema.DecisionPattern.systemSchema:type_name -> jsonSchema.ComplexSystem
+	51, // 52: jsonSchema.DecisionPattern.rawSchema:type_name -> google.protobuf.Value
+	46, // 53: jsonSchema.DecisionPattern.performanceMetrics:type_name -> jsonSchema.DecisionPattern.PerformanceMetricsEntry
+	35, // 54: jsonSchema.SelfImprovementConfig.safetyMechanisms:type_name -> jsonSchema.SafetyConfig
+	47, // 55: jsonSchema.SafetyConfig.performanceBaseline:type_name -> jsonSchema.SafetyConfig.PerformanceBaselineEntry
+	0,  // 56: jsonSchema.RequestBody.definition:type_name -> jsonSchema.Definition
+	23, // 57: jsonSchema.ComplexSystemRequestBody.complexSystem:type_name -> jsonSchema.ComplexSystem
+	50, // 58: jsonSchema.Response.data:type_name -> google.protobuf.Struct
+	48, // 59: jsonSchema.Response.detailedData:type_name -> jsonSchema.Response.DetailedDataEntry
+	50, // 60: jsonSchema.StreamingResponse.data:type_name -> google.protobuf.Struct
+	49, // 61: jsonSchema.StreamingResponse.detailedData:type_name -> jsonSchema.StreamingResponse.DetailedDataEntry
+	0,  // 62: jsonSchema.Definition.PropertiesEntry.value:type_name -> jsonSchema.Definition
+	15, // 63: jsonSchema.ScoringCriteria.DimensionsEntry.value:type_name -> jsonSchema.ScoringDimension
+	22, // 64: jsonSchema.Response.DetailedDataEntry.value:type_name -> jsonSchema.DetailedField
+	22, // 65: jsonSchema.StreamingResponse.DetailedDataEntry.value:type_name -> jsonSchema.DetailedField
+	36, // 66: jsonSchema.JSONSchemaService.GenerateObject:input_type -> jsonSchema.RequestBody
+	36, // 67: jsonSchema.JSONSchemaService.StreamGeneratedObjects:input_type -> jsonSchema.RequestBody
+	37, // 68: jsonSchema.JSONSchemaService.GenerateComplexSystem:input_type -> jsonSchema.ComplexSystemRequestBody
+	37, // 69: jsonSchema.JSONSchemaService.StreamComplexSystem:input_type -> jsonSchema.ComplexSystemRequestBody
+	38, // 70: jsonSchema.JSONSchemaService.GenerateObject:output_type -> jsonSchema.Response
+	39, // 71: jsonSchema.JSONSchemaService.StreamGeneratedObjects:output_type -> jsonSchema.StreamingResponse
+	38, // 72: jsonSchema.JSONSchemaService.GenerateComplexSystem:output_type -> jsonSchema.Response
+	39, // 73: jsonSchema.JSONSchemaService.StreamComplexSystem:output_type -> jsonSchema.StreamingResponse
+	70, // [70:74] is the sub-list for method output_type
+	66, // [66:70] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_objectweaver_proto_init() }
@@ -2173,10 +3752,27 @@ func file_objectweaver_proto_init() {
 	if File_objectweaver_proto != nil {
 		return
 	}
+	file_objectweaver_proto_msgTypes[0].OneofWrappers = []any{}
 	file_objectweaver_proto_msgTypes[11].OneofWrappers = []any{
 		(*Condition_NumberValue)(nil),
 		(*Condition_StringValue)(nil),
 		(*Condition_BoolValue)(nil),
+		(*Condition_IntValue)(nil),
+		(*Condition_ListValue)(nil),
+	}
+	file_objectweaver_proto_msgTypes[12].OneofWrappers = []any{
+		(*ConditionValue_NumberValue)(nil),
+		(*ConditionValue_StringValue)(nil),
+		(*ConditionValue_BoolValue)(nil),
+		(*ConditionValue_IntValue)(nil),
+	}
+	file_objectweaver_proto_msgTypes[19].OneofWrappers = []any{}
+	file_objectweaver_proto_msgTypes[26].OneofWrappers = []any{}
+	file_objectweaver_proto_msgTypes[27].OneofWrappers = []any{}
+	file_objectweaver_proto_msgTypes[33].OneofWrappers = []any{
+		(*DecisionPattern_DefinitionSchema)(nil),
+		(*DecisionPattern_SystemSchema)(nil),
+		(*DecisionPattern_RawSchema)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2184,7 +3780,7 @@ func file_objectweaver_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_objectweaver_proto_rawDesc), len(file_objectweaver_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	JSONSchemaService_GenerateObject_FullMethodName         = "/jsonSchema.JSONSchemaService/GenerateObject"
 	JSONSchemaService_StreamGeneratedObjects_FullMethodName = "/jsonSchema.JSONSchemaService/StreamGeneratedObjects"
+	JSONSchemaService_GenerateComplexSystem_FullMethodName  = "/jsonSchema.JSONSchemaService/GenerateComplexSystem"
+	JSONSchemaService_StreamComplexSystem_FullMethodName    = "/jsonSchema.JSONSchemaService/StreamComplexSystem"
 )
 
 // JSONSchemaServiceClient is the client API for JSONSchemaService service.
@@ -33,6 +35,10 @@ type JSONSchemaServiceClient interface {
 	GenerateObject(ctx context.Context, in *RequestBody, opts ...grpc.CallOption) (*Response, error)
 	// New method: Server-side streaming RPC
 	StreamGeneratedObjects(ctx context.Context, in *RequestBody, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamingResponse], error)
+	// Generates an object from a ComplexSystem
+	GenerateComplexSystem(ctx context.Context, in *ComplexSystemRequestBody, opts ...grpc.CallOption) (*Response, error)
+	// Server-side streaming RPC for a ComplexSystem
+	StreamComplexSystem(ctx context.Context, in *ComplexSystemRequestBody, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamingResponse], error)
 }
 
 type jSONSchemaServiceClient struct {
@@ -72,6 +78,35 @@ func (c *jSONSchemaServiceClient) StreamGeneratedObjects(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type JSONSchemaService_StreamGeneratedObjectsClient = grpc.ServerStreamingClient[StreamingResponse]
 
+func (c *jSONSchemaServiceClient) GenerateComplexSystem(ctx context.Context, in *ComplexSystemRequestBody, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, JSONSchemaService_GenerateComplexSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jSONSchemaServiceClient) StreamComplexSystem(ctx context.Context, in *ComplexSystemRequestBody, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamingResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JSONSchemaService_ServiceDesc.Streams[1], JSONSchemaService_StreamComplexSystem_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ComplexSystemRequestBody, StreamingResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JSONSchemaService_StreamComplexSystemClient = grpc.ServerStreamingClient[StreamingResponse]
+
 // JSONSchemaServiceServer is the server API for JSONSchemaService service.
 // All implementations must embed UnimplementedJSONSchemaServiceServer
 // for forward compatibility.
@@ -82,6 +117,10 @@ type JSONSchemaServiceServer interface {
 	GenerateObject(context.Context, *RequestBody) (*Response, error)
 	// New method: Server-side streaming RPC
 	StreamGeneratedObjects(*RequestBody, grpc.ServerStreamingServer[StreamingResponse]) error
+	// Generates an object from a ComplexSystem
+	GenerateComplexSystem(context.Context, *ComplexSystemRequestBody) (*Response, error)
+	// Server-side streaming RPC for a ComplexSystem
+	StreamComplexSystem(*ComplexSystemRequestBody, grpc.ServerStreamingServer[StreamingResponse]) error
 	mustEmbedUnimplementedJSONSchemaServiceServer()
 }
 
@@ -98,6 +137,12 @@ func (UnimplementedJSONSchemaServiceServer) GenerateObject(context.Context, *Req
 func (UnimplementedJSONSchemaServiceServer) StreamGeneratedObjects(*RequestBody, grpc.ServerStreamingServer[StreamingResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamGeneratedObjects not implemented")
 }
+func (UnimplementedJSONSchemaServiceServer) GenerateComplexSystem(context.Context, *ComplexSystemRequestBody) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateComplexSystem not implemented")
+}
+func (UnimplementedJSONSchemaServiceServer) StreamComplexSystem(*ComplexSystemRequestBody, grpc.ServerStreamingServer[StreamingResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamComplexSystem not implemented")
+}
 func (UnimplementedJSONSchemaServiceServer) mustEmbedUnimplementedJSONSchemaServiceServer() {}
 func (UnimplementedJSONSchemaServiceServer) testEmbeddedByValue()                           {}
 
@@ -148,6 +193,35 @@ func _JSONSchemaService_StreamGeneratedObjects_Handler(srv interface{}, stream g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type JSONSchemaService_StreamGeneratedObjectsServer = grpc.ServerStreamingServer[StreamingResponse]
 
+func _JSONSchemaService_GenerateComplexSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexSystemRequestBody)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JSONSchemaServiceServer).GenerateComplexSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JSONSchemaService_GenerateComplexSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JSONSchemaServiceServer).GenerateComplexSystem(ctx, req.(*ComplexSystemRequestBody))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JSONSchemaService_StreamComplexSystem_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ComplexSystemRequestBody)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JSONSchemaServiceServer).StreamComplexSystem(m, &grpc.GenericServerStream[ComplexSystemRequestBody, StreamingResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JSONSchemaService_StreamComplexSystemServer = grpc.ServerStreamingServer[StreamingResponse]
+
 // JSONSchemaService_ServiceDesc is the grpc.ServiceDesc for JSONSchemaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -159,6 +233,10 @@ var JSONSchemaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateObject",
 			Handler:    _JSONSchemaService_GenerateObject_Handler,
 		},
+		{
+			MethodName: "GenerateComplexSystem",
+			Handler:    _JSONSchemaService_GenerateComplexSystem_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -166,6 +244,11 @@ var JSONSchemaService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _JSONSchemaService_StreamGeneratedObjects_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamComplexSystem",
+			Handler:       _JSONSchemaService_StreamComplexSystem_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "objectweaver.proto",
 }
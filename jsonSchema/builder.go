@@ -0,0 +1,358 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Builder builds a Definition tree through chained calls, starting from Obj, Str or one of the other constructors:
+//
+//	def, err := jsonSchema.Obj().
+//		Prop("title", jsonSchema.Str("A catchy title")).
+//		Prop("body", jsonSchema.Str("The article body")).
+//		Order("title", "body").
+//		Build()
+//
+// The constructors are named Obj, Str and so on rather than Object or String, as those names are taken by the
+// DataType constants. Children passed to Prop, List, Dict, Then and Logic are copied along with their errors,
+// so changing a child builder afterwards, or reusing it for another field, leaves the parent unchanged. Build
+// returns a copy as well, so a builder can keep being extended after it is built.
+type Builder struct {
+	def  Definition
+	errs []error
+}
+
+// BranchBuilder builds a ConditionalBranch for Decide and StopWhen
+type BranchBuilder struct {
+	branch ConditionalBranch
+	errs   []error
+}
+
+// newBuilder starts a Builder for the type with an optional instruction
+func newBuilder(dataType DataType, instruction string) *Builder {
+	return &Builder{def: Definition{Type: dataType, Instruction: instruction}}
+}
+
+// Obj starts an object, add its fields with Prop
+func Obj() *Builder { return newBuilder(Object, "") }
+
+// Str starts a string field generated from the instruction
+func Str(instruction string) *Builder { return newBuilder(String, instruction) }
+
+// Int starts an integer field generated from the instruction
+func Int(instruction string) *Builder { return newBuilder(Integer, instruction) }
+
+// Num starts a number field generated from the instruction
+func Num(instruction string) *Builder { return newBuilder(Number, instruction) }
+
+// Bool starts a boolean field generated from the instruction
+func Bool(instruction string) *Builder { return newBuilder(Boolean, instruction) }
+
+// Bytes starts a byte field, which must be given a TextToSpeech or Image
+func Bytes(instruction string) *Builder { return newBuilder(Byte, instruction) }
+
+// Vec starts a vector field, an embedding of the generated text
+func Vec(instruction string) *Builder { return newBuilder(Vector, instruction) }
+
+// List starts an array whose items are built by items
+func List(instruction string, items *Builder) *Builder {
+	b := newBuilder(Array, instruction)
+	if items == nil {
+		b.errs = append(b.errs, errors.New("list items are required"))
+		return b
+	}
+	itemsDef := b.adopt(items)
+	b.def.Items = &itemsDef
+	return b
+}
+
+// Dict starts a map whose keys are generated from keyInstruction and whose values are built by values
+func Dict(keyInstruction string, values *Builder) *Builder {
+	b := newBuilder(Map, "")
+	if values == nil {
+		b.errs = append(b.errs, errors.New("dict values are required"))
+		return b
+	}
+	valuesDef := b.adopt(values)
+	b.def.HashMap = &HashMap{KeyInstruction: keyInstruction, FieldDefinition: &valuesDef}
+	return b
+}
+
+// Instruction sets the instruction for what to generate
+func (b *Builder) Instruction(instruction string) *Builder {
+	b.def.Instruction = instruction
+	return b
+}
+
+// Prop adds a property to an object, replacing any property of the same name
+func (b *Builder) Prop(name string, property *Builder) *Builder {
+	if property == nil {
+		b.errs = append(b.errs, fmt.Errorf("property %q is nil", name))
+		return b
+	}
+	if b.def.Properties == nil {
+		b.def.Properties = make(map[string]Definition)
+	}
+	b.def.Properties[name] = b.adopt(property)
+	return b
+}
+
+// adopt returns a copy of the child's Definition and carries its errors forward to the parent
+func (b *Builder) adopt(child *Builder) Definition {
+	b.errs = append(b.errs, child.errs...)
+	return child.def.Clone()
+}
+
+// Order sets the properties that are processed first, in the order given
+func (b *Builder) Order(fields ...string) *Builder {
+	b.def.ProcessingOrder = fields
+	return b
+}
+
+// Model sets the model the field is generated with
+func (b *Builder) Model(model string) *Builder {
+	b.def.Model = model
+	return b
+}
+
+// SystemPrompt sets the system prompt used for the properties of the field
+func (b *Builder) SystemPrompt(prompt string) *Builder {
+	b.def.SystemPrompt = &prompt
+	return b
+}
+
+// OverridePrompt replaces the prompt sent for the field
+func (b *Builder) OverridePrompt(prompt string) *Builder {
+	b.def.OverridePrompt = &prompt
+	return b
+}
+
+// Priority sets the priority of the request, see UrgentPriority through EventualPriority
+func (b *Builder) Priority(priority int32) *Builder {
+	b.def.Priority = priority
+	return b
+}
+
+// Stream marks the field to be streamed as it is generated
+func (b *Builder) Stream() *Builder {
+	b.def.Stream = true
+	return b
+}
+
+// SelectFields sets the paths of previously generated fields to wait for and pass to the generation
+func (b *Builder) SelectFields(paths ...string) *Builder {
+	b.def.SelectFields = paths
+	return b
+}
+
+// Focus narrows the context of the generation to the prompt and the given fields
+func (b *Builder) Focus(prompt string, fields ...string) *Builder {
+	b.def.NarrowFocus = &Focus{Prompt: prompt, Fields: fields}
+	return b
+}
+
+// Request sets the HTTP request made to fetch the value of the field
+func (b *Builder) Request(req RequestFormat) *Builder {
+	b.def.Req = &req
+	return b
+}
+
+// TextToSpeech sets the speech generated for a byte field
+func (b *Builder) TextToSpeech(textToSpeech TextToSpeech) *Builder {
+	b.def.TextToSpeech = &textToSpeech
+	return b
+}
+
+// SpeechToText sets the audio transcribed for a string field
+func (b *Builder) SpeechToText(speechToText SpeechToText) *Builder {
+	b.def.SpeechToText = &speechToText
+	return b
+}
+
+// Image sets the image generated for a byte or string field
+func (b *Builder) Image(image Image) *Builder {
+	b.def.Image = &image
+	return b
+}
+
+// SendImages passes images to a multi-modal model
+func (b *Builder) SendImages(images ...[]byte) *Builder {
+	b.def.SendImage = &SendImage{ImagesData: images}
+	return b
+}
+
+// Score adds a dimension the generated value is scored on
+func (b *Builder) Score(name string, dimension ScoringDimension) *Builder {
+	criteria := b.scoringCriteria()
+	if criteria.Dimensions == nil {
+		criteria.Dimensions = make(map[string]ScoringDimension)
+	}
+	criteria.Dimensions[name] = dimension
+	return b
+}
+
+// Aggregate sets how the scores of the dimensions are combined
+func (b *Builder) Aggregate(method AggregationMethod) *Builder {
+	b.scoringCriteria().AggregationMethod = method
+	return b
+}
+
+// EvaluationModel sets the model used to score the generated value
+func (b *Builder) EvaluationModel(model string) *Builder {
+	b.scoringCriteria().EvaluationModel = model
+	return b
+}
+
+func (b *Builder) scoringCriteria() *ScoringCriteria {
+	if b.def.ScoringCriteria == nil {
+		b.def.ScoringCriteria = &ScoringCriteria{}
+	}
+	return b.def.ScoringCriteria
+}
+
+// Decide adds a DecisionPoint routing on the strategy to the first branch whose conditions hold
+func (b *Builder) Decide(name string, strategy RoutingStrategy, branches ...*BranchBuilder) *Builder {
+	decision := &DecisionPoint{Name: name, Strategy: strategy}
+	if b.def.DecisionPoint != nil {
+		decision.EvaluationPrompt = b.def.DecisionPoint.EvaluationPrompt
+	}
+	decision.Branches = b.collectBranches(branches)
+	b.def.DecisionPoint = decision
+	return b
+}
+
+// EvaluationPrompt sets the prompt guiding the evaluation of the DecisionPoint
+func (b *Builder) EvaluationPrompt(prompt string) *Builder {
+	if b.def.DecisionPoint == nil {
+		b.def.DecisionPoint = &DecisionPoint{}
+	}
+	b.def.DecisionPoint.EvaluationPrompt = prompt
+	return b
+}
+
+// Loop regenerates the field up to maxIterations times, keeping the iteration chosen by selection
+func (b *Builder) Loop(maxIterations int, selection SelectionStrategy) *Builder {
+	loop := b.recursiveLoop()
+	loop.MaxIterations = maxIterations
+	loop.Selection = selection
+	return b
+}
+
+// Feedback sets the prompt guiding the improvement between iterations of the Loop
+func (b *Builder) Feedback(prompt string, includePreviousAttempts bool) *Builder {
+	loop := b.recursiveLoop()
+	loop.FeedbackPrompt = prompt
+	loop.IncludePreviousAttempts = includePreviousAttempts
+	return b
+}
+
+// StopWhen ends the Loop early once any of the branches matches
+func (b *Builder) StopWhen(strategy RoutingStrategy, branches ...*BranchBuilder) *Builder {
+	b.recursiveLoop().TerminationPoint = &DecisionPoint{Strategy: strategy, Branches: b.collectBranches(branches)}
+	return b
+}
+
+func (b *Builder) recursiveLoop() *RecursiveLoop {
+	if b.def.RecursiveLoop == nil {
+		b.def.RecursiveLoop = &RecursiveLoop{}
+	}
+	return b.def.RecursiveLoop
+}
+
+// Epistemic validates the generated information with the given number of judges
+func (b *Builder) Epistemic(judges int) *Builder {
+	b.def.Epistemic = EpistemicValidation{Active: true, Judges: judges}
+	return b
+}
+
+// ModelConfig sets the configuration of the model the field is generated with
+func (b *Builder) ModelConfig(config ModelConfig) *Builder {
+	b.def.ModelConfig = &config
+	return b
+}
+
+// Build validates and returns the Definition, see Definition.Validate
+func (b *Builder) Build() (*Definition, error) {
+	if err := errors.Join(append(b.errs, b.def.Validate())...); err != nil {
+		return nil, err
+	}
+	def := b.def.Clone()
+	return &def, nil
+}
+
+// MustBuild is like Build but panics when the Definition is invalid, for package level schemas
+func (b *Builder) MustBuild() *Definition {
+	def, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return def
+}
+
+func (b *Builder) collectBranches(branches []*BranchBuilder) []ConditionalBranch {
+	collected := make([]ConditionalBranch, 0, len(branches))
+	for _, branch := range branches {
+		if branch == nil {
+			continue
+		}
+		b.errs = append(b.errs, branch.errs...)
+		collected = append(collected, branch.copy())
+	}
+	return collected
+}
+
+// Branch starts a ConditionalBranch with the given name
+func Branch(name string) *BranchBuilder {
+	return &BranchBuilder{branch: ConditionalBranch{Name: name}}
+}
+
+// When adds a condition to the branch, all conditions must hold for the branch to be taken
+func (bb *BranchBuilder) When(field string, operator ComparisonOperator, value any) *BranchBuilder {
+	bb.branch.Conditions = append(bb.branch.Conditions, Condition{Field: field, Operator: operator, Value: value})
+	return bb
+}
+
+// WhenPath adds a condition on a nested field selected through SelectFields
+func (bb *BranchBuilder) WhenPath(fieldPath string, operator ComparisonOperator, value any) *BranchBuilder {
+	bb.branch.Conditions = append(bb.branch.Conditions, Condition{Field: lastPathSegment(fieldPath), Operator: operator, Value: value, FieldPath: fieldPath})
+	return bb
+}
+
+// Then sets the Definition generated when the branch is taken
+func (bb *BranchBuilder) Then(then *Builder) *BranchBuilder {
+	if then == nil {
+		bb.errs = append(bb.errs, fmt.Errorf("branch %q has no definition to generate", bb.branch.Name))
+		return bb
+	}
+	bb.errs = append(bb.errs, then.errs...)
+	bb.branch.Then = then.def.Clone()
+	return bb
+}
+
+// Logic sets the Definition used to evaluate the branch
+func (bb *BranchBuilder) Logic(logic *Builder) *BranchBuilder {
+	if logic == nil {
+		return bb
+	}
+	bb.errs = append(bb.errs, logic.errs...)
+	logicDef := logic.def.Clone()
+	bb.branch.Logic = &logicDef
+	return bb
+}
+
+// Priority sets the evaluation order of the branch, higher priorities are evaluated first
+func (bb *BranchBuilder) Priority(priority int) *BranchBuilder {
+	bb.branch.Priority = priority
+	return bb
+}
+
+// copy returns a copy of the branch, so a BranchBuilder can be reused across decisions
+func (bb *BranchBuilder) copy() ConditionalBranch {
+	return deepCopy(reflect.ValueOf(bb.branch)).Interface().(ConditionalBranch)
+}
+
+func lastPathSegment(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
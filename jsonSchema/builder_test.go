@@ -0,0 +1,95 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuilderCopiesChildren(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(child *Builder) *Builder
+		get   func(def *Definition) Definition
+	}{
+		{"prop", func(child *Builder) *Builder { return Obj().Prop("field", child) }, func(def *Definition) Definition { return def.Properties["field"] }},
+		{"list", func(child *Builder) *Builder { return List("items", child) }, func(def *Definition) Definition { return *def.Items }},
+		{"dict", func(child *Builder) *Builder { return Dict("keys", child) }, func(def *Definition) Definition { return *def.HashMap.FieldDefinition }},
+		{"then", func(child *Builder) *Builder {
+			return Str("root").Decide("route", RouteByField, Branch("b").When("x", OpEqual, "y").Then(child))
+		}, func(def *Definition) Definition { return def.DecisionPoint.Branches[0].Then }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child := Obj().Prop("name", Str("A name"))
+			parent := tt.build(child)
+
+			// changing the child after it was added must not reach the parent
+			child.Instruction("changed").Prop("extra", Str("An extra field"))
+			def, err := parent.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tt.get(def)
+			if got.Instruction != "" || len(got.Properties) != 1 {
+				t.Errorf("child = %+v, want the child as it was when added", got)
+			}
+
+			// nor must changing the parent after Build reach the built Definition
+			parent.Model("other")
+			if def.Model != "" {
+				t.Errorf("built definition model = %q, want it unchanged", def.Model)
+			}
+		})
+	}
+}
+
+func TestBuilderCarriesChildErrors(t *testing.T) {
+	invalid := List("no items", nil)
+	tests := []struct {
+		name    string
+		builder *Builder
+	}{
+		{"prop", Obj().Prop("field", invalid)},
+		{"list", List("nested", invalid)},
+		{"dict", Dict("keys", invalid)},
+		{"then", Str("root").Decide("route", RouteByField, Branch("b").When("x", OpEqual, "y").Then(invalid))},
+		{"logic", Str("root").Decide("route", RouteByField, Branch("b").When("x", OpEqual, "y").Then(Str("t")).Logic(invalid))},
+		{"nil prop", Obj().Prop("field", nil)},
+		{"nil dict", Dict("keys", nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); err == nil {
+				t.Error("Build() gave no error")
+			}
+		})
+	}
+}
+
+func TestBuilderBuild(t *testing.T) {
+	def, err := Obj().
+		Prop("title", Str("A catchy title")).
+		Prop("tags", List("Tags", Str("A tag"))).
+		Order("title").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Definition{
+		Type:            Object,
+		ProcessingOrder: []string{"title"},
+		Properties: map[string]Definition{
+			"title": {Type: String, Instruction: "A catchy title"},
+			"tags":  {Type: Array, Instruction: "Tags", Items: &Definition{Type: String, Instruction: "A tag"}},
+		},
+	}
+	if !reflect.DeepEqual(def, want) {
+		t.Errorf("Build() = %+v, want %+v", def, want)
+	}
+
+	_, err = Obj().Order("missing").Build()
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Build() error = %v, want one naming the unknown field", err)
+	}
+}
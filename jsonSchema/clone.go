@@ -0,0 +1,111 @@
+package jsonSchema
+
+import "reflect"
+
+// Clone returns a deep copy of the Definition, so the copy can be edited without changing the original.
+// Every map, slice and pointer is copied, including those under DecisionPoint branches and condition values.
+// Structs with unexported fields held in interfaces, such as a time.Time condition value, are copied by value.
+func (d Definition) Clone() Definition {
+	return deepCopy(reflect.ValueOf(d)).Interface().(Definition)
+}
+
+// Clone returns a deep copy of the ComplexSystem
+func (c ComplexSystem) Clone() ComplexSystem {
+	return deepCopy(reflect.ValueOf(c)).Interface().(ComplexSystem)
+}
+
+// deepCopy copies a value, recursing through pointers, structs, slices, maps and interfaces
+func deepCopy(value reflect.Value) reflect.Value {
+	return (&copier{seen: make(map[copied]reflect.Value)}).copy(value)
+}
+
+// copied identifies a pointer, map or slice that has already been copied
+type copied struct {
+	typ     reflect.Type
+	pointer uintptr
+	length  int
+}
+
+// copier deep copies values, copying what is reachable twice only once so shared values stay shared and cycles end
+type copier struct {
+	seen map[copied]reflect.Value
+}
+
+// copy copies a value with the pointers, slices and maps copied so far
+func (c *copier) copy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := copied{value.Type(), value.Pointer(), 0}
+		if done, ok := c.seen[key]; ok {
+			return done
+		}
+		result := reflect.New(value.Type().Elem())
+		c.seen[key] = result
+		result.Elem().Set(c.copy(value.Elem()))
+		return result
+	case reflect.Struct:
+		if !exportedFields(value.Type()) {
+			// opaque structs such as time.Time can only be copied as a whole
+			break
+		}
+		result := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.NumField(); i++ {
+			result.Field(i).Set(c.copy(value.Field(i)))
+		}
+		return result
+	case reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := copied{value.Type(), value.Pointer(), value.Len()}
+		if done, ok := c.seen[key]; ok {
+			return done
+		}
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		c.seen[key] = result
+		for i := 0; i < value.Len(); i++ {
+			result.Index(i).Set(c.copy(value.Index(i)))
+		}
+		return result
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := copied{value.Type(), value.Pointer(), 0}
+		if done, ok := c.seen[key]; ok {
+			return done
+		}
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		c.seen[key] = result
+		iter := value.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		return result
+	case reflect.Interface:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		result := reflect.New(value.Type()).Elem()
+		result.Set(c.copy(value.Elem()))
+		return result
+	}
+
+	// basic values and opaque structs are copied by assignment, detach them from the source so they stay settable
+	result := reflect.New(value.Type()).Elem()
+	result.Set(value)
+	return result
+}
+
+// exportedFields reports whether every field of the struct type is exported, so it can be copied field by field
+func exportedFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if !typ.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	prompt := "system"
+	edits := []struct {
+		name string
+		edit func(d *Definition)
+	}{
+		{"property", func(d *Definition) { d.Properties["title"] = Definition{Type: Integer} }},
+		{"items", func(d *Definition) { d.Properties["tags"].Items.Instruction = "changed" }},
+		{"hash map", func(d *Definition) { d.Properties["counts"].HashMap.FieldDefinition.Type = String }},
+		{"system prompt", func(d *Definition) { *d.SystemPrompt = "changed" }},
+		{"processing order", func(d *Definition) { d.ProcessingOrder[0] = "changed" }},
+		{"branch then", func(d *Definition) { d.DecisionPoint.Branches[0].Then.Instruction = "changed" }},
+		{"condition list", func(d *Definition) { d.DecisionPoint.Branches[0].Conditions[0].Value.([]string)[0] = "changed" }},
+		{"condition map", func(d *Definition) { d.DecisionPoint.Branches[0].Conditions[1].Value.(map[string]any)["a"] = 2 }},
+	}
+	for _, tt := range edits {
+		t.Run(tt.name, func(t *testing.T) {
+			original := Definition{
+				Type:            Object,
+				SystemPrompt:    &prompt,
+				ProcessingOrder: []string{"title"},
+				Properties: map[string]Definition{
+					"title":  {Type: String},
+					"tags":   {Type: Array, Items: &Definition{Type: String}},
+					"counts": {Type: Map, HashMap: &HashMap{FieldDefinition: &Definition{Type: Integer}}},
+				},
+				DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{{
+					Conditions: []Condition{
+						{Field: "title", Operator: OpIn, Value: []string{"a"}},
+						{Field: "title", Operator: OpEqual, Value: map[string]any{"a": 1}},
+					},
+					Then: Definition{Type: String},
+				}}},
+			}
+			clone := original.Clone()
+			if !reflect.DeepEqual(clone, original) {
+				t.Fatal("clone differs from the original")
+			}
+			snapshot := original.Clone()
+
+			tt.edit(&clone)
+			if !reflect.DeepEqual(original, snapshot) {
+				t.Errorf("editing the clone's %s changed the original", tt.name)
+			}
+		})
+	}
+}
+
+func TestCloneOpaqueValues(t *testing.T) {
+	deadline := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	def := Definition{Type: String, DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{{
+		Conditions: []Condition{{Field: "due", Operator: OpLessThan, Value: deadline}},
+	}}}}
+	if got := def.Clone().DecisionPoint.Branches[0].Conditions[0].Value; got != deadline {
+		t.Errorf("cloned time = %v, want %v", got, deadline)
+	}
+
+	system := ComplexSystem{LazyConfig: &LazyConfigMode{PatternLibrary: &PatternLibraryConfig{
+		CustomPatterns: []DecisionPattern{{Name: "dated", Schema: deadline}},
+	}}}
+	if got := system.Clone().LazyConfig.PatternLibrary.CustomPatterns[0].Schema; got != deadline {
+		t.Errorf("cloned pattern schema = %v, want %v", got, deadline)
+	}
+}
+
+func TestCloneSharedAndCyclic(t *testing.T) {
+	shared := &Definition{Type: String}
+	def := Definition{Type: Object, Properties: map[string]Definition{
+		"a": {Type: Array, Items: shared},
+		"b": {Type: Array, Items: shared},
+	}}
+	clone := def.Clone()
+	if clone.Properties["a"].Items != clone.Properties["b"].Items || clone.Properties["a"].Items == shared {
+		t.Error("a pointer shared in the original is not shared, and new, in the clone")
+	}
+
+	cyclic := &Definition{Type: Array}
+	cyclic.Items = cyclic
+	cloned := cyclic.Clone()
+	if cloned.Items == cyclic || cloned.Items.Items != cloned.Items {
+		t.Error("the clone of a cyclic definition is not a new cycle")
+	}
+}
+
+func TestComplexSystemClone(t *testing.T) {
+	system := ExampleComplexSystem()
+	clone := system.Clone()
+	if !reflect.DeepEqual(clone, system) {
+		t.Fatal("clone differs from the original")
+	}
+	clone.RootSchema.Properties["extra"] = Definition{Type: String}
+	if _, ok := system.RootSchema.Properties["extra"]; ok {
+		t.Error("editing the clone changed the original")
+	}
+}
@@ -0,0 +1,235 @@
+package jsonSchema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind says whether a value was added, removed or modified between two Definitions
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a single difference between two Definitions
+type Change struct {
+	Path string     `json:"path"` //JSON field names joined with dots, ie properties.title.instruction or decisionPoint.branches[0].then
+	Kind ChangeKind `json:"kind"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+// String formats the change as a line of a review, prefixed with +, - or ~
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatChangeValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatChangeValue(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatChangeValue(c.Old), formatChangeValue(c.New))
+}
+
+// Equal reports whether two Definitions describe the same schema.
+// Nil and empty maps or slices are equal, as are numbers of different Go types with the same value.
+func (d Definition) Equal(other Definition) bool {
+	return len(Diff(d, other)) == 0
+}
+
+// Diff lists the changes from a to b, in field order with map keys sorted.
+// Nil and empty maps or slices are treated as equal, so a Definition decoded from JSON matches the one it was encoded from.
+func Diff(a, b Definition) []Change {
+	var changes []Change
+	diffValues(reflect.ValueOf(a), reflect.ValueOf(b), "", &changes)
+	return changes
+}
+
+// diffValues compares two values of the same type
+func diffValues(a, b reflect.Value, path string, changes *[]Change) {
+	if isEmptyValue(a) && isEmptyValue(b) {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		switch {
+		case a.IsNil():
+			*changes = append(*changes, Change{Path: path, Kind: ChangeAdded, New: b.Interface()})
+		case b.IsNil():
+			*changes = append(*changes, Change{Path: path, Kind: ChangeRemoved, Old: a.Interface()})
+		default:
+			diffValues(a.Elem(), b.Elem(), path, changes)
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			diffValues(a.Field(i), b.Field(i), joinChangePath(path, jsonFieldName(field)), changes)
+		}
+	case reflect.Map:
+		keys := make([]reflect.Value, 0, a.Len()+b.Len())
+		seen := make(map[string]bool)
+		for _, m := range []reflect.Value{a, b} {
+			for _, key := range m.MapKeys() {
+				if name := fmt.Sprint(key.Interface()); !seen[name] {
+					seen[name] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+
+		for _, key := range keys {
+			keyPath := joinChangeKey(path, fmt.Sprint(key.Interface()))
+			av, bv := a.MapIndex(key), b.MapIndex(key)
+			switch {
+			case !av.IsValid():
+				*changes = append(*changes, Change{Path: keyPath, Kind: ChangeAdded, New: bv.Interface()})
+			case !bv.IsValid():
+				*changes = append(*changes, Change{Path: keyPath, Kind: ChangeRemoved, Old: av.Interface()})
+			default:
+				diffValues(av, bv, keyPath, changes)
+			}
+		}
+	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytes.Equal(a.Bytes(), b.Bytes()) {
+				*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Old: a.Interface(), New: b.Interface()})
+			}
+			return
+		}
+		for i := 0; i < max(a.Len(), b.Len()); i++ {
+			indexPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, Change{Path: indexPath, Kind: ChangeAdded, New: b.Index(i).Interface()})
+			case i >= b.Len():
+				*changes = append(*changes, Change{Path: indexPath, Kind: ChangeRemoved, Old: a.Index(i).Interface()})
+			default:
+				diffValues(a.Index(i), b.Index(i), indexPath, changes)
+			}
+		}
+	case reflect.Interface:
+		diffInterfaces(a, b, path, changes)
+	default:
+		if a.Interface() != b.Interface() {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Old: a.Interface(), New: b.Interface()})
+		}
+	}
+}
+
+// diffInterfaces compares values held in interfaces, such as Condition.Value, which may differ in type
+func diffInterfaces(a, b reflect.Value, path string, changes *[]Change) {
+	switch {
+	case a.IsNil():
+		*changes = append(*changes, Change{Path: path, Kind: ChangeAdded, New: b.Interface()})
+		return
+	case b.IsNil():
+		*changes = append(*changes, Change{Path: path, Kind: ChangeRemoved, Old: a.Interface()})
+		return
+	}
+
+	ae, be := a.Elem(), b.Elem()
+	if af, ok := toComparableNumber(ae); ok {
+		if bf, ok := toComparableNumber(be); ok {
+			if af != bf {
+				*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Old: a.Interface(), New: b.Interface()})
+			}
+			return
+		}
+	}
+	if ae.Type() == be.Type() {
+		diffValues(ae, be, path, changes)
+		return
+	}
+	// lists of different element types, such as []string and []any after decoding, are compared element by element
+	if ae.Kind() == reflect.Slice && be.Kind() == reflect.Slice {
+		for i := 0; i < max(ae.Len(), be.Len()); i++ {
+			indexPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= ae.Len():
+				*changes = append(*changes, Change{Path: indexPath, Kind: ChangeAdded, New: be.Index(i).Interface()})
+			case i >= be.Len():
+				*changes = append(*changes, Change{Path: indexPath, Kind: ChangeRemoved, Old: ae.Index(i).Interface()})
+			default:
+				diffInterfaces(asInterface(ae.Index(i)), asInterface(be.Index(i)), indexPath, changes)
+			}
+		}
+		return
+	}
+	*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Old: a.Interface(), New: b.Interface()})
+}
+
+// asInterface wraps a value in an interface so it can be compared with diffInterfaces
+func asInterface(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Interface {
+		return value
+	}
+	wrapped := reflect.New(reflect.TypeOf((*any)(nil)).Elem()).Elem()
+	wrapped.Set(value)
+	return wrapped
+}
+
+// toComparableNumber converts numeric kinds to float64 so 70 and 70.0 compare equal
+func toComparableNumber(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// isEmptyValue treats nil and empty maps and slices alike, along with zero values
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+// jsonFieldName returns the name a struct field is encoded with
+func jsonFieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func joinChangePath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// joinChangeKey adds a map key to the path, quoting keys that contain dots or brackets
+func joinChangeKey(path, key string) string {
+	if strings.ContainsAny(key, ".[]\"") || key == "" {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	return joinChangePath(path, key)
+}
+
+func formatChangeValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
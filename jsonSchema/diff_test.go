@@ -0,0 +1,69 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := Definition{
+		Type: Object,
+		Properties: map[string]Definition{
+			"title": {Type: String, Instruction: "A title"},
+			"a.b":   {Type: String},
+		},
+		DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{{
+			Conditions: []Condition{{Field: "score", Operator: OpGreaterThan, Value: 70}},
+		}}},
+	}
+	tests := []struct {
+		name string
+		edit func(d *Definition)
+		want []string
+	}{
+		{"unchanged", func(d *Definition) {}, nil},
+		{"modified instruction", func(d *Definition) {
+			d.Properties["title"] = Definition{Type: String, Instruction: "A new title"}
+		}, []string{`~ properties.title.instruction: "A title" -> "A new title"`}},
+		{"added property", func(d *Definition) { d.Properties["body"] = Definition{Type: String} }, []string{"+ properties.body: " + formatChangeValue(Definition{Type: String})}},
+		{"removed quoted key", func(d *Definition) { delete(d.Properties, "a.b") }, []string{`- properties["a.b"]: ` + formatChangeValue(Definition{Type: String})}},
+		{"same number of another type", func(d *Definition) { d.DecisionPoint.Branches[0].Conditions[0].Value = 70.0 }, nil},
+		{"different number", func(d *Definition) { d.DecisionPoint.Branches[0].Conditions[0].Value = 71 }, []string{"~ decisionPoint.branches[0].conditions[0].value: 70 -> 71"}},
+		{"added pointer", func(d *Definition) { d.Items = &Definition{Type: String} }, []string{"+ items: " + formatChangeValue(&Definition{Type: String})}},
+		{"empty and nil maps", func(d *Definition) { d.SelectFields = []string{} }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := base.Clone()
+			tt.edit(&changed)
+
+			var got []string
+			for _, change := range Diff(base, changed) {
+				got = append(got, change.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff = %q, want %q", got, tt.want)
+			}
+			if equal := base.Equal(changed); equal != (len(tt.want) == 0) {
+				t.Errorf("Equal = %v with changes %q", equal, got)
+			}
+		})
+	}
+}
+
+func TestDiffAfterJSONRoundTrip(t *testing.T) {
+	for _, def := range []Definition{ExampleHybridRouting(), ExampleNestedDecisionTree(), ExampleRecursiveLoop()} {
+		data, err := json.Marshal(def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Definition
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if changes := Diff(def, decoded); len(changes) > 0 {
+			t.Errorf("decoded definition differs: %v", changes)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DecisionResult is the outcome of evaluating a DecisionPoint locally
+type DecisionResult struct {
+	Branch *ConditionalBranch `json:"branch,omitempty"` //the branch taken, nil when none matched
+	Index  int                `json:"index"`            //index of the branch in DecisionPoint.Branches, -1 when none matched
+	Trace  []BranchTrace      `json:"trace"`            //the branches evaluated, in evaluation order
+}
+
+// BranchTrace records why a branch did or did not match
+type BranchTrace struct {
+	Name    string `json:"name"`
+	Index   int    `json:"index"`
+	Matched bool   `json:"matched"`
+	Reason  string `json:"reason,omitempty"` //the first condition that did not hold
+}
+
+// Evaluate picks the branch the DecisionPoint routes to for the given scores and generated data, without calling
+// an LLM, so routing logic can be unit tested.
+//
+// Branches are evaluated by descending Priority, in declaration order for equal priorities, and the first branch
+// whose conditions all hold is taken; a branch without conditions always matches. Conditions read their value from
+// the scores with RouteByScore and from the data with RouteByField. With RouteByHybrid, or no strategy, conditions
+// with a FieldPath read the data and the others read the scores when the Field is scored, the data otherwise.
+//
+// A FieldPath is dotted, ie "requirements.is_technical", with numeric segments indexing lists; other segments
+// applied to a list collect the value from every item. A Field without a FieldPath is looked up at the top of the data
+// first, then as a unique key anywhere in it. Missing values make the condition false.
+//
+// Numbers compare numerically whatever their Go type. A string holding a finite number or a boolean is read as one
+// when compared against a number or boolean, but two strings compare as strings, lexicographically for gt, lt, gte
+// and lte. in and nin take a list and, for a list value, require all or none of its items to be in it. contains
+// checks for a substring, a list item or a map key. Errors are only returned for unknown operators and in or nin
+// conditions without a list.
+func (dp DecisionPoint) Evaluate(scores map[string]any, data map[string]any) (DecisionResult, error) {
+	order := make([]int, len(dp.Branches))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return dp.Branches[order[a]].Priority > dp.Branches[order[b]].Priority })
+
+	result := DecisionResult{Index: -1}
+	for _, index := range order {
+		branch := &dp.Branches[index]
+		trace := BranchTrace{Name: branch.Name, Index: index, Matched: true}
+		for _, condition := range branch.Conditions {
+			holds, reason, err := evaluateCondition(condition, dp.Strategy, scores, data)
+			if err != nil {
+				return DecisionResult{Index: -1}, fmt.Errorf("branch %q: %v", branch.Name, err)
+			}
+			if !holds {
+				trace.Matched, trace.Reason = false, reason
+				break
+			}
+		}
+		result.Trace = append(result.Trace, trace)
+		if trace.Matched {
+			result.Branch, result.Index = branch, index
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// evaluateCondition reports whether the condition holds, with the reason when it does not
+func evaluateCondition(condition Condition, strategy RoutingStrategy, scores, data map[string]any) (bool, string, error) {
+	name := condition.FieldPath
+	if name == "" {
+		name = condition.Field
+	}
+	actual, found := conditionValue(condition, strategy, scores, data)
+	if !found {
+		return false, fmt.Sprintf("%s is missing", name), nil
+	}
+
+	holds, err := compareValues(actual, condition.Operator, condition.Value)
+	if err != nil {
+		return false, "", fmt.Errorf("%s: %v", name, err)
+	}
+	if !holds {
+		return false, fmt.Sprintf("%s %s %s is false for %s", name, condition.Operator, formatChangeValue(condition.Value), formatChangeValue(actual)), nil
+	}
+	return true, "", nil
+}
+
+// conditionValue finds the value a condition compares, following the routing strategy
+func conditionValue(condition Condition, strategy RoutingStrategy, scores, data map[string]any) (any, bool) {
+	switch {
+	case strategy == RouteByScore:
+		value, ok := scores[condition.Field]
+		return value, ok
+	case condition.FieldPath != "":
+		return resolveFieldPath(data, strings.Split(condition.FieldPath, "."))
+	case strategy != RouteByField:
+		if value, ok := scores[condition.Field]; ok {
+			return value, true
+		}
+	}
+	if value, ok := data[condition.Field]; ok {
+		return value, true
+	}
+	return findUniqueKey(data, condition.Field)
+}
+
+// resolveFieldPath follows a dotted path through maps and lists
+func resolveFieldPath(value any, path []string) (any, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	if object, ok := value.(map[string]any); ok {
+		child, ok := object[path[0]]
+		if !ok {
+			return nil, false
+		}
+		return resolveFieldPath(child, path[1:])
+	}
+	list, ok := listValues(value)
+	if !ok {
+		return nil, false
+	}
+	if index, err := strconv.Atoi(path[0]); err == nil {
+		if index < 0 || index >= len(list) {
+			return nil, false
+		}
+		return resolveFieldPath(list[index], path[1:])
+	}
+	// a key applied to a list collects it from every item, as SelectFields does
+	collected := make([]any, 0, len(list))
+	for _, item := range list {
+		if child, ok := resolveFieldPath(item, path); ok {
+			collected = append(collected, child)
+		}
+	}
+	return collected, len(collected) > 0
+}
+
+// findUniqueKey finds the value of a key nested anywhere in the data, when it appears exactly once
+func findUniqueKey(data any, key string) (any, bool) {
+	var found []any
+	var search func(value any)
+	search = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for name, child := range v {
+				if name == key {
+					found = append(found, child)
+				}
+				search(child)
+			}
+		case []any:
+			for _, item := range v {
+				search(item)
+			}
+		}
+	}
+	search(data)
+	if len(found) != 1 {
+		return nil, false
+	}
+	return found[0], true
+}
+
+// compareValues applies the operator to the generated value and the condition value
+func compareValues(actual any, operator ComparisonOperator, expected any) (bool, error) {
+	switch operator {
+	case OpEqual:
+		return valuesEqual(actual, expected), nil
+	case OpNotEqual:
+		return !valuesEqual(actual, expected), nil
+	case OpGreaterThan, OpLessThan, OpGreaterThanOrEqual, OpLessThanOrEqual:
+		order, ok := compareOrder(actual, expected)
+		if !ok {
+			return false, nil
+		}
+		switch operator {
+		case OpGreaterThan:
+			return order > 0, nil
+		case OpLessThan:
+			return order < 0, nil
+		case OpGreaterThanOrEqual:
+			return order >= 0, nil
+		}
+		return order <= 0, nil
+	case OpIn, OpNotIn:
+		options, ok := listValues(expected)
+		if !ok {
+			return false, fmt.Errorf("%s requires a list value", operator)
+		}
+		values, isList := listValues(actual)
+		if !isList {
+			values = []any{actual}
+		}
+		for _, value := range values {
+			in := false
+			for _, option := range options {
+				if valuesEqual(value, option) {
+					in = true
+					break
+				}
+			}
+			if in != (operator == OpIn) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case OpContains:
+		switch v := actual.(type) {
+		case string:
+			substring, ok := expected.(string)
+			return ok && strings.Contains(v, substring), nil
+		case map[string]any:
+			key, ok := expected.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := v[key]
+			return found, nil
+		}
+		if items, ok := listValues(actual); ok {
+			for _, item := range items {
+				if valuesEqual(item, expected) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown operator %q", operator)
+}
+
+// valuesEqual compares values by their meaning rather than their Go type, so 70, int64(70) and 70.0 are equal.
+// Two strings are only equal when they are identical, a string holding a number only equals that number.
+func valuesEqual(a, b any) bool {
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return x == y
+		}
+	}
+	if x, y, ok := comparableNumbers(a, b); ok {
+		return x == y
+	}
+	if isNumber(a) || isNumber(b) {
+		return false
+	}
+	if x, ok := boolValue(a); ok {
+		if y, ok := boolValue(b); ok {
+			return x == y
+		}
+	}
+	if x, ok := listValues(a); ok {
+		y, ok := listValues(b)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !valuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if _, ok := a.(string); ok {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareOrder orders numbers numerically and strings lexicographically, reporting false for other values.
+// A string holding a number is ordered numerically against a number, two strings are always ordered as strings.
+func compareOrder(a, b any) (int, bool) {
+	x, isString := a.(string)
+	y, bothStrings := b.(string)
+	if isString && bothStrings {
+		return strings.Compare(x, y), true
+	}
+	first, second, ok := comparableNumbers(a, b)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case first < second:
+		return -1, true
+	case first > second:
+		return 1, true
+	}
+	return 0, true
+}
+
+// comparableNumbers converts both values to float64 when one is a number and the other a number or a string
+// holding one, so strings are only read as numbers when compared with a number
+func comparableNumbers(a, b any) (float64, float64, bool) {
+	x, aNumber := numberValue(a)
+	y, bNumber := numberValue(b)
+	switch {
+	case aNumber && bNumber:
+		return x, y, true
+	case aNumber:
+		y, ok := numericString(b)
+		return x, y, ok
+	case bNumber:
+		x, ok := numericString(a)
+		return x, y, ok
+	}
+	return 0, 0, false
+}
+
+// isNumber reports whether the value is a number of any Go type or a json.Number
+func isNumber(value any) bool {
+	_, ok := numberValue(value)
+	return ok
+}
+
+// numberValue converts numbers of any Go type and json.Number to float64, strings are not numbers
+func numberValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil && isFinite(number)
+	case nil, string:
+		return 0, false
+	}
+	return toComparableNumber(reflect.ValueOf(value))
+}
+
+// numericString parses a string holding a finite number, "NaN" and "Inf" are not numbers
+func numericString(value any) (float64, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	return number, err == nil && isFinite(number)
+}
+
+// numericValue converts numbers and strings holding finite numbers to float64
+func numericValue(value any) (float64, bool) {
+	if number, ok := numberValue(value); ok {
+		return number, true
+	}
+	return numericString(value)
+}
+
+func isFinite(number float64) bool {
+	return !math.IsNaN(number) && !math.IsInf(number, 0)
+}
+
+// boolValue converts booleans and the strings "true" and "false"
+func boolValue(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		return parsed, err == nil
+	}
+	return false, false
+}
+
+// listValues converts a slice of any type to []any
+func listValues(value any) ([]any, bool) {
+	if list, ok := value.([]any); ok {
+		return list, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list, true
+}
@@ -0,0 +1,192 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   any
+		operator ComparisonOperator
+		expected any
+		want     bool
+	}{
+		// numbers compare by value whatever their Go type
+		{"int equals float", 70, OpEqual, 70.0, true},
+		{"int64 equals json number", int64(70), OpEqual, json.Number("70"), true},
+		{"float not equal", 70.5, OpNotEqual, 70, true},
+		{"number equals numeric string", 70.0, OpEqual, "70", true},
+		{"numeric string equals number", " 7.5 ", OpEqual, 7.5, true},
+		{"number does not equal word", 70, OpEqual, "seventy", false},
+		{"number does not equal nan string", math.NaN(), OpEqual, "NaN", false},
+		{"number does not equal infinite string", 1.0, OpLessThan, "Inf", false},
+
+		// two strings always compare as strings
+		{"nan strings are equal", "NaN", OpEqual, "NaN", true},
+		{"nan strings in list", "nan", OpIn, []any{"nan", "x"}, true},
+		{"padded numbers differ", "007", OpEqual, "7", false},
+		{"exponent differs", "1e3", OpEqual, "1000", false},
+		{"infinities differ", "Inf", OpEqual, "inf", false},
+		{"boolean strings differ", "true", OpEqual, "True", false},
+		{"numeric strings order lexicographically", "10", OpGreaterThan, "9", false},
+		{"numeric strings order lexicographically reversed", "10", OpLessThan, "9", true},
+		{"words order lexicographically", "apple", OpLessThanOrEqual, "banana", true},
+
+		// numbers against strings holding numbers
+		{"number greater than numeric string", 10, OpGreaterThan, "9", true},
+		{"numeric string greater than number", "10", OpGreaterThanOrEqual, 9, true},
+		{"json number against numeric string", json.Number("10"), OpGreaterThan, "9", true},
+		{"number against word does not order", 10, OpGreaterThan, "nine", false},
+		{"number against boolean does not order", 1, OpLessThan, true, false},
+
+		// booleans
+		{"bool equals bool", true, OpEqual, true, true},
+		{"bool equals boolean string", "false", OpEqual, false, true},
+		{"bool does not equal number", true, OpEqual, 1, false},
+
+		// lists and maps
+		{"number in list", 2, OpIn, []int{1, 2}, true},
+		{"numeric string in number list", "2", OpIn, []any{1.0, 2.0}, true},
+		{"string not in numeric strings", "02", OpIn, []string{"2"}, false},
+		{"all items in list", []string{"a", "b"}, OpIn, []string{"a", "b", "c"}, true},
+		{"some items in list", []string{"a", "d"}, OpIn, []string{"a", "b"}, false},
+		{"no items in list", []string{"d"}, OpNotIn, []string{"a", "b"}, true},
+		{"equal lists", []any{1.0, "a"}, OpEqual, []any{1, "a"}, true},
+		{"lists of different length", []any{1.0}, OpEqual, []any{1, 2}, false},
+		{"substring", "technical writing", OpContains, "writing", true},
+		{"list item", []any{"a", 3.0}, OpContains, 3, true},
+		{"map key", map[string]any{"a": 1}, OpContains, "a", true},
+		{"missing map key", map[string]any{"a": 1}, OpContains, "b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareValues(tt.actual, tt.operator, tt.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("compareValues(%#v, %s, %#v) = %v, want %v", tt.actual, tt.operator, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareValuesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator ComparisonOperator
+		expected any
+		wantErr  string
+	}{
+		{"in without a list", OpIn, "a", "in requires a list value"},
+		{"nin without a list", OpNotIn, 1, "nin requires a list value"},
+		{"unknown operator", ComparisonOperator("like"), "a", `unknown operator "like"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compareValues("a", tt.operator, tt.expected)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("compareValues() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	decision := DecisionPoint{
+		Strategy: RouteByHybrid,
+		Branches: []ConditionalBranch{
+			{Name: "expert", Conditions: []Condition{{FieldPath: "audience.level", Operator: OpEqual, Value: "expert"}}},
+			{Name: "improve", Priority: 1, Conditions: []Condition{{Field: "quality", Operator: OpLessThan, Value: 50}}},
+			{Name: "tagged", Conditions: []Condition{{FieldPath: "sections.tag", Operator: OpIn, Value: []string{"intro", "body"}}}},
+			{Name: "fallback"},
+		},
+	}
+	tests := []struct {
+		name   string
+		scores map[string]any
+		data   map[string]any
+		want   string
+		traced []string
+		reason string
+	}{
+		{
+			name:   "priority first",
+			scores: map[string]any{"quality": 40},
+			data:   map[string]any{"audience": map[string]any{"level": "expert"}},
+			want:   "improve",
+			traced: []string{"improve"},
+		},
+		{
+			name:   "field path",
+			scores: map[string]any{"quality": json.Number("80")},
+			data:   map[string]any{"audience": map[string]any{"level": "expert"}},
+			want:   "expert",
+			traced: []string{"improve", "expert"},
+			reason: "quality lt 50 is false for 80",
+		},
+		{
+			name:   "field path through a list",
+			scores: map[string]any{"quality": 80},
+			data:   map[string]any{"sections": []any{map[string]any{"tag": "intro"}, map[string]any{"tag": "body"}}},
+			want:   "tagged",
+			traced: []string{"improve", "expert", "tagged"},
+			reason: "quality lt 50 is false for 80",
+		},
+		{
+			name:   "numeric string score",
+			scores: map[string]any{"quality": "12"},
+			want:   "improve",
+			traced: []string{"improve"},
+		},
+		{
+			name:   "missing values fall through",
+			want:   "fallback",
+			traced: []string{"improve", "expert", "tagged", "fallback"},
+			reason: "quality is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decision.Evaluate(tt.scores, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Branch == nil || result.Branch.Name != tt.want {
+				t.Fatalf("Evaluate() took %+v, want %q", result.Branch, tt.want)
+			}
+			var traced []string
+			for _, trace := range result.Trace {
+				traced = append(traced, trace.Name)
+			}
+			if strings.Join(traced, ",") != strings.Join(tt.traced, ",") {
+				t.Errorf("trace = %v, want %v", traced, tt.traced)
+			}
+			if tt.reason != "" && result.Trace[0].Reason != tt.reason {
+				t.Errorf("reason = %q, want %q", result.Trace[0].Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestEvaluateNoMatch(t *testing.T) {
+	decision := DecisionPoint{Strategy: RouteByScore, Branches: []ConditionalBranch{
+		{Name: "high", Conditions: []Condition{{Field: "quality", Operator: OpGreaterThan, Value: 90}}},
+	}}
+	result, err := decision.Evaluate(map[string]any{"quality": 50}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Branch != nil || result.Index != -1 || len(result.Trace) != 1 || result.Trace[0].Matched {
+		t.Errorf("Evaluate() = %+v, want no branch", result)
+	}
+
+	decision.Branches[0].Conditions[0].Operator = OpIn
+	if _, err := decision.Evaluate(map[string]any{"quality": 50}, nil); err == nil || err.Error() != `branch "high": quality: in requires a list value` {
+		t.Errorf("Evaluate() error = %v", err)
+	}
+}
@@ -0,0 +1,640 @@
+package jsonSchema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportIssue describes a JSON Schema construct that FromJSONSchema dropped or could only approximate
+type ImportIssue struct {
+	Path    string `json:"path"`    //JSON pointer to the schema holding the construct
+	Keyword string `json:"keyword"` //the JSON Schema keyword concerned
+	Reason  string `json:"reason"`
+}
+
+// String formats the issue for logging
+func (i ImportIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Path, i.Keyword, i.Reason)
+}
+
+// importedKeywords are the keywords FromJSONSchema converts into the Definition
+var importedKeywords = map[string]bool{
+	"$ref": true, "type": true, "description": true, "title": true, "format": true,
+	"properties": true, "required": true, "additionalProperties": true, "propertyNames": true,
+	"items": true, "enum": true, "const": true, "oneOf": true, "anyOf": true, "allOf": true,
+	"contentEncoding": true,
+}
+
+// annotationKeywords carry no meaning for generation and are dropped without being reported
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$defs": true, "definitions": true, "$comment": true,
+	"default": true, "examples": true, "readOnly": true, "writeOnly": true, "deprecated": true,
+	"contentMediaType": true,
+}
+
+// FromJSONSchema converts a standard JSON Schema document into a Definition.
+// References within the document ($ref to $defs, definitions or any JSON pointer) are resolved, descriptions
+// become instructions, enum and const are described in the instruction and additionalProperties becomes a Map.
+// oneOf and anyOf alternatives that share a const discriminator property become a DecisionPoint routing on that
+// property, other alternatives are described in the instruction. Every construct that could not be represented
+// exactly is listed in the returned issues; an error is only returned when the document is not valid JSON.
+func FromJSONSchema(data []byte) (*Definition, []ImportIssue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("error decoding JSON Schema: %v", err)
+	}
+
+	importer := &schemaImporter{root: document, reported: make(map[string]bool)}
+	def := importer.convert(document, "#")
+	return &def, importer.issues, nil
+}
+
+// schemaImporter holds the state of a single FromJSONSchema call
+type schemaImporter struct {
+	root      any
+	resolving []string // references currently being expanded, used to detect recursion
+	issues    []ImportIssue
+	reported  map[string]bool
+}
+
+// report records an issue once per schema and keyword, as shared $defs are converted every time they are referenced
+func (imp *schemaImporter) report(path, keyword, reason string) {
+	key := path + "\x00" + keyword
+	if imp.reported[key] {
+		return
+	}
+	imp.reported[key] = true
+	imp.issues = append(imp.issues, ImportIssue{Path: path, Keyword: keyword, Reason: reason})
+}
+
+// convert converts a schema describing a generated value, falling back to a string when no type can be determined
+func (imp *schemaImporter) convert(node any, path string) Definition {
+	def := imp.convertNode(node, path)
+	if def.Type == "" && def.HashMap == nil {
+		imp.report(path, "type", "no type could be determined, the value is generated as a string")
+		def.Type = String
+	}
+
+	if len(def.Properties) > 0 {
+		required := imp.requiredProperties(node, nil)
+		var optional []string
+		if def.DecisionPoint != nil {
+			// the discriminator is added by routeOnDiscriminator rather than declared by the schema
+			required[def.DecisionPoint.Name] = true
+		}
+		for key := range def.Properties {
+			if !required[key] {
+				optional = append(optional, key)
+			}
+		}
+		if len(optional) > 0 {
+			sort.Strings(optional)
+			imp.report(path, "required", fmt.Sprintf("optional properties are always generated: %s", strings.Join(optional, ", ")))
+		}
+	}
+	return def
+}
+
+// convertNode converts a schema without defaulting its type, so it can be merged into another schema
+func (imp *schemaImporter) convertNode(node any, path string) Definition {
+	switch schema := node.(type) {
+	case map[string]any:
+		return imp.convertSchema(schema, path)
+	case bool:
+		if schema {
+			imp.report(path, "true", "a schema accepting any value is generated as a string")
+		} else {
+			imp.report(path, "false", "a schema accepting no value cannot be generated")
+		}
+		return Definition{Type: String}
+	}
+	imp.report(path, "", fmt.Sprintf("expected a schema, got %T", node))
+	return Definition{Type: String}
+}
+
+// convertSchema converts a schema object, layering its own keywords over its $ref and allOf schemas
+func (imp *schemaImporter) convertSchema(schema map[string]any, path string) Definition {
+	keywords := make([]string, 0, len(schema))
+	for keyword := range schema {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if !importedKeywords[keyword] && !annotationKeywords[keyword] {
+			imp.report(path, keyword, "keyword is not supported and was ignored")
+		}
+	}
+
+	var def Definition
+	if parts, ok := schema["allOf"].([]any); ok {
+		for i, part := range parts {
+			def = mergeDefinitions(def, imp.convertNode(part, fmt.Sprintf("%s/allOf/%d", path, i)))
+		}
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		def = mergeDefinitions(def, imp.convertRef(ref, path))
+	}
+	def = mergeDefinitions(def, imp.convertKeywords(schema, path))
+
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if alternatives, ok := schema[keyword].([]any); ok {
+			def = imp.convertAlternatives(def, alternatives, keyword, path)
+		}
+	}
+	return def
+}
+
+// convertKeywords converts the keywords of a schema apart from $ref and the combinators
+func (imp *schemaImporter) convertKeywords(schema map[string]any, path string) Definition {
+	def := Definition{
+		Type:        imp.schemaType(schema, path),
+		Instruction: schemaInstruction(schema),
+	}
+
+	switch def.Type {
+	case Object:
+		imp.convertObject(&def, schema, path)
+	case Array:
+		if items, ok := schema["items"]; ok {
+			converted := imp.convert(items, path+"/items")
+			def.Items = &converted
+		} else {
+			imp.report(path, "items", "an array without items is generated as an array of strings")
+			def.Items = &Definition{Type: String}
+		}
+	case String:
+		if encoding, ok := schema["contentEncoding"].(string); ok {
+			if strings.EqualFold(encoding, "base64") {
+				def.Type = Byte
+			} else {
+				imp.report(path, "contentEncoding", fmt.Sprintf("%s encoding is not supported, the value is generated as a plain string", encoding))
+			}
+		}
+	}
+	return def
+}
+
+// convertObject converts the properties of an object, turning objects with only additionalProperties into a Map
+func (imp *schemaImporter) convertObject(def *Definition, schema map[string]any, path string) {
+	if properties, ok := schema["properties"].(map[string]any); ok && len(properties) > 0 {
+		// convert in a stable order so issues are reported in the same order on every import
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		def.Properties = make(map[string]Definition, len(properties))
+		for _, key := range keys {
+			def.Properties[key] = imp.convert(properties[key], path+"/properties/"+escapePointerToken(key))
+		}
+	}
+
+	keyInstruction := ""
+	if propertyNames, ok := schema["propertyNames"].(map[string]any); ok {
+		keyInstruction = schemaInstruction(propertyNames)
+		for keyword := range propertyNames {
+			if keyword != "description" && keyword != "title" && keyword != "type" {
+				imp.report(path+"/propertyNames", keyword, "only the description of property names is kept")
+			}
+		}
+	}
+
+	additional, ok := schema["additionalProperties"]
+	if !ok || additional == false {
+		return
+	}
+	if len(def.Properties) > 0 {
+		imp.report(path, "additionalProperties", "an object with fixed properties cannot hold additional keys, they are not generated")
+		return
+	}
+
+	def.Type = Map
+	if additional == true {
+		if keyInstruction != "" {
+			def.HashMap = &HashMap{KeyInstruction: keyInstruction}
+		}
+		return
+	}
+	field := imp.convert(additional, path+"/additionalProperties")
+	def.HashMap = &HashMap{KeyInstruction: keyInstruction, FieldDefinition: &field}
+}
+
+// convertAlternatives converts oneOf and anyOf into a DecisionPoint when the alternatives carry a discriminator,
+// otherwise the structure of the first alternative is kept and every alternative is described in the instruction
+func (imp *schemaImporter) convertAlternatives(def Definition, alternatives []any, keyword, path string) Definition {
+	var options []Definition
+	var schemas []map[string]any
+	for i, alternative := range alternatives {
+		optionPath := fmt.Sprintf("%s/%s/%d", path, keyword, i)
+		resolved := imp.resolveSchema(alternative)
+		if resolved != nil && isNullSchema(resolved) {
+			imp.report(optionPath, "type", "null alternatives are dropped, the value is always generated")
+			continue
+		}
+		options = append(options, imp.convert(alternative, optionPath))
+		schemas = append(schemas, resolved)
+	}
+
+	switch len(options) {
+	case 0:
+		return def
+	case 1:
+		return mergeDefinitions(options[0], def)
+	}
+
+	if discriminator, values, ok := findDiscriminator(schemas); ok {
+		return routeOnDiscriminator(def, options, discriminator, values)
+	}
+
+	descriptions := make([]string, len(options))
+	for i, option := range options {
+		description := option.Instruction
+		if description == "" {
+			description = string(option.Type)
+		}
+		descriptions[i] = fmt.Sprintf("(%d) %s", i+1, description)
+	}
+	imp.report(path, keyword, "alternatives without a const discriminator are described in the instruction and only the structure of the first one is kept")
+
+	merged := mergeDefinitions(options[0], def)
+	merged.Instruction = joinInstruction(def.Instruction, "Generate one of the following: "+strings.Join(descriptions, "; ")+".")
+	return merged
+}
+
+// routeOnDiscriminator generates the discriminator first and routes to the alternative matching its value
+func routeOnDiscriminator(def Definition, options []Definition, discriminator string, values []any) Definition {
+	if def.Type == "" {
+		def.Type = Object
+	}
+
+	properties := make(map[string]Definition, len(def.Properties)+1)
+	for key, property := range def.Properties {
+		properties[key] = property
+	}
+	properties[discriminator] = Definition{
+		Type:        valueType(values[0]),
+		Instruction: "Must be one of: " + formatValues(values) + ".",
+	}
+	def.Properties = properties
+
+	decision := &DecisionPoint{Name: discriminator, Strategy: RouteByField}
+	for i, option := range options {
+		then := option
+		if len(option.Properties) > 0 {
+			then.Properties = make(map[string]Definition, len(option.Properties))
+			for key, property := range option.Properties {
+				if key != discriminator {
+					then.Properties[key] = property
+				}
+			}
+		}
+		decision.Branches = append(decision.Branches, ConditionalBranch{
+			Name:       fmt.Sprint(values[i]),
+			Conditions: []Condition{{Field: discriminator, Operator: OpEqual, Value: values[i]}},
+			Then:       then,
+		})
+	}
+	def.DecisionPoint = decision
+	return def
+}
+
+// findDiscriminator looks for a property that every alternative fixes to a different constant
+func findDiscriminator(schemas []map[string]any) (string, []any, bool) {
+	if len(schemas) == 0 || schemas[0] == nil {
+		return "", nil, false
+	}
+	first, _ := schemas[0]["properties"].(map[string]any)
+
+	candidates := make([]string, 0, len(first))
+	for key := range first {
+		candidates = append(candidates, key)
+	}
+	sort.Strings(candidates)
+
+candidate:
+	for _, key := range candidates {
+		values := make([]any, len(schemas))
+		seen := make(map[string]bool, len(schemas))
+		for i, schema := range schemas {
+			properties, _ := schema["properties"].(map[string]any)
+			property, _ := properties[key].(map[string]any)
+			value, ok := constantValue(property)
+			if !ok || seen[fmt.Sprint(value)] {
+				continue candidate
+			}
+			seen[fmt.Sprint(value)] = true
+			values[i] = value
+		}
+		return key, values, true
+	}
+	return "", nil, false
+}
+
+// constantValue returns the value a property schema is fixed to through const or a single valued enum
+func constantValue(property map[string]any) (any, bool) {
+	if property == nil {
+		return nil, false
+	}
+	if value, ok := property["const"]; ok {
+		return normaliseSchemaValue(value), true
+	}
+	if values, ok := property["enum"].([]any); ok && len(values) == 1 {
+		return normaliseSchemaValue(values[0]), true
+	}
+	return nil, false
+}
+
+// convertRef converts the schema a reference points to, cutting off recursive references
+func (imp *schemaImporter) convertRef(ref, path string) Definition {
+	target, err := imp.resolve(ref)
+	if err != nil {
+		imp.report(path, "$ref", err.Error())
+		return Definition{}
+	}
+	for _, active := range imp.resolving {
+		if active == ref {
+			imp.report(path, "$ref", fmt.Sprintf("recursive reference to %s cannot be represented, it is generated as a string", ref))
+			return Definition{Type: String}
+		}
+	}
+
+	imp.resolving = append(imp.resolving, ref)
+	defer func() { imp.resolving = imp.resolving[:len(imp.resolving)-1] }()
+	return imp.convertNode(target, ref)
+}
+
+// resolve follows a JSON pointer reference within the document
+func (imp *schemaImporter) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("reference %s is outside the document and cannot be resolved", ref)
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %s: %v", ref, err)
+	}
+	if pointer == "" {
+		return imp.root, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("anchor reference %s is not supported", ref)
+	}
+
+	node := imp.root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch current := node.(type) {
+		case map[string]any:
+			next, ok := current[token]
+			if !ok {
+				return nil, fmt.Errorf("reference %s does not exist", ref)
+			}
+			node = next
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, fmt.Errorf("reference %s does not exist", ref)
+			}
+			node = current[index]
+		default:
+			return nil, fmt.Errorf("reference %s does not exist", ref)
+		}
+	}
+	return node, nil
+}
+
+// resolveSchema follows the $ref chain of a schema, returning nil when it does not lead to a schema object
+func (imp *schemaImporter) resolveSchema(node any) map[string]any {
+	visited := make(map[string]bool)
+	for {
+		schema, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		ref, ok := schema["$ref"].(string)
+		if !ok || visited[ref] {
+			return schema
+		}
+		visited[ref] = true
+		target, err := imp.resolve(ref)
+		if err != nil {
+			return schema
+		}
+		node = target
+	}
+}
+
+// requiredProperties collects the required property names of a schema, including those of its $ref and allOf schemas
+func (imp *schemaImporter) requiredProperties(node any, visited map[string]bool) map[string]bool {
+	required := make(map[string]bool)
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return required
+	}
+	if names, ok := schema["required"].([]any); ok {
+		for _, name := range names {
+			if key, ok := name.(string); ok {
+				required[key] = true
+			}
+		}
+	}
+
+	var parts []any
+	if allOf, ok := schema["allOf"].([]any); ok {
+		parts = append(parts, allOf...)
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if visited == nil {
+			visited = make(map[string]bool)
+		}
+		if target, err := imp.resolve(ref); err == nil && !visited[ref] {
+			visited[ref] = true
+			parts = append(parts, target)
+		}
+	}
+	for _, part := range parts {
+		for key := range imp.requiredProperties(part, visited) {
+			required[key] = true
+		}
+	}
+	return required
+}
+
+// schemaType reads the type of a schema, inferring it from the other keywords when it is missing
+func (imp *schemaImporter) schemaType(schema map[string]any, path string) DataType {
+	switch declared := schema["type"].(type) {
+	case string:
+		return DataType(declared)
+	case []any:
+		var types []DataType
+		for _, value := range declared {
+			if name, ok := value.(string); ok && name != string(Null) {
+				types = append(types, DataType(name))
+			}
+		}
+		if len(types) > 0 && len(types) < len(declared) {
+			imp.report(path, "type", "null is dropped, the value is always generated")
+		}
+		if len(types) == 0 {
+			return Null
+		}
+		if len(types) > 1 {
+			imp.report(path, "type", fmt.Sprintf("only the first of several types is kept: %s", types[0]))
+		}
+		return types[0]
+	}
+
+	for _, keyword := range []string{"properties", "additionalProperties", "propertyNames"} {
+		if _, ok := schema[keyword]; ok {
+			return Object
+		}
+	}
+	if _, ok := schema["items"]; ok {
+		return Array
+	}
+	if value, ok := schema["const"]; ok {
+		return valueType(normaliseSchemaValue(value))
+	}
+	if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+		return valueType(normaliseSchemaValue(values[0]))
+	}
+	if _, ok := schema["contentEncoding"]; ok {
+		return String
+	}
+	return ""
+}
+
+// schemaInstruction builds an instruction from the description, format, enum and const of a schema
+func schemaInstruction(schema map[string]any) string {
+	instruction, _ := schema["description"].(string)
+	if instruction == "" {
+		instruction, _ = schema["title"].(string)
+	}
+	if format, ok := schema["format"].(string); ok {
+		instruction = joinInstruction(instruction, "Format: "+format+".")
+	}
+	if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+		normalised := make([]any, len(values))
+		for i, value := range values {
+			normalised[i] = normaliseSchemaValue(value)
+		}
+		instruction = joinInstruction(instruction, "Must be one of: "+formatValues(normalised)+".")
+	}
+	if value, ok := schema["const"]; ok {
+		instruction = joinInstruction(instruction, "Must be exactly: "+formatValues([]any{normaliseSchemaValue(value)})+".")
+	}
+	return instruction
+}
+
+// mergeDefinitions layers overlay over base, as the keywords of a schema refine its $ref and allOf schemas
+func mergeDefinitions(base, overlay Definition) Definition {
+	merged := base
+	if overlay.Type != "" {
+		merged.Type = overlay.Type
+	}
+	if overlay.Instruction != "" {
+		merged.Instruction = overlay.Instruction
+	}
+	if len(overlay.Properties) > 0 {
+		properties := make(map[string]Definition, len(base.Properties)+len(overlay.Properties))
+		for key, property := range base.Properties {
+			properties[key] = property
+		}
+		for key, property := range overlay.Properties {
+			properties[key] = property
+		}
+		merged.Properties = properties
+	}
+	if overlay.Items != nil {
+		merged.Items = overlay.Items
+	}
+	if overlay.HashMap != nil {
+		merged.HashMap = overlay.HashMap
+	}
+	if overlay.DecisionPoint != nil {
+		merged.DecisionPoint = overlay.DecisionPoint
+	}
+	return merged
+}
+
+// isNullSchema reports whether a schema only accepts null
+func isNullSchema(schema map[string]any) bool {
+	switch declared := schema["type"].(type) {
+	case string:
+		return declared == string(Null)
+	case []any:
+		return len(declared) == 1 && declared[0] == string(Null)
+	}
+	return false
+}
+
+// normaliseSchemaValue turns numbers decoded as json.Number into int64 or float64
+func normaliseSchemaValue(value any) any {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if integer, err := number.Int64(); err == nil {
+		return integer
+	}
+	if float, err := number.Float64(); err == nil {
+		return float
+	}
+	return number.String()
+}
+
+// valueType returns the DataType of a JSON value
+func valueType(value any) DataType {
+	switch value.(type) {
+	case string:
+		return String
+	case bool:
+		return Boolean
+	case int64:
+		return Integer
+	case float64:
+		return Number
+	case []any:
+		return Array
+	case map[string]any:
+		return Object
+	case nil:
+		return Null
+	}
+	return ""
+}
+
+// formatValues renders values as JSON so strings stay distinguishable from numbers
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		formatted[i] = string(encoded)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func joinInstruction(instruction, addition string) string {
+	if instruction == "" {
+		return addition
+	}
+	if !strings.HasSuffix(instruction, ".") {
+		instruction += "."
+	}
+	return instruction + " " + addition
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,126 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFromJSONSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   Definition
+		issues []string //path and keyword of every issue reported, in order
+	}{
+		{
+			name:   "description and enum",
+			schema: `{"type":"string","description":"A level","enum":["low","high"]}`,
+			want:   Definition{Type: String, Instruction: `A level. Must be one of: "low", "high".`},
+		},
+		{
+			name:   "refs to defs",
+			schema: `{"type":"object","properties":{"home":{"$ref":"#/$defs/address"}},"required":["home"],"$defs":{"address":{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}}}`,
+			want: Definition{Type: Object, Properties: map[string]Definition{
+				"home": {Type: Object, Properties: map[string]Definition{"city": {Type: String}}},
+			}},
+		},
+		{
+			name:   "recursive ref",
+			schema: `{"$defs":{"node":{"type":"object","properties":{"next":{"$ref":"#/$defs/node"}},"required":["next"]}},"$ref":"#/$defs/node"}`,
+			want: Definition{Type: Object, Properties: map[string]Definition{
+				"next": {Type: String},
+			}},
+			issues: []string{"#/$defs/node/properties/next $ref"},
+		},
+		{
+			name:   "additionalProperties",
+			schema: `{"type":"object","propertyNames":{"description":"a city"},"additionalProperties":{"type":"integer"}}`,
+			want:   Definition{Type: Map, HashMap: &HashMap{KeyInstruction: "a city", FieldDefinition: &Definition{Type: Integer}}},
+		},
+		{
+			name:   "base64 string",
+			schema: `{"type":"string","contentEncoding":"base64"}`,
+			want:   Definition{Type: Byte},
+		},
+		{
+			name:   "nullable type",
+			schema: `{"type":["integer","null"]}`,
+			want:   Definition{Type: Integer},
+			issues: []string{"# type"},
+		},
+		{
+			name:   "oneOf with discriminator",
+			schema: `{"oneOf":[{"type":"object","properties":{"kind":{"const":"cat"},"lives":{"type":"integer"}},"required":["kind","lives"]},{"type":"object","properties":{"kind":{"const":"dog"},"breed":{"type":"string"}},"required":["kind","breed"]}]}`,
+			want: Definition{
+				Type:       Object,
+				Properties: map[string]Definition{"kind": {Type: String, Instruction: `Must be one of: "cat", "dog".`}},
+				DecisionPoint: &DecisionPoint{Name: "kind", Strategy: RouteByField, Branches: []ConditionalBranch{
+					{Name: "cat", Conditions: []Condition{{Field: "kind", Operator: OpEqual, Value: "cat"}}, Then: Definition{Type: Object, Properties: map[string]Definition{"lives": {Type: Integer}}}},
+					{Name: "dog", Conditions: []Condition{{Field: "kind", Operator: OpEqual, Value: "dog"}}, Then: Definition{Type: Object, Properties: map[string]Definition{"breed": {Type: String}}}},
+				}},
+			},
+		},
+		{
+			name:   "anyOf without discriminator",
+			schema: `{"anyOf":[{"type":"string","description":"a word"},{"type":"integer"}]}`,
+			want:   Definition{Type: String, Instruction: "Generate one of the following: (1) a word; (2) integer."},
+			issues: []string{"# anyOf"},
+		},
+		{
+			name:   "unsupported keyword and optional property",
+			schema: `{"type":"object","properties":{"name":{"type":"string","minLength":2}}}`,
+			want:   Definition{Type: Object, Properties: map[string]Definition{"name": {Type: String}}},
+			issues: []string{"#/properties/name minLength", "# required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issues, err := FromJSONSchema([]byte(tt.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("definition = %#v, want %#v", *got, tt.want)
+			}
+			var reported []string
+			for _, issue := range issues {
+				reported = append(reported, issue.Path+" "+issue.Keyword)
+			}
+			if !reflect.DeepEqual(reported, tt.issues) {
+				t.Errorf("issues = %v, want %v", issues, tt.issues)
+			}
+		})
+	}
+}
+
+func TestFromJSONSchemaInvalid(t *testing.T) {
+	if _, _, err := FromJSONSchema([]byte(`{"type":`)); err == nil {
+		t.Error("invalid JSON was imported")
+	}
+}
+
+func TestFromJSONSchemaRoundTrip(t *testing.T) {
+	defs := []Definition{
+		{Type: Object, Instruction: "A person", Properties: map[string]Definition{
+			"name": {Type: String, Instruction: "Their name"},
+			"age":  {Type: Integer},
+			"tags": {Type: Array, Items: &Definition{Type: String}},
+		}},
+		{Type: Map, HashMap: &HashMap{KeyInstruction: "a city", FieldDefinition: &Definition{Type: Number}}},
+		{Type: Byte, Instruction: "An avatar"},
+	}
+	for _, def := range defs {
+		data, err := json.Marshal(def.ToJSONSchema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, issues, err := FromJSONSchema(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*got, def) || len(issues) > 0 {
+			t.Errorf("round trip of %s = %#v with issues %v, want %#v", data, *got, issues, def)
+		}
+	}
+}
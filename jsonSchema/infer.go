@@ -0,0 +1,286 @@
+package jsonSchema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// InstructionPlaceholder starts every instruction written by InferFromExamples, so the ones left to fill in are easy to find
+const InstructionPlaceholder = "TODO:"
+
+// maxExampleLength caps the length in runes of the sample values quoted in placeholder instructions
+const maxExampleLength = 40
+
+// dynamicKeyPattern matches keys that are data rather than field names, such as ids, UUIDs and dates
+var dynamicKeyPattern = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|\d{4}-\d{2}-\d{2}.*)$`)
+
+// InferFromExamples builds a Definition from sample JSON documents of the same object type.
+// The samples are merged: whole numbers become Integer and any fraction makes the field a Number, arrays of objects
+// merge every element, and objects whose keys are ids or dates, or never repeat between samples, become a Map.
+// Every Definition is given a placeholder instruction starting with InstructionPlaceholder, noting fields that are
+// optional or nullable in the samples so they can be reviewed before the Definition is used.
+func InferFromExamples(docs ...[]byte) (*Definition, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("at least one example is required")
+	}
+
+	root := &inferredShape{}
+	for i, doc := range docs {
+		decoder := json.NewDecoder(bytes.NewReader(doc))
+		decoder.UseNumber()
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("error decoding example %d: %v", i, err)
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("error decoding example %d: unexpected data after the JSON value", i)
+		}
+		root.merge(shapeOf(value))
+	}
+
+	def := root.definition("", root.seen)
+	return &def, nil
+}
+
+// inferredShape accumulates what has been observed of a single value across the samples
+type inferredShape struct {
+	seen       int // number of times the value was present
+	kinds      map[DataType]int
+	properties map[string]*inferredShape
+	objects    int // number of times the value was an object
+	items      *inferredShape
+	example    any // the first primitive value observed, quoted in the instruction
+}
+
+// shapeOf describes a single decoded JSON value
+func shapeOf(value any) *inferredShape {
+	shape := &inferredShape{seen: 1, kinds: make(map[DataType]int)}
+	switch v := value.(type) {
+	case map[string]any:
+		shape.kinds[Object]++
+		shape.objects = 1
+		shape.properties = make(map[string]*inferredShape, len(v))
+		for key, field := range v {
+			shape.properties[key] = shapeOf(field)
+		}
+	case []any:
+		shape.kinds[Array]++
+		for _, item := range v {
+			if shape.items == nil {
+				shape.items = &inferredShape{}
+			}
+			shape.items.merge(shapeOf(item))
+		}
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			shape.kinds[Number]++
+		} else {
+			shape.kinds[Integer]++
+		}
+		shape.example = v
+	case string:
+		shape.kinds[String]++
+		shape.example = v
+	case bool:
+		shape.kinds[Boolean]++
+		shape.example = v
+	case nil:
+		shape.kinds[Null]++
+	}
+	return shape
+}
+
+// merge adds the observations of other to the shape, copying rather than sharing other's children
+func (s *inferredShape) merge(other *inferredShape) {
+	if s.kinds == nil {
+		s.kinds = make(map[DataType]int)
+	}
+	s.seen += other.seen
+	s.objects += other.objects
+	for kind, count := range other.kinds {
+		s.kinds[kind] += count
+	}
+	if s.example == nil {
+		s.example = other.example
+	}
+
+	for key, field := range other.properties {
+		if s.properties == nil {
+			s.properties = make(map[string]*inferredShape)
+		}
+		if s.properties[key] == nil {
+			s.properties[key] = &inferredShape{}
+		}
+		s.properties[key].merge(field)
+	}
+	if other.items != nil {
+		if s.items == nil {
+			s.items = &inferredShape{}
+		}
+		s.items.merge(other.items)
+	}
+}
+
+// dataType picks the type of the value, widening Integer to Number and falling back to String when the samples disagree
+func (s *inferredShape) dataType() (DataType, bool) {
+	var kinds []DataType
+	for kind := range s.kinds {
+		if kind != Null {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	switch {
+	case len(kinds) == 0:
+		return String, true
+	case len(kinds) == 1:
+		return kinds[0], true
+	case len(kinds) == 2 && s.kinds[Integer] > 0 && s.kinds[Number] > 0:
+		return Number, true
+	}
+	return String, false
+}
+
+// isDynamicMap reports whether the keys of an object are data rather than field names
+func (s *inferredShape) isDynamicMap() bool {
+	if len(s.properties) == 0 {
+		return false
+	}
+
+	dynamicKeys, repeatedKeys := true, false
+	for key, field := range s.properties {
+		if !dynamicKeyPattern.MatchString(key) {
+			dynamicKeys = false
+		}
+		if field.seen > 1 {
+			repeatedKeys = true
+		}
+	}
+	if dynamicKeys {
+		return true
+	}
+	// keys that never repeat between several samples are unlikely to be field names
+	return s.objects > 1 && !repeatedKeys && len(s.properties) > s.objects
+}
+
+// definition converts the shape, present in seen of the parent's total observations
+func (s *inferredShape) definition(path string, total int) Definition {
+	dataType, consistent := s.dataType()
+	def := Definition{Type: dataType}
+
+	var notes []string
+	if !consistent {
+		notes = append(notes, "mixed types in the examples: "+strings.Join(s.kindNames(), ", "))
+	}
+	if s.seen < total {
+		notes = append(notes, fmt.Sprintf("optional, present in %d of %d examples", s.seen, total))
+	}
+	if s.kinds[Null] > 0 {
+		if len(s.kinds) == 1 {
+			notes = append(notes, "always null in the examples")
+		} else {
+			notes = append(notes, "nullable")
+		}
+	}
+
+	switch dataType {
+	case Object:
+		if s.isDynamicMap() {
+			// merge in key order so the quoted example is the same on every run
+			field := &inferredShape{}
+			for _, key := range s.sortedKeys() {
+				field.merge(s.properties[key])
+			}
+			fieldDefinition := field.definition(joinInferredPath(path, "*"), field.seen)
+			def.Type = Map
+			def.HashMap = &HashMap{
+				KeyInstruction:  fmt.Sprintf("%s describe the keys of %s, e.g. %s", InstructionPlaceholder, displayInferredPath(path), s.sampleKeys()),
+				FieldDefinition: &fieldDefinition,
+			}
+			break
+		}
+		def.Properties = make(map[string]Definition, len(s.properties))
+		for key, field := range s.properties {
+			def.Properties[key] = field.definition(joinInferredPath(path, key), s.objects)
+		}
+	case Array:
+		if s.items == nil {
+			notes = append(notes, "always empty in the examples")
+			def.Items = &Definition{Type: String, Instruction: fmt.Sprintf("%s describe the items of %s", InstructionPlaceholder, displayInferredPath(path))}
+			break
+		}
+		items := s.items.definition(path+"[]", s.items.seen)
+		def.Items = &items
+	}
+
+	def.Instruction = s.instruction(path, notes)
+	return def
+}
+
+// instruction writes the placeholder instruction of the value, quoting a sample value where there is one
+func (s *inferredShape) instruction(path string, notes []string) string {
+	instruction := fmt.Sprintf("%s describe %s", InstructionPlaceholder, displayInferredPath(path))
+	if s.example != nil {
+		example, _ := json.Marshal(s.example)
+		quoted := string(example)
+		if runes := []rune(quoted); len(runes) > maxExampleLength {
+			quoted = string(runes[:maxExampleLength]) + "..."
+		}
+		instruction += ", e.g. " + quoted
+	}
+	if len(notes) > 0 {
+		instruction += " (" + strings.Join(notes, "; ") + ")"
+	}
+	return instruction
+}
+
+func (s *inferredShape) kindNames() []string {
+	names := make([]string, 0, len(s.kinds))
+	for kind := range s.kinds {
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *inferredShape) sortedKeys() []string {
+	keys := make([]string, 0, len(s.properties))
+	for key := range s.properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sampleKeys quotes up to three keys of a map
+func (s *inferredShape) sampleKeys() string {
+	keys := s.sortedKeys()
+	if len(keys) > 3 {
+		keys = keys[:3]
+	}
+	for i, key := range keys {
+		keys[i] = fmt.Sprintf("%q", key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+func joinInferredPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayInferredPath(path string) string {
+	if path == "" {
+		return "the object"
+	}
+	return path
+}
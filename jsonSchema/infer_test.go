@@ -0,0 +1,143 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// definitionTypes lists the type of every Definition of the tree by path
+func definitionTypes(t *testing.T, def *Definition) map[string]DataType {
+	t.Helper()
+	types := make(map[string]DataType)
+	err := Walk(def, Visitor{Pre: func(path string, d *Definition) error {
+		types[path] = d.Type
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types
+}
+
+func TestInferFromExamples(t *testing.T) {
+	tests := []struct {
+		name  string
+		docs  []string
+		types map[string]DataType
+		notes map[string]string //a note expected in the instruction of the path
+	}{
+		{
+			name:  "integer widened to number",
+			docs:  []string{`{"count":1,"ratio":2}`, `{"count":3,"ratio":2.5}`},
+			types: map[string]DataType{"$": Object, "$.count": Integer, "$.ratio": Number},
+		},
+		{
+			name:  "exponent is a number",
+			docs:  []string{`{"big":1e3}`},
+			types: map[string]DataType{"$": Object, "$.big": Number},
+		},
+		{
+			name:  "optional and nullable fields",
+			docs:  []string{`{"name":"a","nick":"b","age":null}`, `{"name":"c","age":3}`},
+			types: map[string]DataType{"$": Object, "$.name": String, "$.nick": String, "$.age": Integer},
+			notes: map[string]string{"$.nick": "optional, present in 1 of 2 examples", "$.age": "nullable"},
+		},
+		{
+			name:  "array of objects",
+			docs:  []string{`{"items":[{"id":1},{"id":2,"tag":"x"}]}`},
+			types: map[string]DataType{"$": Object, "$.items": Array, "$.items[]": Object, "$.items[].id": Integer, "$.items[].tag": String},
+			notes: map[string]string{"$.items[].tag": "optional, present in 1 of 2 examples"},
+		},
+		{
+			name:  "id keys become a map",
+			docs:  []string{`{"scores":{"101":1.5,"102":2}}`},
+			types: map[string]DataType{"$": Object, "$.scores": Map, "$.scores.*": Number},
+		},
+		{
+			name:  "keys that never repeat become a map",
+			docs:  []string{`{"byCity":{"paris":1,"rome":2}}`, `{"byCity":{"oslo":3,"lima":4}}`},
+			types: map[string]DataType{"$": Object, "$.byCity": Map, "$.byCity.*": Integer},
+		},
+		{
+			name:  "mixed types fall back to string",
+			docs:  []string{`{"v":1}`, `{"v":"one"}`},
+			types: map[string]DataType{"$": Object, "$.v": String},
+			notes: map[string]string{"$.v": "mixed types in the examples: integer, string"},
+		},
+		{
+			name:  "empty array",
+			docs:  []string{`{"tags":[]}`},
+			types: map[string]DataType{"$": Object, "$.tags": Array, "$.tags[]": String},
+			notes: map[string]string{"$.tags": "always empty in the examples"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs := make([][]byte, len(tt.docs))
+			for i, doc := range tt.docs {
+				docs[i] = []byte(doc)
+			}
+			def, err := InferFromExamples(docs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if types := definitionTypes(t, def); !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("types = %v, want %v", types, tt.types)
+			}
+
+			instructions := make(map[string]string)
+			Walk(def, Visitor{Pre: func(path string, d *Definition) error {
+				instructions[path] = d.Instruction
+				if !strings.HasPrefix(d.Instruction, InstructionPlaceholder) {
+					t.Errorf("%s instruction %q has no placeholder", path, d.Instruction)
+				}
+				return nil
+			}})
+			for path, note := range tt.notes {
+				if !strings.Contains(instructions[path], note) {
+					t.Errorf("%s instruction = %q, want it to note %q", path, instructions[path], note)
+				}
+			}
+		})
+	}
+}
+
+func TestInferFromExamplesErrors(t *testing.T) {
+	if _, err := InferFromExamples(); err == nil {
+		t.Error("no examples gave no error")
+	}
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"truncated", `{`, "error decoding example 1: unexpected EOF"},
+		{"second value", `{"a":1} {"a":2}`, "error decoding example 1: unexpected data after the JSON value"},
+		{"stray brace", `{"a":1}}`, "error decoding example 1: unexpected data after the JSON value"},
+		{"trailing text", `[1] x`, "error decoding example 1: unexpected data after the JSON value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := InferFromExamples([]byte(`{}`), []byte(tt.doc)); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := InferFromExamples([]byte("{\"a\":1}\n\t ")); err != nil {
+		t.Errorf("trailing whitespace gave %v", err)
+	}
+}
+
+func TestInferExampleTruncation(t *testing.T) {
+	long := strings.Repeat("é", maxExampleLength)
+	def, err := InferFromExamples([]byte(`{"name":"` + long + `"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the quoted example is cut after maxExampleLength runes, the opening quote being the first
+	want := `, e.g. "` + strings.Repeat("é", maxExampleLength-1) + "..."
+	if instruction := def.Properties["name"].Instruction; !strings.HasSuffix(instruction, want) {
+		t.Errorf("instruction = %q, want it to end with %q", instruction, want)
+	}
+}
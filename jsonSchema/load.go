@@ -0,0 +1,450 @@
+package jsonSchema
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Directives recognised by the loaders in place of a value
+const (
+	IncludeDirective = "$include" //replaced by the contents of a YAML, TOML or JSON file, optionally followed by a #/json/pointer
+	FileDirective    = "$file"    //replaced by the text of a file, such as a long instruction
+)
+
+// LoadError is a problem found while loading a schema file, pointing at the file and line it was found at
+type LoadError struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"` //0 when the line is not known
+	Path   string `json:"path,omitempty"` //dotted path of the field, ie properties.title.instruction
+	Reason string `json:"reason"`
+}
+
+// Error implements the error interface
+func (e *LoadError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %s", location, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, e.Path, e.Reason)
+}
+
+// LoadDefinitionFile loads a Definition from a YAML (.yaml, .yml), TOML (.toml) or JSON (.json) file.
+//
+// Any value written as a map with a single $file key is replaced by the text of that file, and a map with an
+// $include key by the contents of the included schema file, with the other keys of the map merged over it:
+//
+//	properties:
+//	  address:
+//	    $include: shared/address.yaml
+//	  summary:
+//	    type: string
+//	    instruction:
+//	      $file: prompts/summary.md
+//
+// Paths are relative to the file they are written in. Errors are LoadErrors pointing at the file and line.
+func LoadDefinitionFile(name string) (*Definition, error) {
+	var def Definition
+	if err := newOSLoader().load(name, &def); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// LoadComplexSystemFile loads a ComplexSystem from a YAML, TOML or JSON file, see LoadDefinitionFile
+func LoadComplexSystemFile(name string) (*ComplexSystem, error) {
+	var system ComplexSystem
+	if err := newOSLoader().load(name, &system); err != nil {
+		return nil, err
+	}
+	return &system, nil
+}
+
+// LoadDefinitionFS is LoadDefinitionFile reading from fsys, such as an embed.FS
+func LoadDefinitionFS(fsys fs.FS, name string) (*Definition, error) {
+	var def Definition
+	if err := newFSLoader(fsys).load(name, &def); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// LoadComplexSystemFS is LoadComplexSystemFile reading from fsys, such as an embed.FS
+func LoadComplexSystemFS(fsys fs.FS, name string) (*ComplexSystem, error) {
+	var system ComplexSystem
+	if err := newFSLoader(fsys).load(name, &system); err != nil {
+		return nil, err
+	}
+	return &system, nil
+}
+
+// fileLoader loads schema files and the files they include
+type fileLoader struct {
+	readFile func(name string) ([]byte, error)
+	resolve  func(from, ref string) string //resolves a reference relative to the file it is written in
+	stack    []string                      //files being loaded, to detect include cycles
+}
+
+func newOSLoader() *fileLoader {
+	return &fileLoader{
+		readFile: os.ReadFile,
+		resolve: func(from, ref string) string {
+			if filepath.IsAbs(ref) {
+				return ref
+			}
+			return filepath.Join(filepath.Dir(from), filepath.FromSlash(ref))
+		},
+	}
+}
+
+func newFSLoader(fsys fs.FS) *fileLoader {
+	return &fileLoader{
+		readFile: func(name string) ([]byte, error) { return fs.ReadFile(fsys, name) },
+		resolve:  func(from, ref string) string { return path.Join(path.Dir(from), ref) },
+	}
+}
+
+// load decodes the file into target after checking every key and value against the fields of the target
+func (l *fileLoader) load(name string, target any) error {
+	value, positions, err := l.loadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := checkShape(value, reflect.TypeOf(target).Elem(), ""); err != nil {
+		return positions.errorAt(name, err.pointer, err.reason)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return &LoadError{File: name, Reason: fmt.Sprintf("error encoding schema: %v", err)}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return &LoadError{File: name, Reason: fmt.Sprintf("error decoding schema: %v", err)}
+	}
+	return nil
+}
+
+// loadFile decodes a schema file into generic values with its directives resolved
+func (l *fileLoader) loadFile(name string) (any, positions, error) {
+	data, err := l.readFile(name)
+	if err != nil {
+		return nil, nil, &LoadError{File: name, Reason: err.Error()}
+	}
+	return l.decodeFile(name, data)
+}
+
+// decodeFile decodes the contents of a schema file, resolving its directives
+func (l *fileLoader) decodeFile(name string, data []byte) (any, positions, error) {
+	l.stack = append(l.stack, name)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	var err error
+	var value any
+	var found positions
+	switch strings.ToLower(path.Ext(filepath.ToSlash(name))) {
+	case ".yaml", ".yml":
+		value, found, err = decodeYAML(name, data)
+	case ".json":
+		value, found, err = decodeJSON(name, data)
+	case ".toml":
+		value, found, err = decodeTOML(name, data)
+	default:
+		return nil, nil, &LoadError{File: name, Reason: "unsupported file type, expected .yaml, .yml, .toml or .json"}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value, err = l.resolveDirectives(name, value, "", found)
+	return value, found, err
+}
+
+// resolveDirectives replaces $file and $include maps in the value, recording the positions of included values
+func (l *fileLoader) resolveDirectives(name string, value any, pointer string, found positions) (any, error) {
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			resolved, err := l.resolveDirectives(name, item, fmt.Sprintf("%s/%d", pointer, i), found)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	case map[string]any:
+	default:
+		return value, nil
+	}
+
+	object := value.(map[string]any)
+	for _, key := range sortedKeys(object) {
+		if key == IncludeDirective || key == FileDirective {
+			continue
+		}
+		resolved, err := l.resolveDirectives(name, object[key], pointer+"/"+escapePointerToken(key), found)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = resolved
+	}
+
+	if ref, ok := object[FileDirective]; ok {
+		directivePointer := pointer + "/" + escapePointerToken(FileDirective)
+		if len(object) != 1 {
+			return nil, found.errorAt(name, directivePointer, FileDirective+" cannot be combined with other keys")
+		}
+		file, ok := ref.(string)
+		if !ok {
+			return nil, found.errorAt(name, directivePointer, FileDirective+" requires a file name")
+		}
+		text, err := l.readFile(l.resolve(name, file))
+		if err != nil {
+			return nil, found.errorAt(name, directivePointer, err.Error())
+		}
+		return strings.TrimRight(string(text), "\r\n"), nil
+	}
+
+	ref, ok := object[IncludeDirective]
+	if !ok {
+		return object, nil
+	}
+	directivePointer := pointer + "/" + escapePointerToken(IncludeDirective)
+	include, ok := ref.(string)
+	if !ok {
+		return nil, found.errorAt(name, directivePointer, IncludeDirective+" requires a file name")
+	}
+	file, fragment, _ := strings.Cut(include, "#")
+
+	fragmentPath, err := parsePointer(fragment)
+	if err != nil {
+		return nil, found.errorAt(name, directivePointer, err.Error())
+	}
+	target := l.resolve(name, file)
+	if slices.Contains(l.stack, target) {
+		return nil, found.errorAt(name, directivePointer, "include cycle "+strings.Join(append(l.stack, target), " -> "))
+	}
+	// a file that cannot be read is reported where it is included, errors inside it where they are written
+	data, err := l.readFile(target)
+	if err != nil {
+		return nil, found.errorAt(name, directivePointer, err.Error())
+	}
+	included, includedPositions, err := l.decodeFile(target, data)
+	if err != nil {
+		return nil, err
+	}
+	if included, err = pointerGet(included, fragmentPath); err != nil {
+		return nil, found.errorAt(name, directivePointer, fmt.Sprintf("%s: %v", include, err))
+	}
+	delete(object, IncludeDirective)
+
+	// the keys next to $include override the included values, so their positions are kept
+	for includedPointer, position := range includedPositions {
+		rest, ok := strings.CutPrefix(includedPointer, fragment)
+		if !ok || rest == "" || (rest[0] != '/') {
+			continue
+		}
+		key, _, _ := strings.Cut(rest[1:], "/")
+		if _, overridden := object[unescapePointerToken(key)]; !overridden {
+			found[pointer+rest] = position
+		}
+	}
+	if len(object) == 0 {
+		return included, nil
+	}
+	if _, ok := included.(map[string]any); !ok {
+		return nil, found.errorAt(name, directivePointer, fmt.Sprintf("%s is not a map, so it cannot be combined with other keys", include))
+	}
+	return mergePatch(included, object), nil
+}
+
+// positions maps the JSON pointers of values to where they were written
+type positions map[string]position
+
+type position struct {
+	file string
+	line int
+}
+
+// errorAt returns a LoadError for the value at the pointer, at the position of the nearest value that has one
+func (p positions) errorAt(file, pointer, reason string) *LoadError {
+	err := &LoadError{File: file, Path: displayPointer(pointer), Reason: reason}
+	for at := pointer; ; {
+		if found, ok := p[at]; ok {
+			err.File, err.Line = found.file, found.line
+			return err
+		}
+		if at == "" {
+			return err
+		}
+		at = at[:strings.LastIndex(at, "/")]
+	}
+}
+
+// shapeError is a value that does not fit the field it is decoded into
+type shapeError struct {
+	pointer string
+	reason  string
+}
+
+// checkShape checks a generic value against the type it will be decoded into, so mistakes can be reported
+// with the position of the value rather than as a JSON decoding error
+func checkShape(value any, t reflect.Type, pointer string) *shapeError {
+	if value == nil {
+		return nil
+	}
+	fail := func(format string, args ...any) *shapeError {
+		return &shapeError{pointer: pointer, reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return checkShape(value, t.Elem(), pointer)
+	case reflect.Interface:
+		return nil
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fail("expected a map, got %s", describeValue(value))
+		}
+		for _, key := range sortedKeys(object) {
+			field, ok := structFieldByJSONName(t, key)
+			if !ok {
+				return &shapeError{pointer: pointer + "/" + escapePointerToken(key), reason: fmt.Sprintf("unknown field %q", key)}
+			}
+			if err := checkShape(object[key], field.Type, pointer+"/"+escapePointerToken(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fail("expected a map, got %s", describeValue(value))
+		}
+		for _, key := range sortedKeys(object) {
+			if err := checkShape(object[key], t.Elem(), pointer+"/"+escapePointerToken(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			encoded, ok := value.(string)
+			if !ok {
+				return fail("expected base64 encoded bytes, got %s", describeValue(value))
+			}
+			if _, err := base64.StdEncoding.DecodeString(encoded); err != nil {
+				return fail("invalid base64: %v", err)
+			}
+			return nil
+		}
+		list, ok := value.([]any)
+		if !ok {
+			return fail("expected a list, got %s", describeValue(value))
+		}
+		for i, item := range list {
+			if err := checkShape(item, t.Elem(), fmt.Sprintf("%s/%d", pointer, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return fail("expected a string, got %s", describeValue(value))
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return fail("expected true or false, got %s", describeValue(value))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := toComparableNumber(reflect.ValueOf(value))
+		if !ok || number != float64(int64(number)) {
+			return fail("expected an integer, got %s", describeValue(value))
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := toComparableNumber(reflect.ValueOf(value)); !ok {
+			return fail("expected a number, got %s", describeValue(value))
+		}
+	}
+	return nil
+}
+
+// structFieldByJSONName finds the field a key decodes into, matching case-insensitively as encoding/json does
+func structFieldByJSONName(t reflect.Type, key string) (reflect.StructField, bool) {
+	var folded *reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := jsonFieldName(field)
+		if name == key {
+			return field, true
+		}
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = &field
+		}
+	}
+	if folded != nil {
+		return *folded, true
+	}
+	return reflect.StructField{}, false
+}
+
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		return "a map"
+	case []any:
+		return "a list"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(value)
+}
+
+// sortedKeys returns the keys of the map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// displayPointer turns a JSON pointer into the dotted path used in LoadError, with list indexes as [0]
+func displayPointer(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+	var display strings.Builder
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if _, err := strconv.Atoi(token); err == nil {
+			display.WriteString("[" + token + "]")
+			continue
+		}
+		if display.Len() > 0 {
+			display.WriteString(".")
+		}
+		display.WriteString(unescapePointerToken(token))
+	}
+	return display.String()
+}
@@ -0,0 +1,236 @@
+package jsonSchema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlErrorLine matches the line yaml.v3 reports syntax errors at
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// decodeYAML decodes a YAML file into generic values, recording the line of every key and list item
+func decodeYAML(name string, data []byte) (any, positions, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, nil, &LoadError{File: name, Line: line, Reason: match[2]}
+		}
+		return nil, nil, &LoadError{File: name, Reason: err.Error()}
+	}
+	if len(document.Content) == 0 {
+		return nil, nil, &LoadError{File: name, Reason: "file is empty"}
+	}
+
+	found := positions{"": {file: name, line: document.Content[0].Line}}
+	value, err := yamlValue(name, document.Content[0], "", found)
+	return value, found, err
+}
+
+func yamlValue(name string, node *yaml.Node, pointer string, found positions) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlValue(name, node.Alias, pointer, found)
+	case yaml.MappingNode:
+		object := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind != yaml.ScalarNode {
+				return nil, &LoadError{File: name, Line: key.Line, Path: displayPointer(pointer), Reason: "map keys must be strings"}
+			}
+			if key.Value == "<<" && key.Tag == "!!merge" {
+				return nil, &LoadError{File: name, Line: key.Line, Path: displayPointer(pointer), Reason: "merge keys are not supported, use " + IncludeDirective}
+			}
+			childPointer := pointer + "/" + escapePointerToken(key.Value)
+			found[childPointer] = position{file: name, line: key.Line}
+			child, err := yamlValue(name, value, childPointer, found)
+			if err != nil {
+				return nil, err
+			}
+			object[key.Value] = child
+		}
+		return object, nil
+	case yaml.SequenceNode:
+		list := make([]any, len(node.Content))
+		for i, item := range node.Content {
+			itemPointer := fmt.Sprintf("%s/%d", pointer, i)
+			found[itemPointer] = position{file: name, line: item.Line}
+			child, err := yamlValue(name, item, itemPointer, found)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = child
+		}
+		return list, nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, &LoadError{File: name, Line: node.Line, Path: displayPointer(pointer), Reason: err.Error()}
+	}
+	return value, nil
+}
+
+// decodeJSON decodes a JSON file into generic values, recording the line of every key and list item
+func decodeJSON(name string, data []byte) (any, positions, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	lineAt := func(offset int64) int { return 1 + bytes.Count(data[:offset], []byte("\n")) }
+	fail := func(err error) error {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return &LoadError{File: name, Line: lineAt(syntaxErr.Offset), Reason: syntaxErr.Error()}
+		}
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return &LoadError{File: name, Line: lineAt(decoder.InputOffset()), Reason: err.Error()}
+	}
+
+	if !decoder.More() {
+		return nil, nil, &LoadError{File: name, Reason: "file is empty"}
+	}
+	found := positions{}
+	var value func(pointer string) (any, error)
+	value = func(pointer string) (any, error) {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fail(err)
+		}
+		if _, ok := found[pointer]; !ok {
+			found[pointer] = position{file: name, line: lineAt(decoder.InputOffset())}
+		}
+		switch token {
+		case json.Delim('{'):
+			object := make(map[string]any)
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, fail(err)
+				}
+				if _, ok := object[key.(string)]; ok {
+					return nil, &LoadError{File: name, Line: lineAt(decoder.InputOffset()), Path: displayPointer(pointer), Reason: fmt.Sprintf("key %q is defined twice", key)}
+				}
+				childPointer := pointer + "/" + escapePointerToken(key.(string))
+				found[childPointer] = position{file: name, line: lineAt(decoder.InputOffset())}
+				if object[key.(string)], err = value(childPointer); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, fail(err)
+			}
+			return object, nil
+		case json.Delim('['):
+			list := []any{}
+			for i := 0; decoder.More(); i++ {
+				item, err := value(fmt.Sprintf("%s/%d", pointer, i))
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, fail(err)
+			}
+			return list, nil
+		}
+		return token, nil
+	}
+
+	document, err := value("")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, nil, &LoadError{File: name, Line: lineAt(decoder.InputOffset()), Reason: "unexpected data after the JSON value"}
+	}
+	return document, found, nil
+}
+
+// decodeTOML decodes a TOML file into generic values.
+// The decoder does not report where keys are written, so their lines are found by tomlPositions.
+func decodeTOML(name string, data []byte) (any, positions, error) {
+	var document map[string]any
+	metadata, err := toml.Decode(string(data), &document)
+	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, nil, &LoadError{File: name, Line: parseErr.Position.Line, Reason: parseErr.Message}
+		}
+		return nil, nil, &LoadError{File: name, Reason: err.Error()}
+	}
+	return tomlValue(document), tomlPositions(name, data, metadata.Keys()), nil
+}
+
+// tomlValue converts the arrays of tables the TOML decoder returns to plain lists
+func tomlValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = tomlValue(child)
+		}
+		return v
+	case []map[string]any:
+		list := make([]any, len(v))
+		for i, child := range v {
+			list[i] = tomlValue(child)
+		}
+		return list
+	case []any:
+		for i, child := range v {
+			v[i] = tomlValue(child)
+		}
+		return v
+	}
+	return value
+}
+
+// tomlPositions finds the lines of the table headers and keys of a TOML file.
+// A line starts a statement only if the lines before it decode on their own, and the statement's key is then
+// the next one in keys, the keys of the whole file in the order the decoder found them. Keys inside inline
+// tables and arrays are not found, errors for them point at the enclosing key.
+func tomlPositions(name string, data []byte, keys []toml.Key) positions {
+	found := positions{"": {file: name, line: 1}}
+	tableCounts := make(map[string]int) //number of entries of each array of tables
+	offset := 0
+	for i, text := range strings.SplitAfter(string(data), "\n") {
+		line := i + 1
+		start := offset
+		offset += len(text)
+		text = strings.TrimSpace(text)
+		if !strings.HasPrefix(text, "[") && !strings.Contains(text, "=") {
+			continue
+		}
+		var prefix map[string]any
+		metadata, err := toml.Decode(string(data[:start]), &prefix)
+		if err != nil || len(metadata.Keys()) >= len(keys) {
+			continue
+		}
+
+		key := keys[len(metadata.Keys())]
+		pointer := ""
+		for j, part := range key {
+			pointer += "/" + escapePointerToken(part)
+			count, isArray := tableCounts[pointer]
+			if j == len(key)-1 && strings.HasPrefix(text, "[[") {
+				tableCounts[pointer] = count + 1
+				pointer += "/" + strconv.Itoa(count)
+			} else if isArray {
+				pointer += "/" + strconv.Itoa(count-1)
+			}
+			if _, ok := found[pointer]; !ok || j == len(key)-1 {
+				found[pointer] = position{file: name, line: line}
+			}
+		}
+	}
+	return found
+}
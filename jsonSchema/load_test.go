@@ -0,0 +1,233 @@
+package jsonSchema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+var loadFS = fstest.MapFS{
+	"article.yaml": {Data: []byte(`type: object
+instruction:
+  $file: prompts/article.md
+processingOrder: [title]
+properties:
+  title:
+    type: string
+    instruction: A title
+  author:
+    $include: shared/person.yaml
+    instruction: The author
+  editor:
+    $include: shared/people.json#/editor
+`)},
+	"article.toml": {Data: []byte(`type = "object"
+processingOrder = ["title"]
+
+[properties.title]
+type = "string"
+instruction = """
+A title
+on two lines"""
+`)},
+	"article.json":          {Data: []byte(`{"type":"string","instruction":"A name"}`)},
+	"prompts/article.md":    {Data: []byte("Write an article\n")},
+	"shared/person.yaml":    {Data: []byte("type: object\nproperties:\n  name:\n    type: string\n")},
+	"shared/people.json":    {Data: []byte(`{"editor":{"type":"string","instruction":"An editor"}}`)},
+	"bad/type.yaml":         {Data: []byte("type: object\nproperties:\n  title:\n    type: string\n    processingOrder: 3\n")},
+	"bad/unknown.yaml":      {Data: []byte("type: string\n\ninstructions: typo\n")},
+	"bad/missing.yaml":      {Data: []byte("type: object\nproperties:\n  a:\n    $include: nowhere.yaml\n")},
+	"bad/cycle.yaml":        {Data: []byte("type: object\nproperties:\n  a:\n    $include: cycle.yaml\n")},
+	"bad/file.yaml":         {Data: []byte("instruction:\n  $file: ../prompts/article.md\n  extra: true\n")},
+	"bad/inner.yaml":        {Data: []byte("type: object\nproperties:\n  a:\n    $include: inner/broken.yaml\n")},
+	"bad/inner/broken.yaml": {Data: []byte("type: string\ninstructions: typo\n")},
+	"bad/syntax.yaml":       {Data: []byte("type: [string\n")},
+	"bad/extension.schema":  {Data: []byte("{}")},
+	"system.yaml":           {Data: []byte("name: system\nrootSchema:\n  $include: article.json\n")},
+}
+
+func TestLoadDefinitionFS(t *testing.T) {
+	tests := []struct {
+		name string
+		want Definition
+	}{
+		{"article.yaml", Definition{
+			Type:            Object,
+			Instruction:     "Write an article",
+			ProcessingOrder: []string{"title"},
+			Properties: map[string]Definition{
+				"title":  {Type: String, Instruction: "A title"},
+				"author": {Type: Object, Instruction: "The author", Properties: map[string]Definition{"name": {Type: String}}},
+				"editor": {Type: String, Instruction: "An editor"},
+			},
+		}},
+		{"article.toml", Definition{
+			Type:            Object,
+			ProcessingOrder: []string{"title"},
+			Properties:      map[string]Definition{"title": {Type: String, Instruction: "A title\non two lines"}},
+		}},
+		{"article.json", Definition{Type: String, Instruction: "A name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadDefinitionFS(loadFS, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("loaded %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDefinitionFSErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		line int
+		path string
+	}{
+		{"bad/type.yaml", "bad/type.yaml", 5, "properties.title.processingOrder"},
+		{"bad/unknown.yaml", "bad/unknown.yaml", 3, "instructions"},
+		{"bad/missing.yaml", "bad/missing.yaml", 4, "properties.a.$include"},
+		{"bad/cycle.yaml", "bad/cycle.yaml", 4, "properties.a.$include"},
+		{"bad/file.yaml", "bad/file.yaml", 2, "instruction.$file"},
+		{"bad/inner.yaml", "bad/inner/broken.yaml", 2, "properties.a.instructions"},
+		{"bad/syntax.yaml", "bad/syntax.yaml", 0, ""},
+		{"bad/extension.schema", "bad/extension.schema", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadDefinitionFS(loadFS, tt.name)
+			var loadErr *LoadError
+			if !errors.As(err, &loadErr) {
+				t.Fatalf("error = %v, want a LoadError", err)
+			}
+			if loadErr.File != tt.file || (tt.line > 0 && loadErr.Line != tt.line) || loadErr.Path != tt.path {
+				t.Errorf("error = %+v, want %s:%d at %q", loadErr, tt.file, tt.line, tt.path)
+			}
+		})
+	}
+}
+
+func TestLoadComplexSystemFS(t *testing.T) {
+	system, err := LoadComplexSystemFS(loadFS, "system.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ComplexSystem{Name: "system", RootSchema: Definition{Type: String, Instruction: "A name"}}
+	if !reflect.DeepEqual(*system, want) {
+		t.Errorf("loaded %+v, want %+v", *system, want)
+	}
+}
+
+func TestLoadDefinitionFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, file := range map[string]string{
+		"schema.yaml": "type: string\ninstruction:\n  $file: prompt.txt\n",
+		"prompt.txt":  "From the disk",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(file), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	def, err := LoadDefinitionFile(filepath.Join(dir, "schema.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Instruction != "From the disk" {
+		t.Errorf("instruction = %q", def.Instruction)
+	}
+}
+
+func TestDecodeTOMLPositions(t *testing.T) {
+	data := `type = "object"
+instruction = """
+name = "not a key"
+[not.a.table]
+"""
+enum = [
+  "a = b",
+  "[c]",
+]
+["properties"."a]b"]
+type = "string"
+
+[[decisionPoint.branches]]
+name = "first"
+
+[[decisionPoint.branches]]
+name = "second"
+then = { type = "string" }
+`
+	_, found, err := decodeTOML("schema.toml", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := map[string]int{
+		"/type":                               1,
+		"/instruction":                        2,
+		"/enum":                               6,
+		"/properties/a]b":                     10,
+		"/properties/a]b/type":                11,
+		"/decisionPoint/branches/0":           13,
+		"/decisionPoint/branches/0/name":      14,
+		"/decisionPoint/branches/1":           16,
+		"/decisionPoint/branches/1/name":      17,
+		"/decisionPoint/branches/1/then":      18,
+		"/name":                               0,
+		"/not":                                0,
+		"/decisionPoint/branches/1/then/type": 0,
+	}
+	for pointer, want := range lines {
+		if got := found[pointer].line; got != want {
+			t.Errorf("line of %s = %d, want %d", pointer, got, want)
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	value, found, err := decodeJSON("schema.json", []byte("{\n  \"type\": \"object\",\n  \"enum\": [\n    \"a\",\n    \"b\"\n  ],\n  \"maxItems\": 3\n}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"type": "object", "enum": []any{"a", "b"}, "maxItems": 3.0}
+	if !reflect.DeepEqual(value, want) {
+		t.Errorf("value = %#v, want %#v", value, want)
+	}
+	for pointer, line := range map[string]int{"": 1, "/type": 2, "/enum": 3, "/enum/1": 5, "/maxItems": 7} {
+		if got := found[pointer].line; got != line {
+			t.Errorf("line of %q = %d, want %d", pointer, got, line)
+		}
+	}
+
+	failures := []struct {
+		name string
+		data string
+		line int
+	}{
+		{"empty", " \n", 0},
+		{"syntax", "{\n  \"type\": \"object\",\n  \"enum\": [\"a\" \"b\"]\n}", 3},
+		{"comment", "{\n  # a YAML comment\n  \"type\": \"object\"\n}", 2},
+		{"unquoted key", "{type: object}", 1},
+		{"duplicate key", "{\n  \"type\": \"object\",\n  \"type\": \"string\"\n}", 3},
+		{"trailing data", "{\"type\": \"object\"}\n{}", 2},
+		{"unterminated", "{\n  \"type\": \"object\"", 2},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeJSON("schema.json", []byte(tt.data))
+			loadErr, ok := err.(*LoadError)
+			if !ok {
+				t.Fatalf("error = %v, want a LoadError", err)
+			}
+			if loadErr.Line != tt.line {
+				t.Errorf("error = %v, want line %d", loadErr, tt.line)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package jsonSchema
+
+import "reflect"
+
+// Merge returns a copy of base with the overlay applied, neither Definition is modified.
+// It is meant for per-environment variants of a schema, such as a cheaper Model or EvaluationModel in staging.
+//
+//   - Fields that are set in the overlay replace those in base, zero values are treated as unset.
+//     Use ApplyMergePatch or ApplyJSONPatch to clear a field.
+//   - Properties, Dimensions and other maps are merged key by key, so the overlay only needs the properties it changes.
+//   - Pointers such as Items, ModelConfig and DecisionPoint are merged field by field.
+//   - DecisionPoint branches are matched by Name and merged, overlay branches without a match are appended.
+//   - Other slices, such as ProcessingOrder, SelectFields and Conditions, are replaced as a whole.
+func Merge(base, overlay Definition) Definition {
+	merged := base.Clone()
+	mergeValue(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(overlay))
+	return merged
+}
+
+var branchesType = reflect.TypeOf([]ConditionalBranch(nil))
+
+// mergeValue merges the overlay into dst, which must be settable and not shared with the caller
+func mergeValue(dst, overlay reflect.Value) {
+	switch overlay.Kind() {
+	case reflect.Struct:
+		for i := 0; i < overlay.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), overlay.Field(i))
+			}
+		}
+	case reflect.Pointer:
+		switch {
+		case overlay.IsNil():
+		case dst.IsNil():
+			dst.Set(deepCopy(overlay))
+		default:
+			mergeValue(dst.Elem(), overlay.Elem())
+		}
+	case reflect.Map:
+		if overlay.Len() == 0 {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(overlay.Type(), overlay.Len()))
+		}
+		iter := overlay.MapRange()
+		for iter.Next() {
+			existing := dst.MapIndex(iter.Key())
+			if !existing.IsValid() || !isMergeable(existing) {
+				dst.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+				continue
+			}
+			// map values are not addressable, so merge into a copy and store it back
+			merged := reflect.New(existing.Type()).Elem()
+			merged.Set(existing)
+			mergeValue(merged, iter.Value())
+			dst.SetMapIndex(iter.Key(), merged)
+		}
+	case reflect.Slice:
+		if overlay.Len() == 0 {
+			return
+		}
+		if overlay.Type() == branchesType {
+			dst.Set(reflect.ValueOf(mergeBranches(dst.Interface().([]ConditionalBranch), overlay.Interface().([]ConditionalBranch))))
+			return
+		}
+		dst.Set(deepCopy(overlay))
+	default:
+		if !overlay.IsZero() {
+			dst.Set(deepCopy(overlay))
+		}
+	}
+}
+
+// isMergeable reports whether a map value is merged with the overlay rather than replaced by it
+func isMergeable(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Pointer:
+		return true
+	}
+	return false
+}
+
+// mergeBranches merges overlay branches into the base branches with the same name and appends the rest
+func mergeBranches(base, overlay []ConditionalBranch) []ConditionalBranch {
+	merged := append([]ConditionalBranch(nil), base...)
+	for _, branch := range overlay {
+		index := -1
+		for i := range merged {
+			if branch.Name != "" && merged[i].Name == branch.Name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			merged = append(merged, deepCopy(reflect.ValueOf(branch)).Interface().(ConditionalBranch))
+			continue
+		}
+		mergeValue(reflect.ValueOf(&merged[index]).Elem(), reflect.ValueOf(branch))
+	}
+	return merged
+}
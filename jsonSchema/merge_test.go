@@ -0,0 +1,77 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := Definition{
+		Type:            Object,
+		Model:           "gpt-4o",
+		ProcessingOrder: []string{"title", "body"},
+		ModelConfig:     &ModelConfig{Temperature: 0.7, TopP: 0.9},
+		Properties: map[string]Definition{
+			"title": {Type: String, Instruction: "A title"},
+			"body":  {Type: String, Instruction: "The body"},
+		},
+		DecisionPoint: &DecisionPoint{Name: "route", Branches: []ConditionalBranch{
+			{Name: "short", Then: Definition{Type: String, Instruction: "Short"}},
+		}},
+	}
+	tests := []struct {
+		name    string
+		overlay Definition
+		check   func(t *testing.T, merged Definition)
+	}{
+		{"zero values keep base", Definition{}, func(t *testing.T, merged Definition) {
+			if !reflect.DeepEqual(merged, base) {
+				t.Errorf("merged = %+v, want base", merged)
+			}
+		}},
+		{"scalar replaced", Definition{Model: "gpt-4o-mini"}, func(t *testing.T, merged Definition) {
+			if merged.Model != "gpt-4o-mini" || merged.Type != Object {
+				t.Errorf("model = %q type = %q", merged.Model, merged.Type)
+			}
+		}},
+		{"properties merged by key", Definition{Properties: map[string]Definition{
+			"title": {Model: "cheap"},
+			"tags":  {Type: Array, Items: &Definition{Type: String}},
+		}}, func(t *testing.T, merged Definition) {
+			if title := merged.Properties["title"]; title.Model != "cheap" || title.Instruction != "A title" {
+				t.Errorf("title = %+v, want the base title with the cheap model", title)
+			}
+			if len(merged.Properties) != 3 {
+				t.Errorf("properties = %v, want body, tags and title", merged.Properties)
+			}
+		}},
+		{"pointer merged by field", Definition{ModelConfig: &ModelConfig{Temperature: 0.2}}, func(t *testing.T, merged Definition) {
+			if merged.ModelConfig.Temperature != 0.2 || merged.ModelConfig.TopP != 0.9 {
+				t.Errorf("model config = %+v", merged.ModelConfig)
+			}
+		}},
+		{"slice replaced", Definition{ProcessingOrder: []string{"body"}}, func(t *testing.T, merged Definition) {
+			if !reflect.DeepEqual(merged.ProcessingOrder, []string{"body"}) {
+				t.Errorf("processing order = %v", merged.ProcessingOrder)
+			}
+		}},
+		{"branches matched by name", Definition{DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{
+			{Name: "short", Then: Definition{Model: "cheap"}},
+			{Name: "long", Then: Definition{Type: String, Instruction: "Long"}},
+		}}}, func(t *testing.T, merged Definition) {
+			branches := merged.DecisionPoint.Branches
+			if len(branches) != 2 || branches[0].Then.Model != "cheap" || branches[0].Then.Instruction != "Short" || branches[1].Name != "long" {
+				t.Errorf("branches = %+v", branches)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := base.Clone()
+			tt.check(t, Merge(base, tt.overlay))
+			if !reflect.DeepEqual(base, snapshot) {
+				t.Error("Merge modified the base")
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaRegistry holds the versions of a Definition and the migrations between them, so objects generated
+// from an older version can be upgraded to the current one:
+//
+//	registry := jsonSchema.NewSchemaRegistry()
+//	registry.Register("1", v1)
+//	registry.Register("2", v2)
+//	registry.AddMigration("1", "2", jsonSchema.RenameField("car.colour", "color"))
+//	data, err := registry.Upgrade(stored, "1")
+//
+// Versions are ordered by registration, the last registered version is the current one.
+type SchemaRegistry struct {
+	mu         sync.RWMutex
+	versions   []string
+	schemas    map[string]Definition
+	migrations map[string]schemaMigration //keyed by the version migrated from
+}
+
+type schemaMigration struct {
+	to    string
+	steps []MigrationStep
+}
+
+// MigrationStep changes generated data from the shape of one version to the next.
+// Steps are created with RenameField, ChangeType, ChangeTypeFunc, SplitField and MigrateFunc.
+type MigrationStep interface {
+	apply(data map[string]any) error
+	check(from, to Definition) error
+}
+
+// NewSchemaRegistry returns an empty SchemaRegistry
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[string]Definition), migrations: make(map[string]schemaMigration)}
+}
+
+// Register adds the next version of the Definition, which becomes the current version
+func (r *SchemaRegistry) Register(version string, def Definition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if version == "" {
+		return errors.New("schema version is required")
+	}
+	if _, ok := r.schemas[version]; ok {
+		return fmt.Errorf("schema version %q is already registered", version)
+	}
+	r.versions = append(r.versions, version)
+	r.schemas[version] = def.Clone()
+	return nil
+}
+
+// RegisterComplexSystem registers the RootSchema of the system under its Version
+func (r *SchemaRegistry) RegisterComplexSystem(system ComplexSystem) error {
+	return r.Register(system.Version, system.RootSchema)
+}
+
+// AddMigration declares the steps that upgrade data from one registered version to a later one.
+// The fields the steps refer to are checked against both Definitions.
+func (r *SchemaRegistry) AddMigration(from, to string, steps ...MigrationStep) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fromIndex, toIndex := slices.Index(r.versions, from), slices.Index(r.versions, to)
+	switch {
+	case fromIndex < 0:
+		return fmt.Errorf("schema version %q is not registered", from)
+	case toIndex < 0:
+		return fmt.Errorf("schema version %q is not registered", to)
+	case toIndex <= fromIndex:
+		return fmt.Errorf("cannot migrate from %q to the earlier version %q", from, to)
+	}
+	if _, ok := r.migrations[from]; ok {
+		return fmt.Errorf("a migration from %q is already declared", from)
+	}
+
+	var errs []error
+	for i, step := range steps {
+		if err := step.check(r.schemas[from], r.schemas[to]); err != nil {
+			errs = append(errs, fmt.Errorf("step %d: %v", i, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("migration from %q to %q: %w", from, to, err)
+	}
+	r.migrations[from] = schemaMigration{to: to, steps: steps}
+	return nil
+}
+
+// Current returns the current version and its Definition
+func (r *SchemaRegistry) Current() (string, Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.versions) == 0 {
+		return "", Definition{}, false
+	}
+	version := r.versions[len(r.versions)-1]
+	return version, r.schemas[version].Clone(), true
+}
+
+// Definition returns the Definition registered for the version
+func (r *SchemaRegistry) Definition(version string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.schemas[version]
+	if !ok {
+		return Definition{}, false
+	}
+	return def.Clone(), true
+}
+
+// Upgrade returns a copy of data generated from the given version, migrated to the current version.
+// The data is not modified.
+func (r *SchemaRegistry) Upgrade(data map[string]any, version string) (map[string]any, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.schemas[version]; !ok {
+		return nil, fmt.Errorf("schema version %q is not registered", version)
+	}
+
+	upgraded, _ := deepCopy(reflect.ValueOf(data)).Interface().(map[string]any)
+	if upgraded == nil {
+		upgraded = make(map[string]any)
+	}
+	current := r.versions[len(r.versions)-1]
+	for version != current {
+		migration, ok := r.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration is declared from schema version %q", version)
+		}
+		for i, step := range migration.steps {
+			if err := step.apply(upgraded); err != nil {
+				return nil, fmt.Errorf("migrating from %q to %q, step %d: %v", version, migration.to, i, err)
+			}
+		}
+		version = migration.to
+	}
+	return upgraded, nil
+}
+
+// RenameField renames the field at path to newName within the same object.
+// Paths are dotted, with [] for the items of arrays and * for the values of maps, ie orders[].lines[].sku.
+func RenameField(path, newName string) MigrationStep {
+	return renameStep{path: parseMigrationPath(path), newName: newName}
+}
+
+type renameStep struct {
+	path    []string
+	newName string
+}
+
+func (s renameStep) apply(data map[string]any) error {
+	return applyAtPath(data, s.path, func(parent map[string]any, key string) error {
+		if value, ok := parent[key]; ok {
+			delete(parent, key)
+			parent[s.newName] = value
+		}
+		return nil
+	})
+}
+
+func (s renameStep) check(from, to Definition) error {
+	if err := checkFieldPath(s.path); err != nil {
+		return err
+	}
+	if _, err := definitionAtPath(from, s.path); err != nil {
+		return err
+	}
+	renamed := append(slices.Clone(s.path[:len(s.path)-1]), s.newName)
+	_, err := definitionAtPath(to, renamed)
+	return err
+}
+
+// ChangeType converts the value at path to the type: numbers, booleans and strings are converted between each other
+// where the value allows it, rounding numbers converted to Integer to an int64, and json.Number is accepted as a number.
+// NaN and infinities are not converted, as JSON cannot hold them. Any value is converted to an array by wrapping it,
+// and to a string by encoding it as JSON.
+func ChangeType(path string, to DataType) MigrationStep {
+	return ChangeTypeFunc(path, to, func(value any) (any, error) { return convertDataType(value, to) })
+}
+
+// ChangeTypeFunc converts the value at path to the type with convert
+func ChangeTypeFunc(path string, to DataType, convert func(value any) (any, error)) MigrationStep {
+	return changeTypeStep{path: parseMigrationPath(path), to: to, convert: convert}
+}
+
+type changeTypeStep struct {
+	path    []string
+	to      DataType
+	convert func(value any) (any, error)
+}
+
+func (s changeTypeStep) apply(data map[string]any) error {
+	return applyAtPath(data, s.path, func(parent map[string]any, key string) error {
+		value, ok := parent[key]
+		if !ok || value == nil {
+			return nil
+		}
+		converted, err := s.convert(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		parent[key] = converted
+		return nil
+	})
+}
+
+func (s changeTypeStep) check(from, to Definition) error {
+	if err := checkFieldPath(s.path); err != nil {
+		return err
+	}
+	def, err := definitionAtPath(to, s.path)
+	if err != nil {
+		return err
+	}
+	if def.Type != s.to {
+		return fmt.Errorf("%s is %s in the new version, not %s", formatMigrationPath(s.path), def.Type, s.to)
+	}
+	return nil
+}
+
+// SplitField replaces the field at path with the fields split returns, which must be among into.
+// The new fields are set on the same object, ie splitting name into first_name and last_name.
+func SplitField(path string, into []string, split func(value any) (map[string]any, error)) MigrationStep {
+	return splitStep{path: parseMigrationPath(path), into: into, split: split}
+}
+
+type splitStep struct {
+	path  []string
+	into  []string
+	split func(value any) (map[string]any, error)
+}
+
+func (s splitStep) apply(data map[string]any) error {
+	return applyAtPath(data, s.path, func(parent map[string]any, key string) error {
+		value, ok := parent[key]
+		if !ok {
+			return nil
+		}
+		fields, err := s.split(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		if !slices.Contains(s.into, key) {
+			delete(parent, key)
+		}
+		for name, field := range fields {
+			if !slices.Contains(s.into, name) {
+				return fmt.Errorf("%s: split returned %q, which is not one of %v", key, name, s.into)
+			}
+			parent[name] = field
+		}
+		return nil
+	})
+}
+
+func (s splitStep) check(from, to Definition) error {
+	if err := checkFieldPath(s.path); err != nil {
+		return err
+	}
+	if _, err := definitionAtPath(from, s.path); err != nil {
+		return err
+	}
+	var errs []error
+	for _, name := range s.into {
+		if _, err := definitionAtPath(to, append(slices.Clone(s.path[:len(s.path)-1]), name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MigrateFunc is a step that changes the data with a function, for migrations the other steps do not cover
+func MigrateFunc(migrate func(data map[string]any) error) MigrationStep {
+	return funcStep(migrate)
+}
+
+type funcStep func(data map[string]any) error
+
+func (s funcStep) apply(data map[string]any) error { return s(data) }
+
+func (s funcStep) check(from, to Definition) error { return nil }
+
+// parseMigrationPath splits a dotted path into its keys, with [] and * as separate segments
+func parseMigrationPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		key, items := part, 0
+		for strings.HasSuffix(key, "[]") {
+			key, items = strings.TrimSuffix(key, "[]"), items+1
+		}
+		if key != "" {
+			segments = append(segments, key)
+		}
+		for ; items > 0; items-- {
+			segments = append(segments, "[]")
+		}
+	}
+	return segments
+}
+
+// checkFieldPath checks that a path ends in the key of a field, as the steps change fields of objects
+func checkFieldPath(path []string) error {
+	if len(path) == 0 || path[len(path)-1] == "[]" || path[len(path)-1] == "*" {
+		return fmt.Errorf("path %q does not name a field", formatMigrationPath(path))
+	}
+	return nil
+}
+
+// formatMigrationPath joins the segments of a path back into its dotted form
+func formatMigrationPath(path []string) string {
+	var formatted strings.Builder
+	for i, segment := range path {
+		if i > 0 && segment != "[]" {
+			formatted.WriteString(".")
+		}
+		formatted.WriteString(segment)
+	}
+	return formatted.String()
+}
+
+// applyAtPath calls fn with every object holding the last key of the path, following arrays and maps for [] and *
+func applyAtPath(value any, path []string, fn func(parent map[string]any, key string) error) error {
+	if len(path) == 0 {
+		return nil
+	}
+	switch path[0] {
+	case "[]":
+		list, _ := value.([]any)
+		for _, item := range list {
+			if err := applyAtPath(item, path[1:], fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "*":
+		object, _ := value.(map[string]any)
+		for _, key := range sortedKeys(object) {
+			if err := applyAtPath(object[key], path[1:], fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if len(path) == 1 {
+		return fn(object, path[0])
+	}
+	return applyAtPath(object[path[0]], path[1:], fn)
+}
+
+// definitionAtPath finds the Definition a migration path refers to
+func definitionAtPath(def Definition, path []string) (Definition, error) {
+	for i, segment := range path {
+		switch {
+		case segment == "[]" && def.Items != nil:
+			def = *def.Items
+		case segment == "*" && def.HashMap != nil && def.HashMap.FieldDefinition != nil:
+			def = *def.HashMap.FieldDefinition
+		default:
+			property, ok := def.Properties[segment]
+			if !ok {
+				return Definition{}, fmt.Errorf("%s is not in the schema", formatMigrationPath(path[:i+1]))
+			}
+			def = property
+		}
+	}
+	return def, nil
+}
+
+// convertDataType converts generated data to the type, as ChangeType describes
+func convertDataType(value any, to DataType) (any, error) {
+	switch to {
+	case String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	case Number, Integer:
+		var number float64
+		switch v := value.(type) {
+		case string, json.Number:
+			text := strings.TrimSpace(fmt.Sprint(v))
+			// integers are parsed exactly, as float64 loses precision beyond 2^53
+			if integer, err := strconv.ParseInt(text, 10, 64); to == Integer && err == nil {
+				return integer, nil
+			}
+			parsed, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", text)
+			}
+			number = parsed
+		case bool:
+			if v {
+				number = 1
+			}
+		default:
+			reflected := reflect.ValueOf(value)
+			if to == Integer && reflected.CanInt() {
+				return reflected.Int(), nil
+			}
+			converted, ok := toComparableNumber(reflected)
+			if !ok {
+				return nil, fmt.Errorf("cannot convert %v to %s", value, to)
+			}
+			number = converted
+		}
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("%v is not a finite number", value)
+		}
+		if to == Integer {
+			rounded := math.Round(number)
+			if rounded < math.MinInt64 || rounded >= math.MaxInt64 {
+				return nil, fmt.Errorf("%v is out of the integer range", value)
+			}
+			return int64(rounded), nil
+		}
+		return number, nil
+	case Boolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			return parsed, nil
+		case json.Number:
+			number, err := v.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			return number != 0, nil
+		}
+		if number, ok := toComparableNumber(reflect.ValueOf(value)); ok {
+			return number != 0, nil
+		}
+	case Array:
+		if list, ok := value.([]any); ok {
+			return list, nil
+		}
+		return []any{value}, nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to %s", value, to)
+}
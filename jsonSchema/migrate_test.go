@@ -0,0 +1,204 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertDataType(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		to      DataType
+		want    any
+		wantErr bool
+	}{
+		{"float to integer", 2.6, Integer, int64(3), false},
+		{"negative float to integer", -2.5, Integer, int64(-3), false},
+		{"int to integer", 7, Integer, int64(7), false},
+		{"large int64 to integer", int64(1<<53 + 1), Integer, int64(1<<53 + 1), false},
+		{"string to integer", " 42 ", Integer, int64(42), false},
+		{"decimal string to integer", "4.4", Integer, int64(4), false},
+		{"json number to integer", json.Number("12"), Integer, int64(12), false},
+		{"large json number to integer", json.Number("9007199254740993"), Integer, int64(9007199254740993), false},
+		{"decimal json number to integer", json.Number("1.5"), Integer, int64(2), false},
+		{"bool to integer", true, Integer, int64(1), false},
+		{"json number to number", json.Number("1.25"), Number, 1.25, false},
+		{"string to number", "1e3", Number, 1000.0, false},
+		{"int to number", 3, Number, 3.0, false},
+		{"bool to number", false, Number, 0.0, false},
+		{"nan string to number", "NaN", Number, nil, true},
+		{"infinite string to integer", "inf", Integer, nil, true},
+		{"nan float to integer", math.NaN(), Integer, nil, true},
+		{"out of range integer", 1e20, Integer, nil, true},
+		{"word to number", "many", Number, nil, true},
+		{"map to number", map[string]any{}, Number, nil, true},
+		{"json number to string", json.Number("1.50"), String, "1.50", false},
+		{"float to string", 1.5, String, "1.5", false},
+		{"bool to string", true, String, "true", false},
+		{"list to string", []any{"a", 1.0}, String, `["a",1]`, false},
+		{"string to boolean", "true", Boolean, true, false},
+		{"number to boolean", 2.0, Boolean, true, false},
+		{"json number to boolean", json.Number("0"), Boolean, false, false},
+		{"word to boolean", "maybe", Boolean, nil, true},
+		{"value to array", "a", Array, []any{"a"}, false},
+		{"array to array", []any{"a"}, Array, []any{"a"}, false},
+		{"unsupported type", "a", Object, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertDataType(tt.value, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("convertDataType(%v, %s) error = %v, wantErr %v", tt.value, tt.to, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertDataType(%v, %s) = %#v, want %#v", tt.value, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	v1 := Definition{Type: Object, Properties: map[string]Definition{
+		"name":   {Type: String},
+		"count":  {Type: String},
+		"orders": {Type: Array, Items: &Definition{Type: Object, Properties: map[string]Definition{"colour": {Type: String}}}},
+	}}
+	v2 := Definition{Type: Object, Properties: map[string]Definition{
+		"name":   {Type: String},
+		"count":  {Type: Integer},
+		"orders": {Type: Array, Items: &Definition{Type: Object, Properties: map[string]Definition{"color": {Type: String}}}},
+	}}
+	v3 := Definition{Type: Object, Properties: map[string]Definition{
+		"first_name": {Type: String},
+		"last_name":  {Type: String},
+		"count":      {Type: Integer},
+		"orders":     {Type: Array, Items: &Definition{Type: Object, Properties: map[string]Definition{"color": {Type: String}}}},
+	}}
+	splitName := func(value any) (map[string]any, error) {
+		first, last, _ := strings.Cut(value.(string), " ")
+		return map[string]any{"first_name": first, "last_name": last}, nil
+	}
+
+	registry := NewSchemaRegistry()
+	if err := errors.Join(registry.Register("1", v1), registry.Register("2", v2), registry.Register("3", v3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.AddMigration("1", "2", RenameField("orders[].colour", "color"), ChangeType("count", Integer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.AddMigration("2", "3", SplitField("name", []string{"first_name", "last_name"}, splitName)); err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]any{
+		"name":   "Ada Lovelace",
+		"count":  "3",
+		"orders": []any{map[string]any{"colour": "red"}, map[string]any{"colour": "blue"}},
+	}
+	tests := []struct {
+		name    string
+		data    map[string]any
+		version string
+		want    map[string]any
+	}{
+		{"from the first version", data, "1", map[string]any{
+			"first_name": "Ada",
+			"last_name":  "Lovelace",
+			"count":      int64(3),
+			"orders":     []any{map[string]any{"color": "red"}, map[string]any{"color": "blue"}},
+		}},
+		{"from the middle version", map[string]any{"name": "Ada Lovelace", "count": json.Number("4")}, "2", map[string]any{
+			"first_name": "Ada",
+			"last_name":  "Lovelace",
+			"count":      json.Number("4"),
+		}},
+		{"from the current version", map[string]any{"first_name": "Ada"}, "3", map[string]any{"first_name": "Ada"}},
+		{"missing fields are left out", map[string]any{"count": json.Number("5")}, "1", map[string]any{"count": int64(5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Upgrade(tt.data, tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Upgrade() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+	if data["count"] != "3" || data["orders"].([]any)[0].(map[string]any)["colour"] != "red" {
+		t.Error("Upgrade modified the data")
+	}
+}
+
+func TestUpgradeErrors(t *testing.T) {
+	v1 := Definition{Type: Object, Properties: map[string]Definition{"count": {Type: String}}}
+	v2 := Definition{Type: Object, Properties: map[string]Definition{"count": {Type: Integer}}}
+	registry := NewSchemaRegistry()
+	if err := errors.Join(registry.Register("1", v1), registry.Register("2", v2)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		version string
+		wantErr string
+	}{
+		{"unknown version", nil, "0", `schema version "0" is not registered`},
+		{"no migration", map[string]any{"count": "1"}, "1", `no migration is declared from schema version "1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registry.Upgrade(tt.data, tt.version)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Upgrade() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := registry.AddMigration("1", "2", ChangeType("count", Integer)); err != nil {
+		t.Fatal(err)
+	}
+	_, err := registry.Upgrade(map[string]any{"count": "NaN"}, "1")
+	if want := `migrating from "1" to "2", step 0: count: NaN is not a finite number`; err == nil || err.Error() != want {
+		t.Errorf("Upgrade() error = %v, want %q", err, want)
+	}
+}
+
+func TestAddMigrationErrors(t *testing.T) {
+	v1 := Definition{Type: Object, Properties: map[string]Definition{"name": {Type: String}, "tags": {Type: Array, Items: &Definition{Type: String}}}}
+	v2 := Definition{Type: Object, Properties: map[string]Definition{"title": {Type: String}, "tags": {Type: Array, Items: &Definition{Type: String}}}}
+	tests := []struct {
+		name     string
+		from, to string
+		steps    []MigrationStep
+		wantErr  string
+	}{
+		{"unknown from", "0", "2", nil, `schema version "0" is not registered`},
+		{"unknown to", "1", "3", nil, `schema version "3" is not registered`},
+		{"backwards", "2", "1", nil, `cannot migrate from "2" to the earlier version "1"`},
+		{"rename of a missing field", "1", "2", []MigrationStep{RenameField("missing", "title")}, "missing is not in the schema"},
+		{"rename to a missing field", "1", "2", []MigrationStep{RenameField("name", "heading")}, "heading is not in the schema"},
+		{"change to the wrong type", "1", "2", []MigrationStep{ChangeType("title", Integer)}, "title is string in the new version, not integer"},
+		{"path without a field", "1", "2", []MigrationStep{ChangeType("tags[]", String)}, `path "tags[]" does not name a field`},
+		{"split into a missing field", "1", "2", []MigrationStep{SplitField("name", []string{"title", "subtitle"}, nil)}, "subtitle is not in the schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewSchemaRegistry()
+			if err := errors.Join(registry.Register("1", v1), registry.Register("2", v2)); err != nil {
+				t.Fatal(err)
+			}
+			err := registry.AddMigration(tt.from, tt.to, tt.steps...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("AddMigration() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
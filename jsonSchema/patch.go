@@ -0,0 +1,317 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is a single operation of an RFC 6902 JSON Patch
+type PatchOperation struct {
+	Op    string          `json:"op"`   //add, remove, replace, move, copy or test
+	Path  string          `json:"path"` //JSON pointer into the Definition, ie /properties/title/model
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to the JSON form of the Definition and returns the result.
+// Unlike Merge, a null in the patch removes the field, and DecisionPoint branches are replaced as a whole.
+func ApplyMergePatch(def Definition, patch []byte) (Definition, error) {
+	doc, err := definitionDocument(def)
+	if err != nil {
+		return Definition{}, err
+	}
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return Definition{}, fmt.Errorf("error parsing merge patch: %v", err)
+	}
+	return documentDefinition(mergePatch(doc, patchDoc))
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to the JSON form of the Definition and returns the result.
+// The operations are applied in order and the first failing one, including a failed test, aborts the patch.
+// Empty fields are omitted from the JSON form, so set them with add rather than replace.
+func ApplyJSONPatch(def Definition, patch []byte) (Definition, error) {
+	var operations []PatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return Definition{}, fmt.Errorf("error parsing json patch: %v", err)
+	}
+	doc, err := definitionDocument(def)
+	if err != nil {
+		return Definition{}, err
+	}
+	for i, operation := range operations {
+		if doc, err = applyPatchOperation(doc, operation); err != nil {
+			return Definition{}, fmt.Errorf("json patch operation %d (%s %s): %v", i, operation.Op, operation.Path, err)
+		}
+	}
+	return documentDefinition(doc)
+}
+
+// definitionDocument returns the generic JSON form of the Definition that patches are applied to
+func definitionDocument(def Definition) (any, error) {
+	data, err := json.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling definition: %v", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error unmarshalling definition: %v", err)
+	}
+	return doc, nil
+}
+
+// documentDefinition decodes a patched document back into a Definition
+func documentDefinition(doc any) (Definition, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return Definition{}, fmt.Errorf("error marshalling patched definition: %v", err)
+	}
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return Definition{}, fmt.Errorf("patched document is not a definition: %v", err)
+	}
+	return def, nil
+}
+
+// mergePatch implements the MergePatch function of RFC 7396
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any)
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+func applyPatchOperation(doc any, operation PatchOperation) (any, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if len(operation.Value) == 0 {
+			return nil, errors.New("value is required")
+		}
+		var value any
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return nil, fmt.Errorf("error parsing value: %v", err)
+		}
+		switch operation.Op {
+		case "add":
+			return pointerAdd(doc, path, value)
+		case "replace":
+			if _, err := pointerGet(doc, path); err != nil {
+				return nil, err
+			}
+			if len(path) == 0 {
+				return value, nil
+			}
+			if doc, err = pointerRemove(doc, path); err != nil {
+				return nil, err
+			}
+			return pointerAdd(doc, path, value)
+		}
+		current, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("test failed, value is %s", formatChangeValue(current))
+		}
+		return doc, nil
+	case "remove":
+		return pointerRemove(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %v", err)
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %v", err)
+		}
+		if operation.Op == "copy" {
+			return pointerAdd(doc, path, copyDocument(value))
+		}
+		if operation.Path == operation.From {
+			return doc, nil
+		}
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		if doc, err = pointerRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	}
+	return nil, fmt.Errorf("unknown operation %q", operation.Op)
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into its unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = unescapePointerToken(token)
+	}
+	return tokens, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	for i, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%s does not exist", formatPointer(path[:i+1]))
+			}
+			doc = value
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", formatPointer(path[:i+1]), err)
+			}
+			doc = node[index]
+		default:
+			return nil, fmt.Errorf("%s does not exist", formatPointer(path[:i+1]))
+		}
+	}
+	return doc, nil
+}
+
+// pointerAdd adds the value at the path, inserting into arrays, and returns the updated document
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = value
+		return doc, nil
+	case []any:
+		index := len(node)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(node)); err != nil {
+				return nil, fmt.Errorf("%s: %v", formatPointer(path), err)
+			}
+		}
+		node = append(node[:index], append([]any{value}, node[index:]...)...)
+		return replaceParent(doc, path[:len(path)-1], node)
+	}
+	return nil, fmt.Errorf("%s is not an object or array", formatPointer(path[:len(path)-1]))
+}
+
+// pointerRemove removes the value at the path and returns the updated document
+func pointerRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole definition")
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		if _, ok := node[token]; !ok {
+			return nil, fmt.Errorf("%s does not exist", formatPointer(path))
+		}
+		delete(node, token)
+		return doc, nil
+	case []any:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", formatPointer(path), err)
+		}
+		return replaceParent(doc, path[:len(path)-1], append(node[:index:index], node[index+1:]...))
+	}
+	return nil, fmt.Errorf("%s is not an object or array", formatPointer(path[:len(path)-1]))
+}
+
+// replaceParent stores a resized array back at its path, as slices cannot be grown or shrunk in place
+func replaceParent(doc any, path []string, array []any) (any, error) {
+	if len(path) == 0 {
+		return array, nil
+	}
+	parent, err := pointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = array
+	case []any:
+		index, _ := arrayIndex(token, len(node)-1)
+		node[index] = array
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array index token, which must not exceed max
+func arrayIndex(token string, max int) (int, error) {
+	if token == "-" {
+		return 0, errors.New("index - refers past the end of the array")
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if index > max {
+		return 0, fmt.Errorf("array index %d is out of range", index)
+	}
+	return index, nil
+}
+
+func formatPointer(path []string) string {
+	escaped := make([]string, len(path))
+	for i, token := range path {
+		escaped[i] = escapePointerToken(token)
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+// copyDocument deep copies a generic JSON value for the copy operation
+func copyDocument(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, child := range v {
+			copied[key] = copyDocument(child)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, child := range v {
+			copied[i] = copyDocument(child)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package jsonSchema
+
+import (
+	"strings"
+	"testing"
+)
+
+func patchBase() Definition {
+	return Definition{
+		Type:            Object,
+		Model:           "gpt-4o",
+		ProcessingOrder: []string{"title", "body"},
+		Properties: map[string]Definition{
+			"title": {Type: String, Instruction: "A title"},
+			"body":  {Type: String, Instruction: "The body"},
+		},
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  func() Definition
+		err   bool
+	}{
+		{"set a field", `{"model":"cheap"}`, func() Definition { d := patchBase(); d.Model = "cheap"; return d }, false},
+		{"null removes", `{"model":null,"properties":{"body":null}}`, func() Definition {
+			d := patchBase()
+			d.Model = ""
+			delete(d.Properties, "body")
+			return d
+		}, false},
+		{"nested field", `{"properties":{"title":{"instruction":"New"}}}`, func() Definition {
+			d := patchBase()
+			d.Properties["title"] = Definition{Type: String, Instruction: "New"}
+			return d
+		}, false},
+		{"invalid json", `{`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyMergePatch(patchBase(), []byte(tt.patch))
+			if tt.err {
+				if err == nil {
+					t.Error("no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changes := Diff(got, tt.want()); len(changes) > 0 {
+				t.Errorf("patched definition differs: %v", changes)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  func() Definition
+		err   string
+	}{
+		{"replace", `[{"op":"replace","path":"/model","value":"cheap"}]`, func() Definition { d := patchBase(); d.Model = "cheap"; return d }, ""},
+		{"add property", `[{"op":"add","path":"/properties/tags","value":{"type":"array","items":{"type":"string"}}}]`, func() Definition {
+			d := patchBase()
+			d.Properties["tags"] = Definition{Type: Array, Items: &Definition{Type: String}}
+			return d
+		}, ""},
+		{"append to order", `[{"op":"add","path":"/processingOrder/-","value":"tags"}]`, func() Definition {
+			d := patchBase()
+			d.ProcessingOrder = append(d.ProcessingOrder, "tags")
+			return d
+		}, ""},
+		{"remove and move", `[{"op":"remove","path":"/processingOrder/0"},{"op":"move","from":"/properties/body","path":"/properties/text"}]`, func() Definition {
+			d := patchBase()
+			d.ProcessingOrder = []string{"body"}
+			d.Properties["text"] = d.Properties["body"]
+			delete(d.Properties, "body")
+			return d
+		}, ""},
+		{"copy", `[{"op":"copy","from":"/properties/title","path":"/properties/subtitle"}]`, func() Definition {
+			d := patchBase()
+			d.Properties["subtitle"] = d.Properties["title"]
+			return d
+		}, ""},
+		{"escaped pointer", `[{"op":"add","path":"/properties/a~1b","value":{"type":"string"}}]`, func() Definition {
+			d := patchBase()
+			d.Properties["a/b"] = Definition{Type: String}
+			return d
+		}, ""},
+		{"passing test", `[{"op":"test","path":"/model","value":"gpt-4o"}]`, patchBase, ""},
+		{"failing test aborts", `[{"op":"replace","path":"/model","value":"cheap"},{"op":"test","path":"/type","value":"string"}]`, nil, "test failed"},
+		{"missing path", `[{"op":"replace","path":"/items","value":{}}]`, nil, "items"},
+		{"move into child", `[{"op":"move","from":"/properties","path":"/properties/x"}]`, nil, "children"},
+		{"unknown op", `[{"op":"merge","path":"/model","value":"x"}]`, nil, "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch(patchBase(), []byte(tt.patch))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changes := Diff(got, tt.want()); len(changes) > 0 {
+				t.Errorf("patched definition differs: %v", changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// defaultScale is the scale of numeric dimensions without one
+var defaultScale = ScoreScale{Min: 0, Max: 100}
+
+// DefaultBooleanMapping maps boolean scores to normalized scores when AggregateOptions has no mapping for them
+var DefaultBooleanMapping = map[string]float64{"true": 1, "false": 0}
+
+// DefaultCategoricalMapping maps categorical scores to normalized scores when AggregateOptions has no mapping for them
+var DefaultCategoricalMapping = map[string]float64{"low": 0, "medium": 0.5, "high": 1}
+
+// AggregateOptions configures ScoringCriteria.NormalizeWith and ScoringCriteria.AggregateWith
+type AggregateOptions struct {
+	// Mappings map the boolean ("true" and "false") or categorical scores of a dimension, keyed by dimension name,
+	// to normalized scores between 0 and 1. Categories are matched case-insensitively.
+	Mappings map[string]map[string]float64
+}
+
+// Aggregator combines normalized dimension scores, between 0 and 1, into one score
+type Aggregator func(scores map[string]float64, dimensions map[string]ScoringDimension) (float64, error)
+
+var (
+	aggregatorsMu sync.RWMutex
+	aggregators   = make(map[AggregationMethod]Aggregator)
+)
+
+// RegisterAggregator makes an Aggregator available to ScoringCriteria with the given AggregationMethod.
+// Use AggregateCustom, or a name of your own, for the method; the built-in methods can't be replaced.
+func RegisterAggregator(method AggregationMethod, aggregator Aggregator) error {
+	switch {
+	case method == "":
+		return errors.New("aggregation method is required")
+	case method == AggregateWeightedAverage || method == AggregateMinimum || method == AggregateMaximum:
+		return fmt.Errorf("aggregation method %q is built in", method)
+	case aggregator == nil:
+		return fmt.Errorf("aggregator for %q is nil", method)
+	}
+	aggregatorsMu.Lock()
+	defer aggregatorsMu.Unlock()
+	aggregators[method] = aggregator
+	return nil
+}
+
+// Aggregate normalizes the scores with the default mappings and combines them with the AggregationMethod
+func (sc ScoringCriteria) Aggregate(scores map[string]any) (float64, error) {
+	return sc.AggregateWith(scores, AggregateOptions{})
+}
+
+// AggregateWith normalizes the scores and combines them with the AggregationMethod, a weighted average when empty.
+// The weighted average divides by the sum of the weights, so they need not sum to 1, and treats dimensions
+// equally when no weights are set.
+func (sc ScoringCriteria) AggregateWith(scores map[string]any, options AggregateOptions) (float64, error) {
+	normalized, err := sc.NormalizeWith(scores, options)
+	if err != nil {
+		return 0, err
+	}
+
+	switch sc.AggregationMethod {
+	case "", AggregateWeightedAverage:
+		return weightedAverage(normalized, sc.Dimensions)
+	case AggregateMinimum:
+		result := math.Inf(1)
+		for _, score := range normalized {
+			result = min(result, score)
+		}
+		return result, nil
+	case AggregateMaximum:
+		result := math.Inf(-1)
+		for _, score := range normalized {
+			result = max(result, score)
+		}
+		return result, nil
+	}
+
+	aggregatorsMu.RLock()
+	aggregator, ok := aggregators[sc.AggregationMethod]
+	aggregatorsMu.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("no aggregator registered for %q", sc.AggregationMethod)
+	}
+	result, err := aggregator(normalized, sc.Dimensions)
+	if err != nil {
+		return 0, fmt.Errorf("%s aggregation: %v", sc.AggregationMethod, err)
+	}
+	if !isFinite(result) {
+		return 0, fmt.Errorf("%s aggregation: result %v is not finite", sc.AggregationMethod, result)
+	}
+	return result, nil
+}
+
+// Normalize converts the scores to between 0 and 1 with the default mappings
+func (sc ScoringCriteria) Normalize(scores map[string]any) (map[string]float64, error) {
+	return sc.NormalizeWith(scores, AggregateOptions{})
+}
+
+// NormalizeWith converts the score of every dimension to between 0 and 1. Numeric scores are placed on the
+// dimension's Scale, 0-100 when it has none, and boolean and categorical scores are mapped. Dimensions without a Type
+// are treated as boolean for boolean scores, numeric for numbers and strings holding finite numbers, and categorical
+// for other strings, so "NaN" is a category. NaN and infinite numeric scores are errors.
+// Every dimension must be scored, and every score must belong to a dimension.
+func (sc ScoringCriteria) NormalizeWith(scores map[string]any, options AggregateOptions) (map[string]float64, error) {
+	if len(sc.Dimensions) == 0 {
+		return nil, errors.New("scoring criteria has no dimensions")
+	}
+	var errs []error
+	for _, name := range sortedKeys(scores) {
+		if _, ok := sc.Dimensions[name]; !ok {
+			errs = append(errs, fmt.Errorf("score %q has no dimension", name))
+		}
+	}
+
+	normalized := make(map[string]float64, len(sc.Dimensions))
+	for _, name := range sortedKeys(sc.Dimensions) {
+		value, ok := scores[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("dimension %q: missing score", name))
+			continue
+		}
+		score, err := normalizeScore(sc.Dimensions[name], value, options.Mappings[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("dimension %q: %v", name, err))
+			continue
+		}
+		normalized[name] = score
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return normalized, nil
+}
+
+// normalizeScore converts one score to between 0 and 1, using the mapping for boolean and categorical scores
+func normalizeScore(dimension ScoringDimension, value any, mapping map[string]float64) (float64, error) {
+	scoreType := dimension.Type
+	if scoreType == "" {
+		_, isBool := value.(bool)
+		_, isNumber := numericValue(value)
+		switch {
+		case isBool:
+			scoreType = ScoreBoolean
+		case isNumber:
+			scoreType = ScoreNumeric
+		default:
+			scoreType = ScoreCategorical
+		}
+	}
+
+	switch scoreType {
+	case ScoreNumeric:
+		number, ok := numericValue(value)
+		if !ok {
+			return 0, fmt.Errorf("numeric score %s is not a number", formatChangeValue(value))
+		}
+		if !isFinite(number) {
+			return 0, fmt.Errorf("numeric score %v is not finite", number)
+		}
+		scale := defaultScale
+		if dimension.Scale != nil {
+			scale = *dimension.Scale
+		}
+		if scale.Max <= scale.Min {
+			return 0, fmt.Errorf("scale %d-%d is empty", scale.Min, scale.Max)
+		}
+		if number < float64(scale.Min) || number > float64(scale.Max) {
+			return 0, fmt.Errorf("score %v is outside the scale %d-%d", number, scale.Min, scale.Max)
+		}
+		return (number - float64(scale.Min)) / float64(scale.Max-scale.Min), nil
+	case ScoreBoolean:
+		boolean, ok := boolValue(value)
+		if !ok {
+			return 0, fmt.Errorf("boolean score %s is not a boolean", formatChangeValue(value))
+		}
+		if mapping == nil {
+			mapping = DefaultBooleanMapping
+		}
+		return mappedScore(mapping, fmt.Sprint(boolean))
+	case ScoreCategorical:
+		category, ok := value.(string)
+		if !ok {
+			return 0, fmt.Errorf("categorical score %s is not a string", formatChangeValue(value))
+		}
+		if mapping == nil {
+			mapping = DefaultCategoricalMapping
+		}
+		return mappedScore(mapping, category)
+	}
+	return 0, fmt.Errorf("unknown score type %q", scoreType)
+}
+
+// mappedScore looks the key up in the mapping, case-insensitively
+func mappedScore(mapping map[string]float64, key string) (float64, error) {
+	score, ok := mapping[key]
+	if !ok {
+		for name, value := range mapping {
+			if strings.EqualFold(name, strings.TrimSpace(key)) {
+				score, ok = value, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("score %q has no mapping, expected one of %s", key, strings.Join(sortedKeys(mapping), ", "))
+	}
+	if !(score >= 0 && score <= 1) {
+		return 0, fmt.Errorf("mapping of %q to %v is outside 0-1", key, score)
+	}
+	return score, nil
+}
+
+// weightedAverage averages the scores by the weight of their dimensions, equally when no weights are set
+func weightedAverage(scores map[string]float64, dimensions map[string]ScoringDimension) (float64, error) {
+	var total, weights float64
+	for name, score := range scores {
+		weight := dimensions[name].Weight
+		if weight < 0 {
+			return 0, fmt.Errorf("dimension %q: weight %v is negative", name, weight)
+		}
+		total += weight * score
+		weights += weight
+	}
+	if weights == 0 {
+		for _, score := range scores {
+			total += score
+		}
+		return total / float64(len(scores)), nil
+	}
+	return total / weights, nil
+}
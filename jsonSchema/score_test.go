@@ -0,0 +1,139 @@
+package jsonSchema
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tenPoint := &ScoreScale{Min: 1, Max: 10}
+	tests := []struct {
+		name      string
+		dimension ScoringDimension
+		value     any
+		mapping   map[string]float64
+		want      float64
+		wantErr   string
+	}{
+		{"number on the default scale", ScoringDimension{}, 75, nil, 0.75, ""},
+		{"json number", ScoringDimension{}, json.Number("50"), nil, 0.5, ""},
+		{"numeric string", ScoringDimension{}, "25", nil, 0.25, ""},
+		{"number on a scale", ScoringDimension{Scale: tenPoint}, 10.0, nil, 1, ""},
+		{"boolean", ScoringDimension{}, true, nil, 1, ""},
+		{"boolean string", ScoringDimension{Type: ScoreBoolean}, "false", nil, 0, ""},
+		{"category", ScoringDimension{}, "High", nil, 1, ""},
+		{"mapped category", ScoringDimension{}, "ok", map[string]float64{"ok": 0.6}, 0.6, ""},
+		{"nan string is a category", ScoringDimension{}, "NaN", map[string]float64{"nan": 0.2}, 0.2, ""},
+		{"infinite string is a category", ScoringDimension{}, "inf", nil, 0, `score "inf" has no mapping`},
+		{"nan string is not numeric", ScoringDimension{Type: ScoreNumeric}, "NaN", nil, 0, `numeric score "NaN" is not a number`},
+		{"nan number", ScoringDimension{}, math.NaN(), nil, 0, "numeric score NaN is not finite"},
+		{"infinite number", ScoringDimension{Type: ScoreNumeric}, math.Inf(1), nil, 0, "numeric score +Inf is not finite"},
+		{"outside the scale", ScoringDimension{Scale: tenPoint}, 0, nil, 0, "score 0 is outside the scale 1-10"},
+		{"empty scale", ScoringDimension{Scale: &ScoreScale{Min: 5, Max: 5}}, 5, nil, 0, "scale 5-5 is empty"},
+		{"number as boolean", ScoringDimension{Type: ScoreBoolean}, 1.5, nil, 0, "boolean score 1.5 is not a boolean"},
+		{"number as category", ScoringDimension{Type: ScoreCategorical}, 3, nil, 0, "categorical score 3 is not a string"},
+		{"nan mapping", ScoringDimension{}, "ok", map[string]float64{"ok": math.NaN()}, 0, `mapping of "ok" to NaN is outside 0-1`},
+		{"mapping above one", ScoringDimension{}, "ok", map[string]float64{"ok": 2}, 0, `mapping of "ok" to 2 is outside 0-1`},
+		{"unknown type", ScoringDimension{Type: ScoreType("ranked")}, 1, nil, 0, `unknown score type "ranked"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeScore(tt.dimension, tt.value, tt.mapping)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("normalizeScore() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateWith(t *testing.T) {
+	dimensions := map[string]ScoringDimension{
+		"accuracy": {Weight: 0.75},
+		"tone":     {Weight: 0.25},
+	}
+	scores := map[string]any{"accuracy": 80, "tone": "low"}
+	tests := []struct {
+		name     string
+		criteria ScoringCriteria
+		scores   map[string]any
+		want     float64
+	}{
+		{"weighted average", ScoringCriteria{Dimensions: dimensions}, scores, 0.6},
+		{"equal weights", ScoringCriteria{Dimensions: map[string]ScoringDimension{"accuracy": {}, "tone": {}}}, scores, 0.4},
+		{"minimum", ScoringCriteria{Dimensions: dimensions, AggregationMethod: AggregateMinimum}, scores, 0},
+		{"maximum", ScoringCriteria{Dimensions: dimensions, AggregationMethod: AggregateMaximum}, scores, 0.8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.criteria.Aggregate(tt.scores)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Aggregate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	if err := RegisterAggregator("test_nan", func(map[string]float64, map[string]ScoringDimension) (float64, error) {
+		return math.NaN(), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	dimensions := map[string]ScoringDimension{"accuracy": {}}
+	tests := []struct {
+		name     string
+		criteria ScoringCriteria
+		scores   map[string]any
+		wantErr  string
+	}{
+		{"no dimensions", ScoringCriteria{}, map[string]any{"accuracy": 1}, "scoring criteria has no dimensions"},
+		{"missing score", ScoringCriteria{Dimensions: dimensions}, map[string]any{}, `dimension "accuracy": missing score`},
+		{"unknown score", ScoringCriteria{Dimensions: dimensions}, map[string]any{"accuracy": 1, "style": 1}, `score "style" has no dimension`},
+		{"nan score", ScoringCriteria{Dimensions: dimensions}, map[string]any{"accuracy": math.NaN()}, "numeric score NaN is not finite"},
+		{"unregistered aggregator", ScoringCriteria{Dimensions: dimensions, AggregationMethod: "median"}, map[string]any{"accuracy": 1}, `no aggregator registered for "median"`},
+		{"nan aggregate", ScoringCriteria{Dimensions: dimensions, AggregationMethod: "test_nan"}, map[string]any{"accuracy": 1}, "test_nan aggregation: result NaN is not finite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.criteria.Aggregate(tt.scores)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Aggregate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterAggregatorErrors(t *testing.T) {
+	aggregator := func(map[string]float64, map[string]ScoringDimension) (float64, error) { return 0, nil }
+	tests := []struct {
+		name       string
+		method     AggregationMethod
+		aggregator Aggregator
+		wantErr    string
+	}{
+		{"no method", "", aggregator, "aggregation method is required"},
+		{"built in", AggregateMinimum, aggregator, `aggregation method "minimum" is built in`},
+		{"nil aggregator", AggregateCustom, nil, `aggregator for "custom" is nil`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterAggregator(tt.method, tt.aggregator); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("RegisterAggregator() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
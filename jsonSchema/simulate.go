@@ -0,0 +1,185 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LoopIteration is a mocked iteration of a RecursiveLoop
+type LoopIteration struct {
+	Output any            //the generated value
+	Scores map[string]any //the scores given by the judge, by ScoringCriteria dimension
+	Err    error          //a failed generation, which no selection keeps
+}
+
+// SimulatedIteration is an iteration run by SimulateLoop
+type SimulatedIteration struct {
+	Index       int
+	Output      any
+	Failed      bool
+	Score       float64         //the normalized aggregate score between 0 and 1, when the Definition has ScoringCriteria
+	Termination *DecisionResult //the evaluation of the TerminationPoint, nil without one or for failed iterations
+}
+
+// LoopSelection is the result a SelectionStrategy keeps
+type LoopSelection struct {
+	Indexes []int //the iterations kept, a single one except for SelectAll
+	Output  any   //the output kept, a list of outputs for SelectAll
+}
+
+// LoopSimulation is the outcome of SimulateLoop
+type LoopSimulation struct {
+	Iterations   []SimulatedIteration                //the iterations run, up to and including the one that terminated the loop
+	TerminatedAt int                                 //the iteration that terminated the loop, -1 when it did not terminate early
+	TerminatedBy string                              //the name of the TerminationPoint branch that matched
+	Selections   map[SelectionStrategy]LoopSelection //the result of every strategy, without highest and lowest when unscored
+	Strategy     SelectionStrategy                   //the loop's own Selection
+	Selected     *LoopSelection                      //the result of the loop's own Selection, nil when it has none
+}
+
+// SimulateLoop runs the RecursiveLoop of the Definition over mocked iterations, without calling an LLM, to show
+// which iteration terminates the loop and which result each SelectionStrategy keeps.
+//
+// Iterations run in order until MaxIterations or the mocked iterations run out, or until a branch of the
+// TerminationPoint matches. The TerminationPoint is evaluated with the raw scores of the iteration and, for
+// field conditions, its Output when that is an object. With ScoringCriteria, every iteration gets an aggregate score
+// used by the highest and lowest selections, ties going to the earlier iteration. Failed iterations are run but
+// never selected, so first keeps the first iteration that succeeded.
+//
+// Termination and selection look at different scores: conditions compare the judge's raw scores, ie quality gte 80
+// on a 0-100 scale, while highest and lowest compare the aggregate normalized to between 0 and 1 with the dimension
+// weights. The iteration that terminates the loop is therefore not necessarily the one highest keeps.
+func (d Definition) SimulateLoop(iterations []LoopIteration) (LoopSimulation, error) {
+	return d.SimulateLoopWith(iterations, AggregateOptions{})
+}
+
+// SimulateLoopWith is SimulateLoop with options for aggregating the scores, the options only change the aggregate
+// used for selection and not the raw scores the TerminationPoint evaluates
+func (d Definition) SimulateLoopWith(iterations []LoopIteration, options AggregateOptions) (LoopSimulation, error) {
+	loop := d.RecursiveLoop
+	switch {
+	case loop == nil:
+		return LoopSimulation{}, errors.New("definition has no recursive loop")
+	case loop.MaxIterations <= 0:
+		return LoopSimulation{}, errors.New("maxIterations must be positive")
+	case loop.Selection != "" && !knownSelections[loop.Selection]:
+		return LoopSimulation{}, fmt.Errorf("unknown selection strategy %q", loop.Selection)
+	case (loop.Selection == SelectHighestScore || loop.Selection == SelectLowestScore) && d.ScoringCriteria == nil:
+		return LoopSimulation{}, fmt.Errorf("%s selection requires scoring criteria", loop.Selection)
+	}
+
+	simulation := LoopSimulation{TerminatedAt: -1, Strategy: loop.Selection}
+	for i, iteration := range iterations[:min(len(iterations), loop.MaxIterations)] {
+		run := SimulatedIteration{Index: i, Output: iteration.Output, Failed: iteration.Err != nil}
+		simulation.Iterations = append(simulation.Iterations, run)
+		if run.Failed {
+			continue
+		}
+
+		if d.ScoringCriteria != nil {
+			score, err := d.ScoringCriteria.AggregateWith(iteration.Scores, options)
+			if err != nil {
+				return LoopSimulation{}, fmt.Errorf("iteration %d: %v", i, err)
+			}
+			run.Score = score
+		}
+		if loop.TerminationPoint != nil {
+			data, _ := iteration.Output.(map[string]any)
+			result, err := loop.TerminationPoint.Evaluate(iteration.Scores, data)
+			if err != nil {
+				return LoopSimulation{}, fmt.Errorf("iteration %d: termination point: %v", i, err)
+			}
+			run.Termination = &result
+		}
+		simulation.Iterations[i] = run
+
+		if run.Termination != nil && run.Termination.Branch != nil {
+			simulation.TerminatedAt, simulation.TerminatedBy = i, run.Termination.Branch.Name
+			break
+		}
+	}
+
+	simulation.Selections = selectIterations(simulation.Iterations, d.ScoringCriteria != nil)
+	if selection, ok := simulation.Selections[loop.Selection]; ok {
+		simulation.Selected = &selection
+	}
+	return simulation, nil
+}
+
+// selectIterations applies every SelectionStrategy to the successful iterations
+func selectIterations(iterations []SimulatedIteration, scored bool) map[SelectionStrategy]LoopSelection {
+	var succeeded []SimulatedIteration
+	for _, iteration := range iterations {
+		if !iteration.Failed {
+			succeeded = append(succeeded, iteration)
+		}
+	}
+
+	all := LoopSelection{Indexes: []int{}, Output: []any{}}
+	for _, iteration := range succeeded {
+		all.Indexes = append(all.Indexes, iteration.Index)
+		all.Output = append(all.Output.([]any), iteration.Output)
+	}
+	selections := map[SelectionStrategy]LoopSelection{SelectAll: all}
+	if len(succeeded) == 0 {
+		return selections
+	}
+
+	keep := func(iteration SimulatedIteration) LoopSelection {
+		return LoopSelection{Indexes: []int{iteration.Index}, Output: iteration.Output}
+	}
+	selections[SelectFirst] = keep(succeeded[0])
+	selections[SelectLatest] = keep(succeeded[len(succeeded)-1])
+	if scored {
+		highest, lowest := succeeded[0], succeeded[0]
+		for _, iteration := range succeeded[1:] {
+			if iteration.Score > highest.Score {
+				highest = iteration
+			}
+			if iteration.Score < lowest.Score {
+				lowest = iteration
+			}
+		}
+		selections[SelectHighestScore] = keep(highest)
+		selections[SelectLowestScore] = keep(lowest)
+	}
+	return selections
+}
+
+// String describes the simulation for design reviews, one line per iteration followed by the selections
+func (s LoopSimulation) String() string {
+	_, scored := s.Selections[SelectHighestScore]
+	var out strings.Builder
+	for _, iteration := range s.Iterations {
+		fmt.Fprintf(&out, "iteration %d: ", iteration.Index)
+		if iteration.Failed {
+			out.WriteString("failed\n")
+			continue
+		}
+		if scored {
+			fmt.Fprintf(&out, "score %.3f, ", iteration.Score)
+		}
+		if iteration.Termination != nil && iteration.Termination.Branch != nil {
+			fmt.Fprintf(&out, "terminated by %s\n", iteration.Termination.Branch.Name)
+			continue
+		}
+		out.WriteString("continues\n")
+	}
+	for _, strategy := range []SelectionStrategy{SelectHighestScore, SelectLowestScore, SelectLatest, SelectFirst, SelectAll} {
+		selection, ok := s.Selections[strategy]
+		if !ok {
+			continue
+		}
+		indexes := make([]string, len(selection.Indexes))
+		for i, index := range selection.Indexes {
+			indexes[i] = fmt.Sprint(index)
+		}
+		marker := ""
+		if strategy == s.Strategy {
+			marker = " (selected)"
+		}
+		fmt.Fprintf(&out, "%s keeps [%s]%s\n", strategy, strings.Join(indexes, ", "), marker)
+	}
+	return out.String()
+}
@@ -0,0 +1,185 @@
+package jsonSchema
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scoredLoop terminates on the raw quality score and keeps the iteration with the highest weighted aggregate
+func scoredLoop() Definition {
+	return Definition{
+		Type: String,
+		ScoringCriteria: &ScoringCriteria{Dimensions: map[string]ScoringDimension{
+			"quality":  {Type: ScoreNumeric, Weight: 0.5},
+			"accuracy": {Type: ScoreNumeric, Weight: 0.5},
+		}},
+		RecursiveLoop: &RecursiveLoop{
+			MaxIterations: 4,
+			Selection:     SelectHighestScore,
+			TerminationPoint: &DecisionPoint{Strategy: RouteByScore, Branches: []ConditionalBranch{{
+				Name:       "good enough",
+				Conditions: []Condition{{Field: "quality", Operator: OpGreaterThanOrEqual, Value: 80}},
+			}}},
+		},
+	}
+}
+
+func TestSimulateLoopScoredWithFailure(t *testing.T) {
+	iterations := []LoopIteration{
+		{Err: errors.New("timeout")},
+		{Output: "a", Scores: map[string]any{"quality": 60, "accuracy": 100}},
+		{Output: "b", Scores: map[string]any{"quality": 70, "accuracy": 20}},
+		{Output: "c", Scores: map[string]any{"quality": 85, "accuracy": 30}},
+		{Output: "d", Scores: map[string]any{"quality": 90, "accuracy": 90}},
+	}
+	simulation, err := scoredLoop().SimulateLoop(iterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the raw quality of 85 terminates the loop although its aggregate of 0.575 is below the first iteration's 0.8
+	if simulation.TerminatedAt != 3 || simulation.TerminatedBy != "good enough" {
+		t.Errorf("terminated at %d by %q, want 3 by %q", simulation.TerminatedAt, simulation.TerminatedBy, "good enough")
+	}
+	wantScores := []float64{0, 0.8, 0.45, 0.575}
+	if len(simulation.Iterations) != len(wantScores) {
+		t.Fatalf("ran %d iterations, want %d", len(simulation.Iterations), len(wantScores))
+	}
+	for i, iteration := range simulation.Iterations {
+		if iteration.Failed != (i == 0) {
+			t.Errorf("iteration %d failed = %v", i, iteration.Failed)
+		}
+		if math.Abs(iteration.Score-wantScores[i]) > 1e-9 {
+			t.Errorf("iteration %d score = %v, want %v", i, iteration.Score, wantScores[i])
+		}
+	}
+	if simulation.Iterations[0].Termination != nil {
+		t.Error("failed iteration evaluated the termination point")
+	}
+
+	want := map[SelectionStrategy]LoopSelection{
+		SelectHighestScore: {Indexes: []int{1}, Output: "a"},
+		SelectLowestScore:  {Indexes: []int{2}, Output: "b"},
+		SelectLatest:       {Indexes: []int{3}, Output: "c"},
+		SelectFirst:        {Indexes: []int{1}, Output: "a"},
+		SelectAll:          {Indexes: []int{1, 2, 3}, Output: []any{"a", "b", "c"}},
+	}
+	if !reflect.DeepEqual(simulation.Selections, want) {
+		t.Errorf("Selections = %+v, want %+v", simulation.Selections, want)
+	}
+	if simulation.Selected == nil || !reflect.DeepEqual(*simulation.Selected, want[SelectHighestScore]) {
+		t.Errorf("Selected = %+v, want %+v", simulation.Selected, want[SelectHighestScore])
+	}
+
+	wantString := `iteration 0: failed
+iteration 1: score 0.800, continues
+iteration 2: score 0.450, continues
+iteration 3: score 0.575, terminated by good enough
+highest keeps [1] (selected)
+lowest keeps [2]
+latest keeps [3]
+first keeps [1]
+all keeps [1, 2, 3]
+`
+	if got := simulation.String(); got != wantString {
+		t.Errorf("String() =\n%s\nwant\n%s", got, wantString)
+	}
+}
+
+func TestSimulateLoop(t *testing.T) {
+	tests := []struct {
+		name         string
+		loop         RecursiveLoop
+		iterations   []LoopIteration
+		ran          int
+		terminatedAt int
+		selections   map[SelectionStrategy][]int
+	}{
+		{
+			name:         "runs out of iterations",
+			loop:         RecursiveLoop{MaxIterations: 2, Selection: SelectLatest},
+			iterations:   []LoopIteration{{Output: "a"}, {Output: "b"}, {Output: "c"}},
+			ran:          2,
+			terminatedAt: -1,
+			selections:   map[SelectionStrategy][]int{SelectLatest: {1}, SelectFirst: {0}, SelectAll: {0, 1}},
+		},
+		{
+			name:         "runs out of mocked iterations",
+			loop:         RecursiveLoop{MaxIterations: 5},
+			iterations:   []LoopIteration{{Output: "a"}},
+			ran:          1,
+			terminatedAt: -1,
+			selections:   map[SelectionStrategy][]int{SelectLatest: {0}, SelectFirst: {0}, SelectAll: {0}},
+		},
+		{
+			name: "terminates on a field",
+			loop: RecursiveLoop{MaxIterations: 5, TerminationPoint: &DecisionPoint{Strategy: RouteByField, Branches: []ConditionalBranch{{
+				Name:       "approved",
+				Conditions: []Condition{{Field: "status", Operator: OpEqual, Value: "approved"}},
+			}}}},
+			iterations: []LoopIteration{
+				{Output: map[string]any{"status": "draft"}},
+				{Output: map[string]any{"status": "approved"}},
+				{Output: map[string]any{"status": "draft"}},
+			},
+			ran:          2,
+			terminatedAt: 1,
+			selections:   map[SelectionStrategy][]int{SelectLatest: {1}, SelectFirst: {0}, SelectAll: {0, 1}},
+		},
+		{
+			name:         "every iteration failed",
+			loop:         RecursiveLoop{MaxIterations: 2},
+			iterations:   []LoopIteration{{Err: errors.New("a")}, {Err: errors.New("b")}},
+			ran:          2,
+			terminatedAt: -1,
+			selections:   map[SelectionStrategy][]int{SelectAll: {}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loop := tt.loop
+			simulation, err := Definition{Type: String, RecursiveLoop: &loop}.SimulateLoop(tt.iterations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(simulation.Iterations) != tt.ran || simulation.TerminatedAt != tt.terminatedAt {
+				t.Errorf("ran %d, terminated at %d, want %d and %d", len(simulation.Iterations), simulation.TerminatedAt, tt.ran, tt.terminatedAt)
+			}
+			selections := make(map[SelectionStrategy][]int, len(simulation.Selections))
+			for strategy, selection := range simulation.Selections {
+				selections[strategy] = selection.Indexes
+			}
+			if !reflect.DeepEqual(selections, tt.selections) {
+				t.Errorf("selections = %v, want %v", selections, tt.selections)
+			}
+		})
+	}
+}
+
+func TestSimulateLoopErrors(t *testing.T) {
+	scored := scoredLoop()
+	tests := []struct {
+		name       string
+		def        Definition
+		iterations []LoopIteration
+		wantErr    string
+	}{
+		{"no loop", Definition{Type: String}, nil, "definition has no recursive loop"},
+		{"no iterations", Definition{Type: String, RecursiveLoop: &RecursiveLoop{}}, nil, "maxIterations must be positive"},
+		{"unknown selection", Definition{Type: String, RecursiveLoop: &RecursiveLoop{MaxIterations: 1, Selection: "best"}}, nil, `unknown selection strategy "best"`},
+		{"unscored highest", Definition{Type: String, RecursiveLoop: &RecursiveLoop{MaxIterations: 1, Selection: SelectHighestScore}}, nil, "highest selection requires scoring criteria"},
+		{"missing score", scored, []LoopIteration{{Output: "a", Scores: map[string]any{"quality": 50}}}, `iteration 0: dimension "accuracy": missing score`},
+		{"nan score", scored, []LoopIteration{{Output: "a", Scores: map[string]any{"quality": math.NaN(), "accuracy": 50}}}, "iteration 0: dimension \"quality\": numeric score NaN is not finite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.def.SimulateLoop(tt.iterations)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SimulateLoop() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// fieldPlaceholder matches the {field_name} placeholders of MemoryTrigger.QueryTemplate, which are filled in by the server
+var fieldPlaceholder = regexp.MustCompile(`(^|[^{])\{([A-Za-z_][A-Za-z0-9_.]*)\}`)
+
+// Templates renders the prompts of Definitions and ComplexSystems as text/template templates with per-request variables,
+// ie an Instruction of "Write a reply to {{.customer.name}}" rendered with {"customer": {"name": "Ada"}}.
+// Only text containing {{ is treated as a template, so single brace placeholders such as {topic_analysis} are left
+// for the server to fill in. A Templates is safe for concurrent use once created.
+type Templates struct {
+	base *template.Template
+}
+
+// NewTemplates parses the named prompt snippets, which templates include with {{template "name" .}}.
+// The funcs are available to the snippets and to every rendered prompt.
+func NewTemplates(snippets map[string]string, funcs template.FuncMap) (*Templates, error) {
+	base := template.New("").Option("missingkey=error").Funcs(funcs)
+	for _, name := range sortedKeys(snippets) {
+		if _, err := base.New(name).Parse(snippets[name]); err != nil {
+			return nil, fmt.Errorf("error parsing snippet %q: %v", name, err)
+		}
+	}
+	return &Templates{base: base}, nil
+}
+
+// Render returns the Definition with its prompts rendered with vars, see Templates.Render
+func (d Definition) Render(vars map[string]any) (Definition, error) {
+	templates, _ := NewTemplates(nil, nil)
+	return templates.Render(d, vars)
+}
+
+// Render returns a copy of the Definition with its prompts rendered with vars: Instruction, SystemPrompt,
+// OverridePrompt, HashMap.KeyInstruction, NarrowFocus.Prompt, DecisionPoint.EvaluationPrompt and
+// RecursiveLoop.FeedbackPrompt, throughout the tree.
+// Variables missing from vars are errors rather than "<no value>", every failing prompt is returned as a
+// ValidationError joined with errors.Join so all missing variables can be fixed at once.
+func (t *Templates) Render(def Definition, vars map[string]any) (Definition, error) {
+	r := &templateRenderer{templates: t, vars: vars}
+	rendered, _ := Rewrite(def, r.definition)
+	if err := errors.Join(r.errs...); err != nil {
+		return Definition{}, err
+	}
+	return rendered, nil
+}
+
+// RenderComplexSystem returns a copy of the system with the prompts of every Definition rendered as in Render,
+// along with the PrimarySystemPrompt, the MainThread AggregationPrompt and intervention OverridePrompts,
+// and the Memory QueryTemplates. It also checks that the {field} placeholders of the QueryTemplates name
+// a property of the RootSchema.
+func (t *Templates) RenderComplexSystem(system ComplexSystem, vars map[string]any) (ComplexSystem, error) {
+	r := &templateRenderer{templates: t, vars: vars}
+	rendered, _ := RewriteComplexSystem(system, r.definition)
+
+	r.render("$.primarySystemPrompt", &rendered.PrimarySystemPrompt)
+	path := "$.mainThread"
+	for thread := rendered.MainThread; thread != nil; thread = thread.ParentThread {
+		r.render(path+".aggregationPrompt", &thread.AggregationPrompt)
+		for i := range thread.InterventionRules {
+			r.render(fmt.Sprintf("%s.interventionRules[%d].action.overridePrompt", path, i), thread.InterventionRules[i].Action.OverridePrompt)
+		}
+		path += ".parentThread"
+	}
+
+	if rendered.Memory != nil {
+		fields := propertyNames(rendered.RootSchema)
+		for i := range rendered.Memory.RetrievalTriggers {
+			triggerPath := fmt.Sprintf("$.memory.retrievalTriggers[%d].queryTemplate", i)
+			r.render(triggerPath, &rendered.Memory.RetrievalTriggers[i].QueryTemplate)
+			for _, match := range fieldPlaceholder.FindAllStringSubmatch(rendered.Memory.RetrievalTriggers[i].QueryTemplate, -1) {
+				if !fields[match[2]] {
+					r.errs = append(r.errs, ValidationError{Path: triggerPath, Reason: fmt.Sprintf("{%s} does not name a property of the root schema", match[2])})
+				}
+			}
+		}
+	}
+
+	if err := errors.Join(r.errs...); err != nil {
+		return ComplexSystem{}, err
+	}
+	return rendered, nil
+}
+
+// templateRenderer collects the errors of a single Render call
+type templateRenderer struct {
+	templates *Templates
+	vars      map[string]any
+	errs      []error
+}
+
+func (r *templateRenderer) definition(path string, def Definition) (Definition, error) {
+	r.render(path+".instruction", &def.Instruction)
+	r.render(path+".systemPrompt", def.SystemPrompt)
+	r.render(path+".overridePrompt", def.OverridePrompt)
+	if def.HashMap != nil {
+		r.render(path+".HashMap.keyInstruction", &def.HashMap.KeyInstruction)
+	}
+	if def.NarrowFocus != nil {
+		r.render(path+".narrowFocus.prompt", &def.NarrowFocus.Prompt)
+	}
+	if def.DecisionPoint != nil {
+		r.render(path+".decisionPoint.evaluationPrompt", &def.DecisionPoint.EvaluationPrompt)
+	}
+	if def.RecursiveLoop != nil {
+		r.render(path+".recursiveLoop.feedbackPrompt", &def.RecursiveLoop.FeedbackPrompt)
+	}
+	return def, nil
+}
+
+// render replaces the text with the rendered template, recording an error instead when it fails
+func (r *templateRenderer) render(path string, text *string) {
+	if text == nil || !strings.Contains(*text, "{{") {
+		return
+	}
+	tmpl, err := r.templates.base.Clone()
+	if err == nil {
+		tmpl, err = tmpl.New(path).Parse(*text)
+	}
+	if err != nil {
+		r.errs = append(r.errs, ValidationError{Path: path, Reason: trimTemplateError(path, err)})
+		return
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, r.vars); err != nil {
+		r.errs = append(r.errs, ValidationError{Path: path, Reason: trimTemplateError(path, err)})
+		return
+	}
+	*text = out.String()
+}
+
+// trimTemplateError removes the template name from the error, as it repeats the path
+func trimTemplateError(path string, err error) string {
+	message := strings.TrimPrefix(err.Error(), "template: ")
+	if _, rest, ok := strings.Cut(message, ": "); ok {
+		message = rest
+	}
+	return strings.TrimPrefix(message, fmt.Sprintf("executing %q at ", path))
+}
+
+// propertyNames collects the names of the properties in the tree, both as keys and as dotted paths
+func propertyNames(def Definition) map[string]bool {
+	names := make(map[string]bool)
+	_ = Walk(&def, Visitor{Pre: func(path string, d *Definition) error {
+		for key := range d.Properties {
+			names[key] = true
+			if path == "$" {
+				continue
+			}
+			names[strings.TrimPrefix(path, "$.")+"."+key] = true
+		}
+		return nil
+	}})
+	return names
+}
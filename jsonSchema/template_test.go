@@ -0,0 +1,237 @@
+package jsonSchema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRender(t *testing.T) {
+	vars := map[string]any{"customer": map[string]any{"name": "Ada"}, "tone": "formal"}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"variable", "Write to {{.customer.name}}", "Write to Ada"},
+		{"several variables", "A {{.tone}} reply to {{.customer.name}}", "A formal reply to Ada"},
+		{"no template", "Write a reply", "Write a reply"},
+		{"server placeholder", "Summarise {topic_analysis}", "Summarise {topic_analysis}"},
+		{"placeholder beside a template", "Use {topic_analysis} for {{.customer.name}}", "Use {topic_analysis} for Ada"},
+		{"builtin function", `{{printf "%q" .tone}}`, `"formal"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Definition{Type: String, Instruction: tt.text}.Render(vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Instruction != tt.want {
+				t.Errorf("Instruction = %q, want %q", got.Instruction, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEveryPrompt(t *testing.T) {
+	prompt := "{{.x}}"
+	override := "{{.x}}"
+	def := Definition{
+		Type:           Object,
+		Instruction:    "{{.x}}",
+		SystemPrompt:   &prompt,
+		OverridePrompt: &override,
+		NarrowFocus:    &Focus{Prompt: "{{.x}}"},
+		DecisionPoint:  &DecisionPoint{EvaluationPrompt: "{{.x}}", Branches: []ConditionalBranch{{Then: Definition{Type: String, Instruction: "{{.x}}"}}}},
+		RecursiveLoop:  &RecursiveLoop{FeedbackPrompt: "{{.x}}"},
+		Properties: map[string]Definition{
+			"tags":   {Type: Array, Items: &Definition{Type: String, Instruction: "{{.x}}"}},
+			"scores": {Type: Map, HashMap: &HashMap{KeyInstruction: "{{.x}}", FieldDefinition: &Definition{Type: Number, Instruction: "{{.x}}"}}},
+		},
+	}
+	got, err := def.Render(map[string]any{"x": "done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := []string{
+		got.Instruction, *got.SystemPrompt, *got.OverridePrompt, got.NarrowFocus.Prompt,
+		got.DecisionPoint.EvaluationPrompt, got.DecisionPoint.Branches[0].Then.Instruction, got.RecursiveLoop.FeedbackPrompt,
+		got.Properties["tags"].Items.Instruction, got.Properties["scores"].HashMap.KeyInstruction,
+		got.Properties["scores"].HashMap.FieldDefinition.Instruction,
+	}
+	for i, text := range rendered {
+		if text != "done" {
+			t.Errorf("prompt %d = %q, want %q", i, text, "done")
+		}
+	}
+	if def.Instruction != "{{.x}}" || *def.SystemPrompt != "{{.x}}" || def.Properties["tags"].Items.Instruction != "{{.x}}" {
+		t.Error("Render modified the original definition")
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		def       Definition
+		wantPaths []string
+		wantText  string
+	}{
+		{
+			name:      "missing variable",
+			def:       Definition{Type: String, Instruction: "Write to {{.customer}}"},
+			wantPaths: []string{"$.instruction"},
+			wantText:  "customer",
+		},
+		{
+			name:      "parse error",
+			def:       Definition{Type: String, Instruction: "Write to {{.customer"},
+			wantPaths: []string{"$.instruction"},
+			wantText:  "unclosed action",
+		},
+		{
+			name:      "unknown snippet",
+			def:       Definition{Type: String, Instruction: `{{template "missing" .}}`},
+			wantPaths: []string{"$.instruction"},
+			wantText:  "missing",
+		},
+		{
+			name: "every failing prompt",
+			def: Definition{
+				Type:          Object,
+				DecisionPoint: &DecisionPoint{EvaluationPrompt: "{{.a}}"},
+				Properties: map[string]Definition{
+					"title": {Type: String, Instruction: "{{.b}}"},
+					"body":  {Type: String, Instruction: "{{.c}}"},
+				},
+			},
+			wantPaths: []string{"$.body.instruction", "$.title.instruction", "$.decisionPoint.evaluationPrompt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.def.Render(map[string]any{})
+			if err == nil {
+				t.Fatal("Render() returned no error")
+			}
+			paths := validationPaths(err)
+			if strings.Join(paths, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error %q does not mention %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestNewTemplates(t *testing.T) {
+	templates, err := NewTemplates(
+		map[string]string{"signoff": "Sign as {{upper .author}}"},
+		template.FuncMap{"upper": strings.ToUpper},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := Definition{Type: String, Instruction: `Write a {{upper "reply"}}. {{template "signoff" .}}`}
+	got, err := templates.Render(def, map[string]any{"author": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Write a REPLY. Sign as ADA"; got.Instruction != want {
+		t.Errorf("Instruction = %q, want %q", got.Instruction, want)
+	}
+
+	if _, err := NewTemplates(map[string]string{"broken": "{{.author"}, nil); err == nil || !strings.Contains(err.Error(), `"broken"`) {
+		t.Errorf("NewTemplates() error = %v, want a parse error naming the snippet", err)
+	}
+}
+
+func TestRenderComplexSystem(t *testing.T) {
+	override := "Focus on {{.topic}}"
+	system := ComplexSystem{
+		PrimarySystemPrompt: "You write about {{.topic}}",
+		RootSchema: Definition{
+			Type:        Object,
+			Instruction: "An article on {{.topic}}",
+			Properties: map[string]Definition{
+				"outline": {Type: Object, Properties: map[string]Definition{"summary": {Type: String}}},
+			},
+		},
+		MainThread: &MainThreadConfig{
+			AggregationPrompt: "Combine the {{.topic}} sections",
+			InterventionRules: []InterventionRule{{Action: InterventionAction{
+				OverridePrompt:   &override,
+				ModifyDefinition: &Definition{Type: String, Instruction: "Rewrite on {{.topic}}"},
+			}}},
+			ParentThread: &MainThreadConfig{AggregationPrompt: "Review the {{.topic}} article"},
+		},
+		Memory: &MemoryConfig{RetrievalTriggers: []MemoryTrigger{
+			{QueryTemplate: "{{.topic}} examples like {outline.summary}"},
+			{QueryTemplate: "Articles like {outline}"},
+		}},
+	}
+
+	templates, _ := NewTemplates(nil, nil)
+	got, err := templates.RenderComplexSystem(system, map[string]any{"topic": "Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"primary system prompt", got.PrimarySystemPrompt, "You write about Go"},
+		{"root schema", got.RootSchema.Instruction, "An article on Go"},
+		{"aggregation prompt", got.MainThread.AggregationPrompt, "Combine the Go sections"},
+		{"override prompt", *got.MainThread.InterventionRules[0].Action.OverridePrompt, "Focus on Go"},
+		{"modify definition", got.MainThread.InterventionRules[0].Action.ModifyDefinition.Instruction, "Rewrite on Go"},
+		{"parent thread", got.MainThread.ParentThread.AggregationPrompt, "Review the Go article"},
+		{"query template", got.Memory.RetrievalTriggers[0].QueryTemplate, "Go examples like {outline.summary}"},
+		{"original", *system.MainThread.InterventionRules[0].Action.OverridePrompt, "Focus on {{.topic}}"},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %q, want %q", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestRenderComplexSystemErrors(t *testing.T) {
+	system := ComplexSystem{
+		PrimarySystemPrompt: "{{.missing}}",
+		RootSchema:          Definition{Type: Object, Properties: map[string]Definition{"summary": {Type: String}}},
+		MainThread:          &MainThreadConfig{ParentThread: &MainThreadConfig{AggregationPrompt: "{{.missing}}"}},
+		Memory: &MemoryConfig{RetrievalTriggers: []MemoryTrigger{
+			{QueryTemplate: "Like {summary}"},
+			{QueryTemplate: "Like {title} and {{`{{literal}}`}}"},
+		}},
+	}
+	templates, _ := NewTemplates(nil, nil)
+	_, err := templates.RenderComplexSystem(system, map[string]any{})
+	want := []string{"$.primarySystemPrompt", "$.mainThread.parentThread.aggregationPrompt", "$.memory.retrievalTriggers[1].queryTemplate"}
+	if paths := validationPaths(err); strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("error paths = %v, want %v", paths, want)
+	}
+	if !strings.Contains(err.Error(), "{title} does not name a property") {
+		t.Errorf("error %q does not report the unknown placeholder", err)
+	}
+}
+
+// validationPaths returns the paths of the ValidationErrors joined in err, in order
+func validationPaths(err error) []string {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for _, err := range joined.Unwrap() {
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) {
+			paths = append(paths, validationErr.Path)
+		}
+	}
+	return paths
+}
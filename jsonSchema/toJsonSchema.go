@@ -0,0 +1,82 @@
+package jsonSchema
+
+import "sort"
+
+// JSONSchemaDialect is the JSON Schema draft documents produced by ToJSONSchema declare in $schema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// ToJSONSchema converts the Definition to a standard JSON Schema (draft 2020-12) document describing the generated object.
+// Instructions become descriptions and every property is required, as every property is generated.
+// Map and HashMap become objects validated through additionalProperties, Byte a base64 encoded string and Vector an array of numbers.
+// Generation settings such as Model, DecisionPoint or RecursiveLoop have no JSON Schema equivalent and are left out.
+func (d Definition) ToJSONSchema() map[string]interface{} {
+	schema := d.toJSONSchema()
+	schema["$schema"] = JSONSchemaDialect
+	return schema
+}
+
+// toJSONSchema converts a Definition without the $schema keyword, so it can be nested in another schema
+func (d Definition) toJSONSchema() map[string]interface{} {
+	schema := make(map[string]interface{})
+	if d.Instruction != "" {
+		schema["description"] = d.Instruction
+	}
+
+	// a HashMap always outputs a map, whatever the type says
+	if d.HashMap != nil {
+		schema["type"] = string(Object)
+		if d.HashMap.KeyInstruction != "" {
+			schema["propertyNames"] = map[string]interface{}{"description": d.HashMap.KeyInstruction}
+		}
+		if d.HashMap.FieldDefinition != nil {
+			schema["additionalProperties"] = d.HashMap.FieldDefinition.toJSONSchema()
+		} else {
+			schema["additionalProperties"] = true
+		}
+		return schema
+	}
+
+	switch d.Type {
+	case Object:
+		schema["type"] = string(Object)
+		d.addJSONSchemaProperties(schema)
+	case Map:
+		schema["type"] = string(Object)
+		d.addJSONSchemaProperties(schema)
+		schema["additionalProperties"] = true
+	case Array:
+		schema["type"] = string(Array)
+		if d.Items != nil {
+			schema["items"] = d.Items.toJSONSchema()
+		}
+	case Byte:
+		schema["type"] = string(String)
+		schema["contentEncoding"] = "base64"
+	case Vector:
+		schema["type"] = string(Array)
+		schema["items"] = map[string]interface{}{"type": string(Number)}
+	case "":
+	default:
+		schema["type"] = string(d.Type)
+	}
+
+	return schema
+}
+
+// addJSONSchemaProperties adds the properties of the Definition, all of which are required
+func (d Definition) addJSONSchemaProperties(schema map[string]interface{}) {
+	if len(d.Properties) == 0 {
+		return
+	}
+
+	properties := make(map[string]interface{}, len(d.Properties))
+	required := make([]string, 0, len(d.Properties))
+	for key, value := range d.Properties {
+		properties[key] = value.toJSONSchema()
+		required = append(required, key)
+	}
+	sort.Strings(required)
+
+	schema["properties"] = properties
+	schema["required"] = required
+}
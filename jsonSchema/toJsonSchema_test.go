@@ -0,0 +1,44 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToJSONSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		def  Definition
+		want map[string]interface{}
+	}{
+		{"string with instruction", Definition{Type: String, Instruction: "a name"}, map[string]interface{}{"type": "string", "description": "a name"}},
+		{"untyped", Definition{Instruction: "anything"}, map[string]interface{}{"description": "anything"}},
+		{"object", Definition{Type: Object, Properties: map[string]Definition{"b": {Type: Integer}, "a": {Type: Boolean}}}, map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"a": map[string]interface{}{"type": "boolean"}, "b": map[string]interface{}{"type": "integer"}},
+			"required":   []string{"a", "b"},
+		}},
+		{"map", Definition{Type: Map}, map[string]interface{}{"type": "object", "additionalProperties": true}},
+		{"hash map", Definition{Type: String, HashMap: &HashMap{KeyInstruction: "a city", FieldDefinition: &Definition{Type: Number}}}, map[string]interface{}{
+			"type":                 "object",
+			"propertyNames":        map[string]interface{}{"description": "a city"},
+			"additionalProperties": map[string]interface{}{"type": "number"},
+		}},
+		{"hash map without fields", Definition{Type: Object, HashMap: &HashMap{}}, map[string]interface{}{"type": "object", "additionalProperties": true}},
+		{"array", Definition{Type: Array, Items: &Definition{Type: String}}, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+		{"byte", Definition{Type: Byte}, map[string]interface{}{"type": "string", "contentEncoding": "base64"}},
+		{"vector", Definition{Type: Vector}, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.def.ToJSONSchema()
+			if got["$schema"] != JSONSchemaDialect {
+				t.Errorf("$schema = %v, want %s", got["$schema"], JSONSchemaDialect)
+			}
+			delete(got, "$schema")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToJSONSchema() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,31 +1,111 @@
 package jsonSchema
 
-// ToMap converts the Definition struct to a map representation
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ToMap converts the Definition struct to a map representation.
+// Every set field is included under its JSON name, with nested structs such as DecisionPoint, ModelConfig and
+// the Definitions in Properties and Items converted to maps as well. Empty fields are left out.
+// Values keep their Go types, ie type is a DataType and processingOrder a []string, FromMap reverses it.
 func (d Definition) ToMap() map[string]interface{} {
-	result := make(map[string]interface{})
+	return mapValue(reflect.ValueOf(d)).(map[string]interface{})
+}
 
-	if d.Type != "" {
-		result["type"] = d.Type
+// FromMap builds a Definition from a map in the form returned by ToMap or decoded from a JSON or YAML config.
+// Keys are the JSON names of the fields, unknown keys are reported as errors so typos do not go unnoticed.
+// Numbers in untyped fields such as Condition.Value are decoded as float64, as encoding/json does.
+func FromMap(m map[string]interface{}) (*Definition, error) {
+	data, err := json.Marshal(normaliseMapValue(m))
+	if err != nil {
+		return nil, fmt.Errorf("error encoding definition map: %v", err)
 	}
-	if d.Instruction != "" {
-		result["instruction"] = d.Instruction
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var def Definition
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("error decoding definition map: %v", err)
 	}
-	if len(d.Properties) > 0 {
-		propertiesMap := make(map[string]interface{})
-		for key, value := range d.Properties {
-			propertiesMap[key] = value.ToMap()
+	return &def, nil
+}
+
+// mapValue converts structs to maps keyed by their JSON names, leaving basic values and slices of them as they are
+func mapValue(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return mapValue(value.Elem())
+	case reflect.Struct:
+		result := make(map[string]interface{})
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() || isEmptyValue(value.Field(i)) {
+				continue
+			}
+			result[jsonFieldName(field)] = mapValue(value.Field(i))
 		}
-		result["properties"] = propertiesMap
-	}
-	if d.Items != nil {
-		result["items"] = d.Items.ToMap()
-	}
-	if d.Model != "" {
-		result["model"] = d.Model
+		return result
+	case reflect.Map:
+		if isBasicKind(value.Type().Elem().Kind()) {
+			return deepCopy(value).Interface()
+		}
+		result := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(iter.Key().Interface())] = mapValue(iter.Value())
+		}
+		return result
+	case reflect.Slice:
+		if elem := value.Type().Elem(); isBasicKind(elem.Kind()) || elem.Kind() == reflect.Slice && isBasicKind(elem.Elem().Kind()) {
+			return deepCopy(value).Interface()
+		}
+		result := make([]interface{}, value.Len())
+		for i := range result {
+			result[i] = mapValue(value.Index(i))
+		}
+		return result
+	case reflect.Invalid:
+		return nil
 	}
-	if len(d.ProcessingOrder) > 0 {
-		result["processingOrder"] = d.ProcessingOrder
+	return value.Interface()
+}
+
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
 	}
+	return false
+}
 
-	return result
+// normaliseMapValue converts the map[interface{}]interface{} produced by some YAML decoders to map[string]interface{}
+// so the value can be encoded as JSON
+func normaliseMapValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalised := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			normalised[key] = normaliseMapValue(child)
+		}
+		return normalised
+	case map[interface{}]interface{}:
+		normalised := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			normalised[fmt.Sprint(key)] = normaliseMapValue(child)
+		}
+		return normalised
+	case []interface{}:
+		normalised := make([]interface{}, len(v))
+		for i, child := range v {
+			normalised[i] = normaliseMapValue(child)
+		}
+		return normalised
+	}
+	return value
 }
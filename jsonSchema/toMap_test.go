@@ -0,0 +1,100 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestToMapFromMapRoundTrip(t *testing.T) {
+	prompt := "Be concise"
+	tests := []struct {
+		name string
+		def  Definition
+	}{
+		{"score based routing", ExampleSimpleScoreBasedRouting()},
+		{"hybrid routing", ExampleHybridRouting()},
+		{"recursive loop", ExampleRecursiveLoop()},
+		{"nested decision tree", ExampleNestedDecisionTree()},
+		{"every pointer", Definition{
+			Type:         Object,
+			SystemPrompt: &prompt,
+			HashMap:      &HashMap{KeyInstruction: "a key", FieldDefinition: &Definition{Type: Integer}},
+			ModelConfig:  &ModelConfig{Temperature: 0.5, LogProbs: true},
+			Req:          &RequestFormat{URL: "https://example.com", Method: "GET"},
+			SendImage:    &SendImage{ImagesData: [][]byte{[]byte("png")}},
+			Epistemic:    EpistemicValidation{Active: true, Judges: 3},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromMap(tt.def.ToMap())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changes := Diff(tt.def, *got); len(changes) > 0 {
+				t.Errorf("round trip changed the definition: %v", changes)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	def := Definition{
+		Type:            Object,
+		Instruction:     "An article",
+		ProcessingOrder: []string{"title"},
+		Properties:      map[string]Definition{"title": {Type: String}},
+		DecisionPoint:   &DecisionPoint{Name: "route"},
+	}
+	want := map[string]interface{}{
+		"type":            Object,
+		"instruction":     "An article",
+		"processingOrder": []string{"title"},
+		"properties":      map[string]interface{}{"title": map[string]interface{}{"type": String}},
+		"decisionPoint":   map[string]interface{}{"name": "route"},
+	}
+	got := def.ToMap()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap() = %#v, want %#v", got, want)
+	}
+
+	// the map is a copy
+	got["processingOrder"].([]string)[0] = "changed"
+	if def.ProcessingOrder[0] != "title" {
+		t.Error("editing the map changed the definition")
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[string]interface{}
+		want *Definition
+		err  string
+	}{
+		{"json names", map[string]interface{}{"type": "string", "instruction": "A name"}, &Definition{Type: String, Instruction: "A name"}, ""},
+		{"yaml style maps", map[string]interface{}{"type": "object", "properties": map[interface{}]interface{}{
+			"age": map[interface{}]interface{}{"type": "integer"},
+		}}, &Definition{Type: Object, Properties: map[string]Definition{"age": {Type: Integer}}}, ""},
+		{"unknown key", map[string]interface{}{"type": "string", "instructions": "typo"}, nil, "instructions"},
+		{"wrong type", map[string]interface{}{"processingOrder": "title"}, nil, "processingOrder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromMap(tt.m)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want one mentioning %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromMap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
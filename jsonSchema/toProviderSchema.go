@@ -0,0 +1,258 @@
+package jsonSchema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// openAIMaxNesting and openAIMaxProperties are the limits OpenAI places on strict structured output schemas
+	openAIMaxNesting    = 10
+	openAIMaxProperties = 5000
+)
+
+// openAISchemaName is the pattern OpenAI requires for the name of a json_schema response format
+var openAISchemaName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ExportIssue describes a feature of a Definition that cannot be represented in a provider's schema
+type ExportIssue struct {
+	Path    string `json:"path"`    //JSON pointer to the Definition holding the feature
+	Feature string `json:"feature"` //the Definition feature or type concerned
+	Reason  string `json:"reason"`
+}
+
+// String formats the issue for logging
+func (i ExportIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Path, i.Feature, i.Reason)
+}
+
+// providerTarget captures what a provider's structured output schema supports
+type providerTarget struct {
+	name                 string
+	upperCaseTypes       bool // Gemini uses the OpenAPI type enum, ie OBJECT and STRING
+	closedObjects        bool // strict mode requires additionalProperties false on every object
+	additionalProperties bool // maps can be described with additionalProperties
+	nullType             bool
+	propertyOrdering     bool
+	nonEmptyObjects      bool // objects must declare at least one property
+	maxNesting           int  // zero when the provider documents no limit
+}
+
+var (
+	openAITarget    = providerTarget{name: "OpenAI", closedObjects: true, nullType: true, maxNesting: openAIMaxNesting}
+	geminiTarget    = providerTarget{name: "Gemini", upperCaseTypes: true, propertyOrdering: true, nonEmptyObjects: true}
+	anthropicTarget = providerTarget{name: "Anthropic", additionalProperties: true, nullType: true}
+)
+
+// ToOpenAIResponseFormat converts the Definition to an OpenAI response_format using a strict json_schema.
+// Strict mode requires every property and closes every object, so maps are exported as JSON encoded strings.
+func (d Definition) ToOpenAIResponseFormat(name string) (map[string]interface{}, []ExportIssue) {
+	exporter := &providerExporter{target: openAITarget}
+	if !openAISchemaName.MatchString(name) {
+		exporter.report("#", "name", fmt.Sprintf("%q must be 1 to 64 letters, digits, underscores or dashes", name))
+	}
+	exporter.requireObjectRoot(d)
+
+	schema := exporter.convert(d, "#", 1)
+	if exporter.properties > openAIMaxProperties {
+		exporter.report("#", "properties", fmt.Sprintf("%d properties exceed the limit of %d", exporter.properties, openAIMaxProperties))
+	}
+
+	return map[string]interface{}{
+		"type": "json_schema",
+		"json_schema": map[string]interface{}{
+			"name":   name,
+			"strict": true,
+			"schema": schema,
+		},
+	}, exporter.issues
+}
+
+// ToGeminiResponseSchema converts the Definition to a Gemini responseSchema, to be sent with the application/json response mime type.
+// Gemini has no equivalent of additionalProperties, so maps are exported as JSON encoded strings.
+func (d Definition) ToGeminiResponseSchema() (map[string]interface{}, []ExportIssue) {
+	exporter := &providerExporter{target: geminiTarget}
+	return exporter.convert(d, "#", 1), exporter.issues
+}
+
+// ToAnthropicInputSchema converts the Definition to the input_schema of an Anthropic tool, which must describe an object
+func (d Definition) ToAnthropicInputSchema() (map[string]interface{}, []ExportIssue) {
+	exporter := &providerExporter{target: anthropicTarget}
+	exporter.requireObjectRoot(d)
+	return exporter.convert(d, "#", 1), exporter.issues
+}
+
+// providerExporter holds the state of a single provider export
+type providerExporter struct {
+	target     providerTarget
+	properties int
+	issues     []ExportIssue
+}
+
+func (e *providerExporter) report(path, feature, reason string) {
+	e.issues = append(e.issues, ExportIssue{Path: path, Feature: feature, Reason: reason})
+}
+
+func (e *providerExporter) requireObjectRoot(d Definition) {
+	if d.Type != Object || d.HashMap != nil {
+		e.report("#", "type", fmt.Sprintf("%s requires the root to be an object, got %s", e.target.name, d.Type))
+	}
+}
+
+// convert converts a single Definition, reporting features the provider cannot represent
+func (e *providerExporter) convert(d Definition, path string, depth int) map[string]interface{} {
+	if e.target.maxNesting > 0 && depth == e.target.maxNesting+1 {
+		e.report(path, "nesting", fmt.Sprintf("%s schemas may be nested at most %d levels deep", e.target.name, e.target.maxNesting))
+	}
+	e.reportGenerationFeatures(d, path)
+
+	schema := make(map[string]interface{})
+	if d.Instruction != "" {
+		schema["description"] = d.Instruction
+	}
+
+	if d.HashMap != nil || d.Type == Map {
+		e.convertMap(d, schema, path, depth)
+		return schema
+	}
+
+	switch d.Type {
+	case Object:
+		schema["type"] = e.typeName(Object)
+		e.addProperties(d, schema, path, depth)
+	case Array:
+		schema["type"] = e.typeName(Array)
+		if d.Items != nil {
+			schema["items"] = e.convert(*d.Items, path+"/items", depth+1)
+		} else {
+			e.report(path, "items", "arrays need an item definition, exported as an array of strings")
+			schema["items"] = map[string]interface{}{"type": e.typeName(String)}
+		}
+	case Byte:
+		e.report(path, string(Byte), "binary data is produced by ObjectWeaver rather than the model, exported as a base64 string")
+		schema["type"] = e.typeName(String)
+	case Vector:
+		e.report(path, string(Vector), "embeddings are computed by ObjectWeaver rather than the model, exported as an array of numbers")
+		schema["type"] = e.typeName(Array)
+		schema["items"] = map[string]interface{}{"type": e.typeName(Number)}
+	case Null:
+		if e.target.nullType {
+			schema["type"] = e.typeName(Null)
+		} else {
+			e.report(path, string(Null), fmt.Sprintf("%s has no null type, exported as a nullable string", e.target.name))
+			schema["type"] = e.typeName(String)
+			schema["nullable"] = true
+		}
+	case String, Number, Integer, Boolean:
+		schema["type"] = e.typeName(d.Type)
+	default:
+		e.report(path, "type", fmt.Sprintf("type %q is not supported, exported as a string", d.Type))
+		schema["type"] = e.typeName(String)
+	}
+	return schema
+}
+
+// convertMap exports a Map or HashMap through additionalProperties, or as a JSON encoded string when the provider cannot
+func (e *providerExporter) convertMap(d Definition, schema map[string]interface{}, path string, depth int) {
+	if !e.target.additionalProperties {
+		e.report(path, "HashMap", fmt.Sprintf("%s cannot describe objects with dynamic keys, exported as a JSON encoded string", e.target.name))
+		schema["type"] = e.typeName(String)
+		schema["description"] = joinInstruction(d.Instruction, "A JSON encoded object.")
+		return
+	}
+
+	schema["type"] = e.typeName(Object)
+	if d.HashMap != nil && d.HashMap.KeyInstruction != "" {
+		schema["propertyNames"] = map[string]interface{}{"description": d.HashMap.KeyInstruction}
+	}
+	if d.HashMap != nil && d.HashMap.FieldDefinition != nil {
+		schema["additionalProperties"] = e.convert(*d.HashMap.FieldDefinition, path+"/HashMap/fieldDefinition", depth+1)
+	} else {
+		schema["additionalProperties"] = true
+	}
+}
+
+// addProperties exports the properties of an object, all of which are required
+func (e *providerExporter) addProperties(d Definition, schema map[string]interface{}, path string, depth int) {
+	keys := make([]string, 0, len(d.Properties))
+	for key := range d.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	e.properties += len(keys)
+	if e.target.nonEmptyObjects && len(keys) == 0 {
+		e.report(path, "properties", fmt.Sprintf("%s rejects objects without properties", e.target.name))
+	}
+
+	properties := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		properties[key] = e.convert(d.Properties[key], path+"/properties/"+escapePointerToken(key), depth+1)
+	}
+
+	schema["properties"] = properties
+	schema["required"] = keys
+	if e.target.closedObjects {
+		schema["additionalProperties"] = false
+	}
+	if e.target.propertyOrdering && len(keys) > 0 {
+		schema["propertyOrdering"] = propertyOrdering(d.ProcessingOrder, keys)
+	}
+}
+
+// reportGenerationFeatures reports the ObjectWeaver features that change what is generated and have no schema equivalent
+func (e *providerExporter) reportGenerationFeatures(d Definition, path string) {
+	features := []struct {
+		name    string
+		present bool
+	}{
+		{"DecisionPoint", d.DecisionPoint != nil},
+		{"ScoringCriteria", d.ScoringCriteria != nil},
+		{"RecursiveLoop", d.RecursiveLoop != nil},
+		{"Epistemic", d.Epistemic.Active},
+		{"Req", d.Req != nil},
+		{"NarrowFocus", d.NarrowFocus != nil},
+		{"SelectFields", len(d.SelectFields) > 0},
+		{"TextToSpeech", d.TextToSpeech != nil},
+		{"SpeechToText", d.SpeechToText != nil},
+		{"Image", d.Image != nil},
+		{"SendImage", d.SendImage != nil},
+		{"SystemPrompt", d.SystemPrompt != nil},
+		{"OverridePrompt", d.OverridePrompt != nil},
+	}
+	for _, feature := range features {
+		if feature.present {
+			e.report(path, feature.name, fmt.Sprintf("has no equivalent in the %s schema and is ignored", e.target.name))
+		}
+	}
+}
+
+func (e *providerExporter) typeName(dataType DataType) string {
+	if e.target.upperCaseTypes {
+		return strings.ToUpper(string(dataType))
+	}
+	return string(dataType)
+}
+
+// propertyOrdering lists the fields of the processing order first, followed by the remaining keys
+func propertyOrdering(processingOrder, keys []string) []string {
+	present := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		present[key] = true
+	}
+
+	ordering := make([]string, 0, len(keys))
+	for _, key := range processingOrder {
+		if present[key] {
+			ordering = append(ordering, key)
+			delete(present, key)
+		}
+	}
+	for _, key := range keys {
+		if present[key] {
+			ordering = append(ordering, key)
+		}
+	}
+	return ordering
+}
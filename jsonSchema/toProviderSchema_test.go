@@ -0,0 +1,127 @@
+package jsonSchema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// issueFeatures lists the path and feature of every issue, in order
+func issueFeatures(issues []ExportIssue) []string {
+	var features []string
+	for _, issue := range issues {
+		features = append(features, issue.Path+" "+issue.Feature)
+	}
+	return features
+}
+
+func TestProviderSchemas(t *testing.T) {
+	person := Definition{Type: Object, ProcessingOrder: []string{"name"}, Properties: map[string]Definition{
+		"age":  {Type: Integer},
+		"name": {Type: String, Instruction: "Their name"},
+	}}
+	tags := Definition{Type: Object, Properties: map[string]Definition{
+		"tags": {Type: Map, HashMap: &HashMap{FieldDefinition: &Definition{Type: Number}}},
+	}}
+	properties := map[string]interface{}{
+		"age":  map[string]interface{}{"type": "integer"},
+		"name": map[string]interface{}{"type": "string", "description": "Their name"},
+	}
+
+	tests := []struct {
+		name   string
+		export func() (map[string]interface{}, []ExportIssue)
+		want   map[string]interface{}
+		issues []string
+	}{
+		{
+			name: "openai object",
+			export: func() (map[string]interface{}, []ExportIssue) {
+				return openAISchema(person.ToOpenAIResponseFormat("person"))
+			},
+			want: map[string]interface{}{"type": "object", "properties": properties, "required": []string{"age", "name"}, "additionalProperties": false},
+		},
+		{
+			name: "openai map",
+			export: func() (map[string]interface{}, []ExportIssue) {
+				return openAISchema(tags.ToOpenAIResponseFormat("tags"))
+			},
+			want: map[string]interface{}{"type": "object", "required": []string{"tags"}, "additionalProperties": false, "properties": map[string]interface{}{
+				"tags": map[string]interface{}{"type": "string", "description": "A JSON encoded object."},
+			}},
+			issues: []string{"#/properties/tags HashMap"},
+		},
+		{
+			name:   "gemini object",
+			export: person.ToGeminiResponseSchema,
+			want: map[string]interface{}{"type": "OBJECT", "required": []string{"age", "name"}, "propertyOrdering": []string{"name", "age"}, "properties": map[string]interface{}{
+				"age":  map[string]interface{}{"type": "INTEGER"},
+				"name": map[string]interface{}{"type": "STRING", "description": "Their name"},
+			}},
+		},
+		{
+			name:   "gemini null",
+			export: Definition{Type: Null}.ToGeminiResponseSchema,
+			want:   map[string]interface{}{"type": "STRING", "nullable": true},
+			issues: []string{"# null"},
+		},
+		{
+			name:   "anthropic map",
+			export: tags.ToAnthropicInputSchema,
+			want: map[string]interface{}{"type": "object", "required": []string{"tags"}, "properties": map[string]interface{}{
+				"tags": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "number"}},
+			}},
+		},
+		{
+			name:   "anthropic non object root",
+			export: Definition{Type: Byte, DecisionPoint: &DecisionPoint{}}.ToAnthropicInputSchema,
+			want:   map[string]interface{}{"type": "string"},
+			issues: []string{"# type", "# DecisionPoint", "# byte"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issues := tt.export()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schema = %#v, want %#v", got, tt.want)
+			}
+			if features := issueFeatures(issues); !reflect.DeepEqual(features, tt.issues) {
+				t.Errorf("issues = %v, want %v", issues, tt.issues)
+			}
+		})
+	}
+}
+
+// openAISchema extracts the json_schema of an OpenAI response format
+func openAISchema(format map[string]interface{}, issues []ExportIssue) (map[string]interface{}, []ExportIssue) {
+	return format["json_schema"].(map[string]interface{})["schema"].(map[string]interface{}), issues
+}
+
+func TestOpenAIResponseFormatLimits(t *testing.T) {
+	deep := Definition{Type: String}
+	for i := 0; i < openAIMaxNesting; i++ {
+		deep = Definition{Type: Object, Properties: map[string]Definition{"child": deep}}
+	}
+
+	tests := []struct {
+		name    string
+		def     Definition
+		schema  string
+		feature string
+	}{
+		{"invalid name", Definition{Type: Object, Properties: map[string]Definition{"a": {Type: String}}}, "has spaces", "name"},
+		{"too deep", deep, "deep", "nesting"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, issues := tt.def.ToOpenAIResponseFormat(tt.schema)
+			if format["type"] != "json_schema" {
+				t.Errorf("type = %v, want json_schema", format["type"])
+			}
+			features := strings.Join(issueFeatures(issues), ",")
+			if !strings.Contains(features, " "+tt.feature) {
+				t.Errorf("issues = %v, want one about %s", issues, tt.feature)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ValidationError describes a single problem found by Validate
+type ValidationError struct {
+	Path   string `json:"path"` //dotted path of the Definition, properties by name and items as []
+	Reason string `json:"reason"`
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Reason)
+}
+
+var (
+	knownDataTypes          = map[DataType]bool{Object: true, Number: true, Integer: true, String: true, Array: true, Null: true, Boolean: true, Map: true, Byte: true, Vector: true}
+	knownOperators          = map[ComparisonOperator]bool{OpEqual: true, OpNotEqual: true, OpGreaterThan: true, OpLessThan: true, OpGreaterThanOrEqual: true, OpLessThanOrEqual: true, OpIn: true, OpNotIn: true, OpContains: true}
+	knownStrategies         = map[RoutingStrategy]bool{RouteByScore: true, RouteByField: true, RouteByHybrid: true}
+	knownScoreTypes         = map[ScoreType]bool{ScoreNumeric: true, ScoreBoolean: true, ScoreCategorical: true}
+	knownAggregationMethods = map[AggregationMethod]bool{AggregateWeightedAverage: true, AggregateMinimum: true, AggregateMaximum: true, AggregateCustom: true}
+	knownSelections         = map[SelectionStrategy]bool{SelectHighestScore: true, SelectLowestScore: true, SelectLatest: true, SelectFirst: true, SelectAll: true}
+)
+
+// Validate checks the Definition tree for mistakes that would otherwise only surface as a failed or empty generation,
+// such as arrays without items, processing orders naming unknown fields or decision points without branches.
+// All problems are returned together as ValidationErrors joined with errors.Join, or nil when there are none.
+func (d Definition) Validate() error {
+	v := &validator{}
+	v.definition(&d, "$")
+	return errors.Join(v.errs...)
+}
+
+// validator collects the problems found in a Definition tree
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) definition(d *Definition, path string) {
+	switch {
+	case d.Type == "":
+		v.fail(path, "type is required")
+	case !knownDataTypes[d.Type]:
+		v.fail(path, "unknown type %q", d.Type)
+	}
+
+	if len(d.Properties) > 0 && d.Type != Object && d.Type != Map {
+		v.fail(path, "properties are only generated for objects, not %s", d.Type)
+	}
+	for _, field := range d.ProcessingOrder {
+		if _, ok := d.Properties[field]; !ok {
+			v.fail(path, "processing order names unknown property %q", field)
+		}
+	}
+
+	switch d.Type {
+	case Array:
+		if d.Items == nil {
+			v.fail(path, "arrays require items")
+		}
+	case Byte:
+		if d.TextToSpeech == nil && d.Image == nil {
+			v.fail(path, "byte requires either textToSpeech or image")
+		} else if d.TextToSpeech != nil && d.Image != nil {
+			v.fail(path, "byte takes either textToSpeech or image, not both")
+		}
+	}
+	if d.Items != nil && d.Type != Array {
+		v.fail(path, "items are only generated for arrays, not %s", d.Type)
+	}
+
+	if d.HashMap != nil {
+		if d.HashMap.FieldDefinition == nil {
+			v.fail(path, "hashMap requires a field definition")
+		} else {
+			v.definition(d.HashMap.FieldDefinition, path+".*")
+		}
+	}
+
+	if d.Epistemic.Active && d.Epistemic.Judges <= 0 {
+		v.fail(path, "epistemic validation requires at least one judge")
+	}
+
+	v.scoring(d.ScoringCriteria, path+".scoringCriteria")
+	v.decision(d.DecisionPoint, d.ScoringCriteria, path+".decisionPoint")
+	v.loop(d.RecursiveLoop, d.ScoringCriteria, path+".recursiveLoop")
+	v.modelConfig(d.ModelConfig, path+".modelConfig")
+
+	if d.Items != nil {
+		v.definition(d.Items, path+"[]")
+	}
+	keys := make([]string, 0, len(d.Properties))
+	for key := range d.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		property := d.Properties[key]
+		v.definition(&property, path+"."+key)
+	}
+}
+
+func (v *validator) scoring(criteria *ScoringCriteria, path string) {
+	if criteria == nil {
+		return
+	}
+	if len(criteria.Dimensions) == 0 {
+		v.fail(path, "scoring criteria require at least one dimension")
+	}
+	if criteria.AggregationMethod != "" && !knownAggregationMethods[criteria.AggregationMethod] {
+		v.fail(path, "unknown aggregation method %q", criteria.AggregationMethod)
+	}
+
+	names := make([]string, 0, len(criteria.Dimensions))
+	for name := range criteria.Dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dimension := criteria.Dimensions[name]
+		dimensionPath := path + ".dimensions." + name
+		if dimension.Type != "" && !knownScoreTypes[dimension.Type] {
+			v.fail(dimensionPath, "unknown score type %q", dimension.Type)
+		}
+		if dimension.Weight < 0 || dimension.Weight > 1 {
+			v.fail(dimensionPath, "weight %v must be between 0 and 1", dimension.Weight)
+		}
+		if dimension.Scale != nil && dimension.Scale.Min >= dimension.Scale.Max {
+			v.fail(dimensionPath, "scale minimum %d must be below the maximum %d", dimension.Scale.Min, dimension.Scale.Max)
+		}
+	}
+}
+
+func (v *validator) decision(decision *DecisionPoint, criteria *ScoringCriteria, path string) {
+	if decision == nil {
+		return
+	}
+	if len(decision.Branches) == 0 {
+		v.fail(path, "decision points require at least one branch")
+	}
+	if decision.Strategy != "" && !knownStrategies[decision.Strategy] {
+		v.fail(path, "unknown routing strategy %q", decision.Strategy)
+	}
+
+	for i, branch := range decision.Branches {
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+		for j, condition := range branch.Conditions {
+			conditionPath := fmt.Sprintf("%s.conditions[%d]", branchPath, j)
+			if condition.Field == "" && condition.FieldPath == "" {
+				v.fail(conditionPath, "conditions require a field")
+			}
+			if !knownOperators[condition.Operator] {
+				v.fail(conditionPath, "unknown operator %q", condition.Operator)
+			}
+			if (condition.Operator == OpIn || condition.Operator == OpNotIn) && !isList(condition.Value) {
+				v.fail(conditionPath, "%s requires a list value", condition.Operator)
+			}
+			// score conditions can only refer to the dimensions that are scored
+			if decision.Strategy == RouteByScore && criteria != nil {
+				if _, ok := criteria.Dimensions[condition.Field]; !ok {
+					v.fail(conditionPath, "field %q is not a scoring dimension", condition.Field)
+				}
+			}
+		}
+		if branch.Logic != nil {
+			v.definition(branch.Logic, branchPath+".logic")
+		}
+		v.definition(&branch.Then, branchPath+".then")
+	}
+}
+
+func (v *validator) loop(loop *RecursiveLoop, criteria *ScoringCriteria, path string) {
+	if loop == nil {
+		return
+	}
+	if loop.MaxIterations <= 0 {
+		v.fail(path, "recursive loops require at least one iteration")
+	}
+	if loop.Selection != "" && !knownSelections[loop.Selection] {
+		v.fail(path, "unknown selection strategy %q", loop.Selection)
+	}
+	if (loop.Selection == SelectHighestScore || loop.Selection == SelectLowestScore) && criteria == nil {
+		v.fail(path, "%s selection requires scoring criteria", loop.Selection)
+	}
+	v.decision(loop.TerminationPoint, criteria, path+".terminationPoint")
+}
+
+func (v *validator) modelConfig(config *ModelConfig, path string) {
+	if config == nil {
+		return
+	}
+	if config.Temperature < 0 || config.Temperature > 2 {
+		v.fail(path, "temperature %v must be between 0 and 2", config.Temperature)
+	}
+	if config.TopP < 0 || config.TopP > 1 {
+		v.fail(path, "top_p %v must be between 0 and 1", config.TopP)
+	}
+	if config.TopLogProbs > 0 && !config.LogProbs {
+		v.fail(path, "top_logprobs requires logprobs")
+	}
+}
+
+// isList reports whether a condition value holds a list, as OpIn and OpNotIn expect
+func isList(value any) bool {
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.Slice
+}
@@ -0,0 +1,41 @@
+package jsonSchema
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateListOperators(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		valid bool
+	}{
+		{"any slice", []any{"a", 1}, true},
+		{"strings", []string{"a"}, true},
+		{"int32", []int32{1, 2}, true},
+		{"float32", []float32{0.5}, true},
+		{"uint", []uint{1}, true},
+		{"empty typed slice", []int64{}, true},
+		{"string", "a", false},
+		{"number", 3, false},
+		{"nil", nil, false},
+		{"array", [2]int{1, 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := Definition{Type: String, DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{{
+				Conditions: []Condition{{Field: "level", Operator: OpIn, Value: tt.value}},
+				Then:       Definition{Type: String},
+			}}}}
+			err := def.Validate()
+			var validationErr ValidationError
+			if tt.valid && err != nil {
+				t.Errorf("Validate() = %v, want no error", err)
+			}
+			if !tt.valid && !errors.As(err, &validationErr) {
+				t.Errorf("Validate() = %v, want a ValidationError", err)
+			}
+		})
+	}
+}
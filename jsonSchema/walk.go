@@ -0,0 +1,202 @@
+package jsonSchema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SkipSubtree is returned by a Visitor's Pre hook to skip the children of the Definition it was called with.
+// It is not returned by Walk.
+var SkipSubtree = errors.New("skip subtree")
+
+// Visitor holds the hooks called by Walk, either of them may be nil.
+// Path uses the format of ValidationError: "$" for the root, ".key" for properties, "[]" for items,
+// ".*" for HashMap values and ".decisionPoint.branches[0].then" for branches.
+type Visitor struct {
+	Pre  func(path string, def *Definition) error //called before the children, return SkipSubtree to skip them
+	Post func(path string, def *Definition) error //called after the children, even when they were skipped
+}
+
+// Walk calls the visitor for the Definition and every Definition nested in it, depth first.
+// Children are Items, HashMap.FieldDefinition, Properties in sorted order, then the Logic and Then of the
+// DecisionPoint branches and of the RecursiveLoop TerminationPoint branches.
+// Changes the hooks make through the pointer are kept, including to properties.
+// Walk stops at the first error a hook returns, other than SkipSubtree, and returns it. A nil Definition is not walked.
+func Walk(def *Definition, visitor Visitor) error {
+	if def == nil {
+		return nil
+	}
+	return walkDefinition(def, "$", visitor)
+}
+
+// WalkComplexSystem walks the RootSchema of the system, the ModifyDefinition of every intervention rule
+// of the MainThread and its parent threads, and the Definitions and ComplexSystems used as the schema
+// of the LazyConfig's custom patterns. Other pattern schemas, such as raw JSON Schema maps, are not walked.
+func WalkComplexSystem(system *ComplexSystem, visitor Visitor) error {
+	if system == nil {
+		return nil
+	}
+	return walkSystem(system, "$", visitor)
+}
+
+// Rewrite returns a copy of the Definition with every Definition in it replaced by the result of rewrite.
+// The tree is rewritten bottom up, so rewrite receives Definitions whose children have already been rewritten.
+// The original Definition is not modified.
+func Rewrite(def Definition, rewrite func(path string, def Definition) (Definition, error)) (Definition, error) {
+	rewritten := def.Clone()
+	if err := Walk(&rewritten, rewriteVisitor(rewrite)); err != nil {
+		return Definition{}, err
+	}
+	return rewritten, nil
+}
+
+// RewriteComplexSystem is Rewrite for every Definition visited by WalkComplexSystem
+func RewriteComplexSystem(system ComplexSystem, rewrite func(path string, def Definition) (Definition, error)) (ComplexSystem, error) {
+	rewritten := system.Clone()
+	if err := WalkComplexSystem(&rewritten, rewriteVisitor(rewrite)); err != nil {
+		return ComplexSystem{}, err
+	}
+	return rewritten, nil
+}
+
+func rewriteVisitor(rewrite func(path string, def Definition) (Definition, error)) Visitor {
+	return Visitor{Post: func(path string, def *Definition) error {
+		replacement, err := rewrite(path, *def)
+		if err != nil {
+			return err
+		}
+		*def = replacement
+		return nil
+	}}
+}
+
+func walkSystem(system *ComplexSystem, path string, visitor Visitor) error {
+	if err := walkDefinition(&system.RootSchema, path+".rootSchema", visitor); err != nil {
+		return err
+	}
+	threadPath := path + ".mainThread"
+	for thread := system.MainThread; thread != nil; thread = thread.ParentThread {
+		for i := range thread.InterventionRules {
+			if modify := thread.InterventionRules[i].Action.ModifyDefinition; modify != nil {
+				if err := walkDefinition(modify, fmt.Sprintf("%s.interventionRules[%d].action.modifyDefinition", threadPath, i), visitor); err != nil {
+					return err
+				}
+			}
+		}
+		threadPath += ".parentThread"
+	}
+
+	if system.LazyConfig == nil || system.LazyConfig.PatternLibrary == nil {
+		return nil
+	}
+	patterns := system.LazyConfig.PatternLibrary.CustomPatterns
+	for i := range patterns {
+		if err := walkPatternSchema(&patterns[i], fmt.Sprintf("%s.lazyConfig.patternLibrary.customPatterns[%d].schema", path, i), visitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkPatternSchema walks the schema of a pattern when it is a Definition or ComplexSystem,
+// storing a schema held by value back so that changes are kept
+func walkPatternSchema(pattern *DecisionPattern, path string, visitor Visitor) error {
+	switch schema := pattern.Schema.(type) {
+	case Definition:
+		err := walkDefinition(&schema, path, visitor)
+		pattern.Schema = schema
+		return err
+	case *Definition:
+		if schema != nil {
+			return walkDefinition(schema, path, visitor)
+		}
+	case ComplexSystem:
+		err := walkSystem(&schema, path, visitor)
+		pattern.Schema = schema
+		return err
+	case *ComplexSystem:
+		if schema != nil {
+			return walkSystem(schema, path, visitor)
+		}
+	}
+	return nil
+}
+
+func walkDefinition(def *Definition, path string, visitor Visitor) error {
+	skip := false
+	if visitor.Pre != nil {
+		if err := visitor.Pre(path, def); errors.Is(err, SkipSubtree) {
+			skip = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if !skip {
+		if err := walkChildren(def, path, visitor); err != nil {
+			return err
+		}
+	}
+
+	if visitor.Post != nil {
+		if err := visitor.Post(path, def); err != nil && !errors.Is(err, SkipSubtree) {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkChildren(def *Definition, path string, visitor Visitor) error {
+	if def.Items != nil {
+		if err := walkDefinition(def.Items, path+"[]", visitor); err != nil {
+			return err
+		}
+	}
+	if def.HashMap != nil && def.HashMap.FieldDefinition != nil {
+		if err := walkDefinition(def.HashMap.FieldDefinition, path+".*", visitor); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(def.Properties))
+	for key := range def.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// map values are not addressable, so the property is walked as a copy and stored back
+		property := def.Properties[key]
+		if err := walkDefinition(&property, path+"."+key, visitor); err != nil {
+			return err
+		}
+		def.Properties[key] = property
+	}
+
+	if err := walkBranches(def.DecisionPoint, path+".decisionPoint", visitor); err != nil {
+		return err
+	}
+	if def.RecursiveLoop != nil {
+		return walkBranches(def.RecursiveLoop.TerminationPoint, path+".recursiveLoop.terminationPoint", visitor)
+	}
+	return nil
+}
+
+func walkBranches(decision *DecisionPoint, path string, visitor Visitor) error {
+	if decision == nil {
+		return nil
+	}
+	for i := range decision.Branches {
+		branch := &decision.Branches[i]
+		branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+		if branch.Logic != nil {
+			if err := walkDefinition(branch.Logic, branchPath+".logic", visitor); err != nil {
+				return err
+			}
+		}
+		if err := walkDefinition(&branch.Then, branchPath+".then", visitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
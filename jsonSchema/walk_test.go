@@ -0,0 +1,191 @@
+package jsonSchema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func walkTree() Definition {
+	return Definition{
+		Type: Object,
+		Properties: map[string]Definition{
+			"b":    {Type: Array, Items: &Definition{Type: String}},
+			"a":    {Type: Map, HashMap: &HashMap{FieldDefinition: &Definition{Type: Integer}}},
+			"skip": {Type: Object, Properties: map[string]Definition{"hidden": {Type: String}}},
+		},
+		DecisionPoint: &DecisionPoint{Branches: []ConditionalBranch{
+			{Logic: &Definition{Type: Boolean}, Then: Definition{Type: String}},
+		}},
+		RecursiveLoop: &RecursiveLoop{TerminationPoint: &DecisionPoint{Branches: []ConditionalBranch{
+			{Then: Definition{Type: String}},
+		}}},
+	}
+}
+
+func TestWalkOrder(t *testing.T) {
+	var events []string
+	def := walkTree()
+	err := Walk(&def, Visitor{
+		Pre: func(path string, d *Definition) error {
+			events = append(events, "pre "+path)
+			if path == "$.skip" {
+				return SkipSubtree
+			}
+			return nil
+		},
+		Post: func(path string, d *Definition) error {
+			if path == "$.a" || path == "$.skip" {
+				events = append(events, "post "+path)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"pre $",
+		"pre $.a", "pre $.a.*", "post $.a",
+		"pre $.b", "pre $.b[]",
+		"pre $.skip", "post $.skip",
+		"pre $.decisionPoint.branches[0].logic",
+		"pre $.decisionPoint.branches[0].then",
+		"pre $.recursiveLoop.terminationPoint.branches[0].then",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %q\nwant %q", events, want)
+	}
+}
+
+func TestWalkStopsAndEdits(t *testing.T) {
+	stop := errors.New("stop")
+	tests := []struct {
+		name    string
+		visitor Visitor
+		err     error
+		check   func(def Definition) bool
+	}{
+		{"pre error stops", Visitor{Pre: func(path string, d *Definition) error {
+			if path == "$.b" {
+				return stop
+			}
+			d.Model = "seen"
+			return nil
+		}}, stop, func(def Definition) bool {
+			return def.Properties["a"].Model == "seen" && def.Properties["skip"].Model == ""
+		}},
+		{"post error stops", Visitor{Post: func(path string, d *Definition) error {
+			if path == "$.a.*" {
+				return stop
+			}
+			return nil
+		}}, stop, func(def Definition) bool { return true }},
+		{"edits to properties are kept", Visitor{Pre: func(path string, d *Definition) error {
+			d.Instruction = path
+			return nil
+		}}, nil, func(def Definition) bool {
+			return def.Properties["skip"].Properties["hidden"].Instruction == "$.skip.hidden" && def.Items == nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := walkTree()
+			if err := Walk(&def, tt.visitor); !errors.Is(err, tt.err) {
+				t.Errorf("Walk error = %v, want %v", err, tt.err)
+			}
+			if !tt.check(def) {
+				t.Errorf("unexpected tree after walking: %+v", def)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	original := walkTree()
+	rewritten, err := Rewrite(original, func(path string, def Definition) (Definition, error) {
+		if def.Type == String {
+			def.Model = "cheap"
+		}
+		return def, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewritten.Properties["b"].Items.Model != "cheap" || rewritten.DecisionPoint.Branches[0].Then.Model != "cheap" {
+		t.Errorf("string definitions were not rewritten: %+v", rewritten)
+	}
+	if !reflect.DeepEqual(original, walkTree()) {
+		t.Error("Rewrite modified the original")
+	}
+
+	stop := errors.New("stop")
+	if _, err := Rewrite(original, func(string, Definition) (Definition, error) { return Definition{}, stop }); !errors.Is(err, stop) {
+		t.Errorf("Rewrite error = %v, want %v", err, stop)
+	}
+}
+
+func TestWalkComplexSystem(t *testing.T) {
+	system := ComplexSystem{
+		RootSchema: Definition{Type: String},
+		MainThread: &MainThreadConfig{
+			InterventionRules: []InterventionRule{{Action: InterventionAction{ModifyDefinition: &Definition{Type: String}}}},
+			ParentThread: &MainThreadConfig{
+				InterventionRules: []InterventionRule{{}, {Action: InterventionAction{ModifyDefinition: &Definition{Type: String}}}},
+			},
+		},
+		LazyConfig: &LazyConfigMode{PatternLibrary: &PatternLibraryConfig{CustomPatterns: []DecisionPattern{
+			{Schema: Definition{Type: String}},
+			{Schema: &Definition{Type: String}},
+			{Schema: map[string]interface{}{"type": "string"}},
+			{Schema: ComplexSystem{RootSchema: Definition{Type: String}}},
+			{Schema: &ComplexSystem{RootSchema: Definition{Type: String}}},
+		}}},
+	}
+	var paths []string
+	err := WalkComplexSystem(&system, Visitor{Pre: func(path string, d *Definition) error {
+		paths = append(paths, path)
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"$.rootSchema",
+		"$.mainThread.interventionRules[0].action.modifyDefinition",
+		"$.mainThread.parentThread.interventionRules[1].action.modifyDefinition",
+		"$.lazyConfig.patternLibrary.customPatterns[0].schema",
+		"$.lazyConfig.patternLibrary.customPatterns[1].schema",
+		"$.lazyConfig.patternLibrary.customPatterns[3].schema.rootSchema",
+		"$.lazyConfig.patternLibrary.customPatterns[4].schema.rootSchema",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+
+	// schemas held by value keep the rewritten copy
+	rewritten, err := RewriteComplexSystem(system, func(path string, def Definition) (Definition, error) {
+		def.Model = "cheap"
+		return def, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	patterns := rewritten.LazyConfig.PatternLibrary.CustomPatterns
+	if patterns[0].Schema.(Definition).Model != "cheap" || patterns[3].Schema.(ComplexSystem).RootSchema.Model != "cheap" {
+		t.Errorf("pattern schemas were not rewritten: %+v", patterns)
+	}
+	if system.LazyConfig.PatternLibrary.CustomPatterns[1].Schema.(*Definition).Model != "" {
+		t.Error("RewriteComplexSystem modified the original")
+	}
+}
+
+func TestWalkNil(t *testing.T) {
+	visitor := Visitor{Pre: func(string, *Definition) error { return errors.New("visited") }}
+	if err := Walk(nil, visitor); err != nil {
+		t.Errorf("Walk(nil) error = %v, want nil", err)
+	}
+	if err := WalkComplexSystem(nil, visitor); err != nil {
+		t.Errorf("WalkComplexSystem(nil) error = %v, want nil", err)
+	}
+}